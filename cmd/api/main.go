@@ -2,18 +2,26 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
 
 	_ "github.com/jorgediasdsg/pdf-expert/cmd/api/docs"
 	_ "github.com/swaggo/files"
 	_ "github.com/swaggo/gin-swagger"
 
 	_ "github.com/jorgediasdsg/pdf-expert/cmd/api/docs"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/cache"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/jobstore"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/ocr"
 	"github.com/jorgediasdsg/pdf-expert/internal/adapter/pdf"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/pii"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/termstats"
 	"github.com/jorgediasdsg/pdf-expert/internal/api"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
 	"github.com/jorgediasdsg/pdf-expert/internal/config"
 	"github.com/jorgediasdsg/pdf-expert/internal/log"
 	"github.com/jorgediasdsg/pdf-expert/internal/pdfanalyzer"
+	"github.com/jorgediasdsg/pdf-expert/internal/upload"
 )
 
 func main() {
@@ -22,20 +30,110 @@ func main() {
 	// Initialize global logger (dev or prod)
 	log.Init(cfg.Env)
 
+	// Uploads older than any upload in use are orphans of a previous
+	// run; younger ones may belong to another instance sharing the
+	// folder
+	if removed, err := upload.NewStager(cfg.TempFolder, cfg.MaxUploadBytes).Sweep(max(cfg.AnalysisTimeout, cfg.UploadRetention())); err != nil {
+		log.Logger.Error("upload_sweep_failed", "error", err)
+	} else if removed > 0 {
+		log.Logger.Info("upload_sweep", "removed", removed)
+	}
+
 	// Infra analyzer (old implementation)
-	infraAnalyzer := pdfanalyzer.NewPDFAnalyzer()
+	infraAnalyzer := pdfanalyzer.NewPDFAnalyzer(
+		pdfanalyzer.WithMaxPages(cfg.MaxPages),
+		pdfanalyzer.WithMaxStreamBytes(cfg.MaxStreamBytes),
+	)
 
 	// Adapter wrapping the infra analyzer as a Port implementation
-	analyzerAdapter := pdf.NewPDFAnalyzerAdapter(infraAnalyzer)
+	var analyzerAdapter port.PDFAnalyzerPort = pdf.NewPDFAnalyzerAdapter(infraAnalyzer)
+
+	// Optional result cache decorating the adapter
+	if resultCache := newResultCache(cfg); resultCache != nil {
+		analyzerAdapter = cache.NewCachingAnalyzer(analyzerAdapter, resultCache)
+	}
+
+	// Use case, recognizing image-only pages when an OCR engine is
+	// available and reporting the personal data of the pages
+	analyzeUseCase := usecase.NewAnalyzePDFUseCase(analyzerAdapter,
+		usecase.WithOCR(newOCREngine(cfg)),
+		usecase.WithTermStats(newTermStats(cfg)),
+		usecase.WithPIIDetector(newPIIDetector(cfg)),
+	)
+
+	// Asynchronous jobs run the same use case on a bounded worker pool
+	jobStore := jobstore.NewMemoryJobStore(cfg.JobRetention)
+	jobsUseCase := usecase.NewAnalysisJobsUseCase(analyzeUseCase, jobStore, cfg.JobWorkers, cfg.JobQueueSize,
+		usecase.WithUploadRetention(cfg.UploadRetention()),
+	)
 
-	// Use case
-	analyzeUseCase := usecase.NewAnalyzePDFUseCase(analyzerAdapter)
+	// Batches share a bounded pool of concurrent analyses
+	batchUseCase := usecase.NewAnalyzeBatchUseCase(analyzeUseCase, cfg.BatchConcurrency)
 
-	// Router (Gin) receives ONLY the use case
-	router := api.NewRouter(analyzeUseCase)
+	// Form fields and tables are read through the same adapter
+	formsUseCase := usecase.NewExtractFormsUseCase(analyzerAdapter)
+	tablesUseCase := usecase.NewExtractTablesUseCase(analyzerAdapter)
+
+	// Router (Gin) receives ONLY the use cases
+	router := api.NewRouter(analyzeUseCase, jobsUseCase, batchUseCase, formsUseCase, tablesUseCase)
 
 	addr := fmt.Sprintf(":%s", cfg.HTTPPort)
 	log.Logger.Info("server_started", "addr", addr)
 
 	router.Run(addr)
 }
+
+// newResultCache builds the cache backend selected by CACHE_BACKEND.
+// It returns nil when caching is disabled or the backend cannot be
+// initialized, in which case the service runs without a cache.
+func newResultCache(cfg config.Config) port.ResultCachePort {
+	switch cfg.CacheBackend {
+	case "memory":
+		return cache.NewMemoryCache(cfg.CacheMaxEntries, cfg.CacheTTL)
+	case "disk":
+		diskCache, err := cache.NewDiskCache(filepath.Join(cfg.TempFolder, "cache"), cfg.CacheMaxBytes, cfg.CacheTTL)
+		if err != nil {
+			log.Logger.Error("cache_disabled", "error", err)
+			return nil
+		}
+		return diskCache
+	default:
+		return nil
+	}
+}
+
+// newTermStats builds the document frequency store selected by
+// TERM_STATS_BACKEND, or nil when keywords are ranked by frequency
+// only.
+func newTermStats(cfg config.Config) port.TermStatsPort {
+	switch cfg.TermStatsBackend {
+	case "memory":
+		return termstats.NewMemoryTermStats(cfg.TermStatsMaxDocuments, cfg.TermStatsMaxTerms)
+	default:
+		return nil
+	}
+}
+
+// newPIIDetector builds the personal data detector selected by
+// PII_DETECTOR. It returns nil when detection is disabled or the
+// detector is unknown, in which case responses carry no PII report.
+func newPIIDetector(cfg config.Config) port.PIIDetectorPort {
+	detector, err := pii.New(cfg.PIIDetector)
+	if err != nil {
+		log.Logger.Error("pii_detection_disabled", "error", err)
+		return nil
+	}
+	return detector
+}
+
+// newOCREngine builds the OCR engine selected by OCR_ENGINE. It
+// returns nil when OCR is disabled or the engine cannot run, in which
+// case image-only pages are reported as scanned documents.
+func newOCREngine(cfg config.Config) port.OCRPort {
+	engine, err := ocr.New(cfg.OCREngine, cfg.TesseractPath, cfg.OCRLanguages)
+	if err != nil {
+		log.Logger.Error("ocr_disabled", "error", err)
+		return nil
+	}
+	return engine
+}
@@ -0,0 +1,41 @@
+package main
+
+import "github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+
+// Exit codes. When several files fail, the first failure in argument
+// order decides the code; an interrupted run always exits with
+// exitCancelled.
+const (
+	exitOK            = 0
+	exitFailure       = 1 // I/O or unexpected error
+	exitUsage         = 2 // bad flags or no input files
+	exitInvalidInput  = 3 // invalid settings or not a PDF
+	exitTooLarge      = 4 // file or document over a limit
+	exitUnprocessable = 5 // encrypted, corrupt or unsupported PDF; empty content; no OCR engine
+	exitTimeout       = 6 // domain.ErrAnalysisTimeout
+	exitCancelled     = 130
+)
+
+// kindExitCode is the exit code of each error kind of
+// dto.ErrorClasses. Kinds left out exit with exitFailure.
+var kindExitCode = map[dto.ErrorKind]int{
+	dto.KindInvalidInput:     exitInvalidInput,
+	dto.KindUnsupportedMedia: exitInvalidInput,
+	dto.KindTooLarge:         exitTooLarge,
+	dto.KindUnauthorized:     exitUnprocessable,
+	dto.KindUnprocessable:    exitUnprocessable,
+	dto.KindNotImplemented:   exitUnprocessable,
+	dto.KindTimeout:          exitTimeout,
+	dto.KindCancelled:        exitCancelled,
+}
+
+// exitCode maps an analysis error to the process exit code.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	if code, ok := kindExitCode[dto.ClassifyError(err).Kind]; ok {
+		return code
+	}
+	return exitFailure
+}
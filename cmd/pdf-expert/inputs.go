@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// expandInputs turns the command-line arguments into a list of files.
+// Arguments may be files, glob patterns (filepath.Match syntax, no
+// "**") or directories, which are walked recursively for files with a
+// .pdf extension. Explicit files are kept whatever their extension.
+// Duplicates are dropped and the order of first appearance is kept.
+func expandInputs(args []string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(p string) {
+		p = filepath.Clean(p)
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+
+	for _, arg := range args {
+		matches := []string{arg}
+		if hasGlobMeta(arg) {
+			m, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", arg, err)
+			}
+			if len(m) == 0 {
+				return nil, fmt.Errorf("%s: no matching files", arg)
+			}
+			matches = m
+		}
+
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				add(m)
+				continue
+			}
+			err = filepath.WalkDir(m, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.Type().IsRegular() && isPDFName(p) {
+					add(p)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, errors.New("no PDF files found")
+	}
+	return paths, nil
+}
+
+// hasGlobMeta reports whether arg contains filepath.Match syntax.
+func hasGlobMeta(arg string) bool {
+	return strings.ContainsAny(arg, `*?[`)
+}
+
+// isPDFName reports whether name has a .pdf extension, in any case.
+func isPDFName(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".pdf")
+}
@@ -0,0 +1,170 @@
+// Command pdf-expert analyzes local PDF files without starting the
+// HTTP server. It runs the same use case and adapter as the API, so
+// results and limits are identical.
+//
+// Usage:
+//
+//	pdf-expert [flags] <file|glob|dir>...
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/ocr"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/pdf"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/pii"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+	"github.com/jorgediasdsg/pdf-expert/internal/pdfanalyzer"
+)
+
+const usage = `Usage: pdf-expert [flags] <file|glob|dir>...
+
+Analyzes PDF files and prints one result per file, in argument order.
+Directories are searched recursively for *.pdf files. Limits are read
+from the same environment variables as the API (MAX_PAGES,
+MAX_STREAM_BYTES, ANALYSIS_TIMEOUT). The password of encrypted files
+can be given in PDF_EXPERT_PASSWORD instead of -password, which keeps
+it out of the process list. Image-only pages are recognized with the
+engine set in OCR_ENGINE (tesseract by default) when it is installed.
+
+Flags:
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// flagSet reports whether the flag name was given on the command line.
+func flagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// run is main without the process globals, so it can be tested.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	cfg := config.Load()
+
+	flags := flag.NewFlagSet("pdf-expert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	format := flags.String("format", "table", "output format: table, json or ndjson")
+	concurrency := flags.Int("concurrency", runtime.NumCPU(), "number of files analyzed in parallel")
+	password := flags.String("password", "", "user or owner password of encrypted files (default $PDF_EXPERT_PASSWORD)")
+	ocrMode := flags.String("ocr", string(dto.OCRAuto), "OCR of image-only pages: auto, force or off")
+	layout := flags.String("layout", string(dto.LayoutRaw), "text extraction: raw, reading or physical")
+	analyses := flags.String("analyses", "", "comma-separated text analyses to run: keywords")
+	keywordsTop := flags.Int("keywords-top", 0, "number of keywords reported, 1 to 100 (default 10)")
+	flags.DurationVar(&cfg.AnalysisTimeout, "timeout", cfg.AnalysisTimeout, "maximum duration of each analysis, 0 disables it")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	// The environment password is not the flag default: the usage
+	// output would print it.
+	if !flagSet(flags, "password") {
+		*password = os.Getenv("PDF_EXPERT_PASSWORD")
+	}
+	if *concurrency < 1 {
+		fmt.Fprintln(stderr, "pdf-expert: -concurrency must be at least 1")
+		return exitUsage
+	}
+
+	input := dto.AnalyzePDFInputDTO{
+		Password:    *password,
+		OCR:         dto.OCRMode(*ocrMode),
+		Layout:      dto.LayoutMode(*layout),
+		Analyses:    dto.ParseAnalyses(*analyses),
+		KeywordsTop: *keywordsTop,
+	}
+	if err := input.OCR.Validate(); err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: -ocr: %v\n", err)
+		return exitUsage
+	}
+	if err := input.Layout.Validate(); err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: -layout: %v\n", err)
+		return exitUsage
+	}
+	if err := input.ValidateOptions(); err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: %v\n", err)
+		return exitUsage
+	}
+
+	out, err := newResultWriter(*format, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: %v\n", err)
+		return exitUsage
+	}
+
+	paths, err := expandInputs(flags.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: %v\n", err)
+		return exitUsage
+	}
+
+	// Same wiring as cmd/api, minus the result cache: a one-shot
+	// process would never hit it.
+	infraAnalyzer := pdfanalyzer.NewPDFAnalyzer(
+		pdfanalyzer.WithMaxPages(cfg.MaxPages),
+		pdfanalyzer.WithMaxStreamBytes(cfg.MaxStreamBytes),
+	)
+
+	// A missing OCR engine only matters when OCR is forced; auto mode
+	// then reports image-only files as scanned documents.
+	engine, err := ocr.New(cfg.OCREngine, cfg.TesseractPath, cfg.OCRLanguages)
+	if err != nil && input.OCR == dto.OCRForce {
+		fmt.Fprintf(stderr, "pdf-expert: OCR disabled: %v\n", err)
+	}
+	detector, err := pii.New(cfg.PIIDetector)
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: PII detection disabled: %v\n", err)
+	}
+	analyzeUseCase := usecase.NewAnalyzePDFUseCase(pdf.NewPDFAnalyzerAdapter(infraAnalyzer), usecase.WithOCR(engine), usecase.WithPIIDetector(detector))
+
+	code := exitOK
+	err = analyzeAll(ctx, analyzeUseCase, cfg, paths, input, *concurrency, func(r result) error {
+		if r.Err != nil {
+			fmt.Fprintf(stderr, "pdf-expert: %s: %v\n", r.Path, r.Err)
+			if code == exitOK {
+				code = exitCode(r.Err)
+			}
+		}
+		return out.Write(r)
+	})
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf-expert: %v\n", err)
+		return exitFailure
+	}
+	if ctx.Err() != nil {
+		return exitCancelled
+	}
+	return code
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpandInputs(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.pdf")
+	b := filepath.Join(dir, "sub", "deep", "b.PDF")
+	c := filepath.Join(dir, "notes.txt")
+	writeFile(t, a, "%PDF-1.4")
+	writeFile(t, b, "%PDF-1.4")
+	writeFile(t, c, "text")
+
+	t.Run("directory is walked recursively", func(t *testing.T) {
+		got, err := expandInputs([]string{dir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{a, b}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("glob and explicit file, deduplicated", func(t *testing.T) {
+		got, err := expandInputs([]string{c, filepath.Join(dir, "*.pdf"), a})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{c, a}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{filepath.Join(dir, "missing.pdf")},
+			{filepath.Join(dir, "*.doc")},
+			{filepath.Join(dir, "sub", "deep", "none")},
+		} {
+			if _, err := expandInputs(args); err == nil {
+				t.Errorf("expandInputs(%v): expected error", args)
+			}
+		}
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, exitOK},
+		{dto.ErrUnsupportedMediaType, exitInvalidInput},
+		{dto.ErrInvalidPageRange, exitInvalidInput},
+		{fmt.Errorf("%w: 3000 pages", domain.ErrDocumentTooLarge), exitTooLarge},
+		{domain.ErrEmptyContent, exitUnprocessable},
+		{domain.ErrPasswordRequired, exitUnprocessable},
+		{fmt.Errorf("%w: cannot parse file", domain.ErrCorruptDocument), exitUnprocessable},
+		{domain.ErrOCRUnavailable, exitUnprocessable},
+		{domain.ErrAnalysisTimeout, exitTimeout},
+		{domain.ErrAnalysisCancelled, exitCancelled},
+		{os.ErrPermission, exitFailure},
+	}
+	for _, tc := range tests {
+		if got := exitCode(tc.err); got != tc.want {
+			t.Errorf("exitCode(%v) = %d; want %d", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestResultWriters(t *testing.T) {
+	results := []result{
+		{Path: "a.pdf", Output: dto.AnalyzePDFOutputDTO{WordCount: 12, Pages: make([]dto.PageDTO, 2)}},
+		{Path: "b.pdf", Err: domain.ErrAnalysisTimeout},
+	}
+	render := func(format string) string {
+		var buf bytes.Buffer
+		w, err := newResultWriter(format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range results {
+			if err := w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(render("ndjson")), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines; want 2", len(lines))
+		}
+		var rec record
+		if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Status != "failed" || rec.ExitCode != exitTimeout {
+			t.Errorf("unexpected record %+v", rec)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var recs []record
+		if err := json.Unmarshal([]byte(render("json")), &recs); err != nil {
+			t.Fatal(err)
+		}
+		if len(recs) != 2 || recs[0].WordCount != 12 || recs[0].PageCount != 2 {
+			t.Errorf("unexpected records %+v", recs)
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		out := render("table")
+		if !strings.HasPrefix(out, "FILE") || !strings.Contains(out, "failed: analysis timed out") {
+			t.Errorf("unexpected table:\n%s", out)
+		}
+	})
+
+	if _, err := newResultWriter("xml", &bytes.Buffer{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	notPDF := filepath.Join(dir, "fake.pdf")
+	writeFile(t, notPDF, "<html></html>")
+
+	t.Run("non-PDF file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-format", "ndjson", notPDF}, &stdout, &stderr)
+		if code != exitInvalidInput {
+			t.Errorf("exit code = %d; want %d (stderr: %s)", code, exitInvalidInput, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), `"status":"failed"`) {
+			t.Errorf("unexpected output %q", stdout.String())
+		}
+	})
+
+	t.Run("usage errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{},
+			{"-format", "xml", notPDF},
+			{"-concurrency", "0", notPDF},
+			{"-analyses", "summary", notPDF},
+			{"-keywords-top", "101", notPDF},
+			{"-bogus"},
+		} {
+			var stdout, stderr bytes.Buffer
+			if code := run(context.Background(), args, &stdout, &stderr); code != exitUsage {
+				t.Errorf("run(%v) = %d; want %d", args, code, exitUsage)
+			}
+		}
+	})
+
+	t.Run("usage hides the password", func(t *testing.T) {
+		t.Setenv("PDF_EXPERT_PASSWORD", "hunter2")
+		for _, args := range [][]string{{}, {"-h"}, {"-bogus"}} {
+			var stdout, stderr bytes.Buffer
+			run(context.Background(), args, &stdout, &stderr)
+			if !strings.Contains(stderr.String(), "-password") || strings.Contains(stderr.String()+stdout.String(), "hunter2") {
+				t.Errorf("run(%v) printed:\n%s", args, stderr.String())
+			}
+		}
+	})
+}
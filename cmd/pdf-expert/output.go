@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// resultWriter prints analysis results in one output format.
+type resultWriter interface {
+	Write(r result) error
+	Close() error
+}
+
+func newResultWriter(format string, w io.Writer) (resultWriter, error) {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "FILE\tPAGES\tWORDS\tSENTENCES\tPARAGRAPHS\tSTATUS")
+		return &tableWriter{tw: tw}, nil
+	case "json":
+		return &jsonWriter{w: w, records: []record{}}, nil
+	case "ndjson":
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want table, json or ndjson)", format)
+	}
+}
+
+// record is the JSON shape of a result. Field names follow the HTTP
+// API so both outputs can be consumed by the same code.
+type record struct {
+	File           string   `json:"file"`
+	Status         string   `json:"status"`
+	WordCount      int      `json:"word_count"`
+	TokenCount     int      `json:"token_count"`
+	SentenceCount  int      `json:"sentence_count"`
+	ParagraphCount int      `json:"paragraph_count"`
+	PageCount      int      `json:"page_count"`
+	Title          string   `json:"title,omitempty"`
+	Author         string   `json:"author,omitempty"`
+	Encrypted      bool     `json:"encrypted,omitempty"`
+	NeedsOCR       bool     `json:"needs_ocr,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	Keywords       []string `json:"keywords,omitempty"`
+	PIICount       *int     `json:"pii_count,omitempty"` // nil without a PII detector
+	Error          string   `json:"error,omitempty"`
+	ExitCode       int      `json:"exit_code,omitempty"`
+}
+
+func toRecord(r result) record {
+	if r.Err != nil {
+		return record{
+			File:     r.Path,
+			Status:   "failed",
+			Error:    r.Err.Error(),
+			ExitCode: exitCode(r.Err),
+		}
+	}
+	var keywords []string
+	if r.Output.Keywords != nil {
+		for _, k := range r.Output.Keywords.Keywords {
+			keywords = append(keywords, k.Text)
+		}
+	}
+	var piiCount *int
+	if r.Output.PII != nil {
+		piiCount = &r.Output.PII.Count
+	}
+	return record{
+		File:           r.Path,
+		Status:         "completed",
+		WordCount:      r.Output.WordCount,
+		TokenCount:     r.Output.TokenCount,
+		SentenceCount:  r.Output.SentenceCount,
+		ParagraphCount: r.Output.ParagraphCount,
+		PageCount:      len(r.Output.Pages),
+		Title:          r.Output.Metadata.Title,
+		Author:         r.Output.Metadata.Author,
+		Encrypted:      r.Output.Encryption.Encrypted,
+		NeedsOCR:       r.Output.NeedsOCR,
+		Language:       r.Output.Language.Code,
+		Keywords:       keywords,
+		PIICount:       piiCount,
+	}
+}
+
+// tableWriter aligns results in columns for humans.
+type tableWriter struct {
+	tw *tabwriter.Writer
+}
+
+func (t *tableWriter) Write(r result) error {
+	rec := toRecord(r)
+	status := rec.Status
+	if rec.Error != "" {
+		status += ": " + rec.Error
+	}
+	_, err := fmt.Fprintf(t.tw, "%s\t%d\t%d\t%d\t%d\t%s\n",
+		rec.File, rec.PageCount, rec.WordCount, rec.SentenceCount, rec.ParagraphCount, status)
+	return err
+}
+
+func (t *tableWriter) Close() error {
+	return t.tw.Flush()
+}
+
+// jsonWriter prints a single JSON array once every result is known.
+type jsonWriter struct {
+	w       io.Writer
+	records []record
+}
+
+func (j *jsonWriter) Write(r result) error {
+	j.records = append(j.records, toRecord(r))
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(j.records)
+}
+
+// ndjsonWriter prints one JSON object per line as results arrive,
+// for pipelines that stream the output.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(r result) error {
+	return n.enc.Encode(toRecord(r))
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
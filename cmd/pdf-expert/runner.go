@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+)
+
+// result is the outcome of analyzing one file.
+type result struct {
+	Path   string
+	Output dto.AnalyzePDFOutputDTO
+	Err    error
+}
+
+// analyzeAll analyzes paths on a pool of concurrency workers and
+// calls emit once per path, in input order, as soon as every earlier
+// path is done. It stops at the first emit error. Every file is
+// analyzed with the options of input, such as the password.
+func analyzeAll(ctx context.Context, uc *usecase.AnalyzePDFUseCase, cfg config.Config, paths []string, input dto.AnalyzePDFInputDTO, concurrency int, emit func(result) error) error {
+	type indexed struct {
+		i int
+		r result
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := make(chan int)
+	done := make(chan indexed)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(paths)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				done <- indexed{i: i, r: analyzeOne(ctx, uc, cfg, paths[i], input)}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(next)
+		for i := range paths {
+			select {
+			case next <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Reorder completions so output follows the arguments.
+	pending := make(map[int]result)
+	emitted := 0
+	var err error
+	for d := range done {
+		if err != nil {
+			continue // drain so workers can exit
+		}
+		pending[d.i] = d.r
+		for r, ok := pending[emitted]; ok; r, ok = pending[emitted] {
+			delete(pending, emitted)
+			emitted++
+			if err = emit(r); err != nil {
+				cancel()
+				break
+			}
+		}
+	}
+	return err
+}
+
+// analyzeOne runs the use case on a single file under the configured
+// analysis timeout.
+func analyzeOne(ctx context.Context, uc *usecase.AnalyzePDFUseCase, cfg config.Config, path string, input dto.AnalyzePDFInputDTO) result {
+	if err := checkSignature(path); err != nil {
+		return result{Path: path, Err: err}
+	}
+
+	ctx, cancel := cfg.AnalysisContext(ctx)
+	defer cancel()
+
+	input.FilePath = path
+	out, err := uc.Execute(ctx, input)
+	return result{Path: path, Output: out, Err: err}
+}
+
+// pdfMagic is the signature every PDF file starts with.
+var pdfMagic = []byte("%PDF-")
+
+// checkSignature rejects files that are not PDFs before the parser
+// sees them, mirroring the check applied to uploads.
+func checkSignature(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	head := make([]byte, len(pdfMagic))
+	if _, err := io.ReadFull(f, head); err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	if !bytes.Equal(head, pdfMagic) {
+		return dto.ErrUnsupportedMediaType
+	}
+	return nil
+}
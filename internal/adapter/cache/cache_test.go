@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// countingAnalyzer counts how many times the wrapped analyzer runs.
+type countingAnalyzer struct {
+	calls  int
+	result domain.AnalysisResult
+}
+
+func (c *countingAnalyzer) AnalyzeFile(ctx context.Context, path string, opts port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	c.calls++
+	return c.result, nil
+}
+
+func (c *countingAnalyzer) ExtractForms(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.FormField, error) {
+	return nil, nil
+}
+
+func (c *countingAnalyzer) ExtractImage(ctx context.Context, path string, opts port.AnalyzeOptions, n int) (domain.ImageData, error) {
+	return domain.ImageData{}, domain.ErrImageNotFound
+}
+
+func (c *countingAnalyzer) ExtractTables(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.Table, error) {
+	return nil, nil
+}
+
+func (c *countingAnalyzer) ExtractScans(ctx context.Context, path string, opts port.AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error) {
+	return nil, nil
+}
+
+func (c *countingAnalyzer) CountContent(pageTexts []string) domain.ContentStats {
+	return domain.ContentStats{}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	return path
+}
+
+func TestCachingAnalyzer_HitAndMiss(t *testing.T) {
+	dir := t.TempDir()
+	inner := &countingAnalyzer{result: domain.AnalysisResult{Content: "hello", WordCount: 1}}
+	analyzer := NewCachingAnalyzer(inner, NewMemoryCache(10, 0))
+
+	first, err := analyzer.AnalyzeFile(context.Background(), writeFile(t, dir, "a.pdf", "%PDF-1.4 same bytes"), port.AnalyzeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.FromCache {
+		t.Errorf("expected first analysis to be a miss")
+	}
+
+	// Same content under another name must hit.
+	second, err := analyzer.AnalyzeFile(context.Background(), writeFile(t, dir, "b.pdf", "%PDF-1.4 same bytes"), port.AnalyzeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !second.FromCache || second.WordCount != 1 {
+		t.Errorf("expected cached result, got %+v", second)
+	}
+
+	// Different content must miss.
+	if _, err := analyzer.AnalyzeFile(context.Background(), writeFile(t, dir, "c.pdf", "%PDF-1.4 other bytes"), port.AnalyzeOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if inner.calls != 2 {
+		t.Errorf("expected inner analyzer to run twice, got %d", inner.calls)
+	}
+}
+
+func TestCachingAnalyzer_PasswordBypassesCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	inner := &countingAnalyzer{result: domain.AnalysisResult{Content: "secret", WordCount: 1}}
+	analyzer := NewCachingAnalyzer(inner, NewMemoryCache(10, 0))
+	path := writeFile(t, dir, "locked.pdf", "%PDF-1.4 encrypted bytes")
+
+	if _, err := analyzer.AnalyzeFile(ctx, path, port.AnalyzeOptions{Password: "pw"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The decrypted result must not be served without the password.
+	res, err := analyzer.AnalyzeFile(ctx, path, port.AnalyzeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.FromCache {
+		t.Errorf("expected password-protected result not to be cached")
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected inner analyzer to run twice, got %d", inner.calls)
+	}
+}
+
+func TestCachingAnalyzer_LayoutsAreCachedSeparately(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingAnalyzer{result: domain.AnalysisResult{Content: "hello", WordCount: 1}}
+	analyzer := NewCachingAnalyzer(inner, NewMemoryCache(10, 0))
+	path := writeFile(t, t.TempDir(), "a.pdf", "%PDF-1.4 columns")
+
+	for _, layout := range []string{"", "reading", "physical", "raw", "reading"} {
+		if _, err := analyzer.AnalyzeFile(ctx, path, port.AnalyzeOptions{Layout: layout}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// "" and "raw" share an entry, as do both "reading" calls.
+	if inner.calls != 3 {
+		t.Errorf("expected inner analyzer to run 3 times, got %d", inner.calls)
+	}
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(2, 0)
+
+	_ = c.Set(ctx, "a", domain.AnalysisResult{Content: "a"})
+	_ = c.Set(ctx, "b", domain.AnalysisResult{Content: "b"})
+	_, _, _ = c.Get(ctx, "a") // "b" becomes least recently used
+	_ = c.Set(ctx, "c", domain.AnalysisResult{Content: "c"})
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(10, time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	_ = c.Set(ctx, "a", domain.AnalysisResult{Content: "a"})
+
+	now = now.Add(2 * time.Minute)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Errorf("expected expired entry to miss")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected expired entry to be removed")
+	}
+}
+
+func TestDiskCache_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	c, err := NewDiskCache(dir, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := domain.AnalysisResult{
+		Content:   "hello world",
+		WordCount: 2,
+		Pages:     []domain.PageAnalysis{{Number: 1, Text: "hello world", WordCount: 2}},
+		Metadata:  domain.Metadata{Title: "Doc", XMP: map[string]string{"dc:title": "Doc"}},
+	}
+	if err := c.Set(ctx, "abc123", want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A new instance over the same directory sees the entry.
+	reopened, _ := NewDiskCache(dir, 0, 0)
+	got, ok, err := reopened.Get(ctx, "abc123")
+	if err != nil || !ok {
+		t.Fatalf("expected hit, got ok=%v err=%v", ok, err)
+	}
+	if got.WordCount != 2 || len(got.Pages) != 1 || got.Metadata.XMP["dc:title"] != "Doc" {
+		t.Errorf("unexpected cached result: %+v", got)
+	}
+}
+
+func TestDiskCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	c, _ := NewDiskCache(t.TempDir(), 0, time.Hour)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	_ = c.Set(ctx, "abc", domain.AnalysisResult{Content: "a"})
+
+	now = now.Add(2 * time.Hour)
+	if _, ok, _ := c.Get(ctx, "abc"); ok {
+		t.Errorf("expected expired entry to miss")
+	}
+}
+
+func TestDiskCache_SizeLimit(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	result := domain.AnalysisResult{Content: "some content that takes space", WordCount: 5}
+
+	// Measure one entry, then allow room for two of them.
+	probe, _ := NewDiskCache(t.TempDir(), 0, 0)
+	_ = probe.Set(ctx, "ab", result)
+	info, err := os.Stat(filepath.Join(probe.dir, "ab.json"))
+	if err != nil {
+		t.Fatalf("stat probe entry: %v", err)
+	}
+	c, _ := NewDiskCache(dir, 2*info.Size()+info.Size()/2, 0)
+
+	now := time.Now()
+	for i, key := range []string{"a1", "b2", "c3"} {
+		stamp := now.Add(time.Duration(i) * time.Second)
+		c.now = func() time.Time { return stamp }
+		_ = c.Set(ctx, key, result)
+		_ = os.Chtimes(filepath.Join(dir, key+".json"), stamp, stamp)
+	}
+
+	entries, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(entries) >= 3 {
+		t.Fatalf("expected eviction to keep the directory under the limit, got %d files", len(entries))
+	}
+	if _, ok, _ := c.Get(ctx, "c3"); !ok {
+		t.Errorf("expected most recent entry to be kept")
+	}
+	if _, ok, _ := c.Get(ctx, "a1"); ok {
+		t.Errorf("expected oldest entry to be evicted")
+	}
+}
+
+func TestDiskCache_RejectsInvalidKeys(t *testing.T) {
+	c, _ := NewDiskCache(t.TempDir(), 0, 0)
+
+	for _, key := range []string{"", "../escape", "ABC", "a/b"} {
+		if err := c.Set(context.Background(), key, domain.AnalysisResult{}); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
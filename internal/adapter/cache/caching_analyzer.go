@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// Ensure interface compliance
+var _ port.PDFAnalyzerPort = (*CachingAnalyzer)(nil)
+
+// CachingAnalyzer decorates a PDFAnalyzerPort with a
+// content-addressed result cache. The key is the SHA-256 of the
+// file bytes, so the same document uploaded under different names
+// (or paths) is parsed only once.
+//
+// Cache failures never fail an analysis: a broken backend behaves
+// like an empty cache.
+type CachingAnalyzer struct {
+	inner port.PDFAnalyzerPort
+	cache port.ResultCachePort
+}
+
+// NewCachingAnalyzer wraps inner with the given cache backend.
+func NewCachingAnalyzer(inner port.PDFAnalyzerPort, cache port.ResultCachePort) *CachingAnalyzer {
+	return &CachingAnalyzer{inner: inner, cache: cache}
+}
+
+// AnalyzeFile returns the cached result for the file content when
+// present, and analyzes and stores it otherwise. Results served from
+// the cache have FromCache set. Each layout mode is cached separately.
+//
+// Calls with a password bypass the cache: the content of a document
+// that needed one must not be served to a caller who did not supply it.
+func (a *CachingAnalyzer) AnalyzeFile(ctx context.Context, path string, opts port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	if opts.Password != "" {
+		return a.inner.AnalyzeFile(ctx, path, opts)
+	}
+
+	key, err := hashFile(path, opts.Layout)
+	if err != nil {
+		return a.inner.AnalyzeFile(ctx, path, opts)
+	}
+
+	if result, ok, err := a.cache.Get(ctx, key); err == nil && ok {
+		result.FromCache = true
+		return result, nil
+	}
+
+	result, err := a.inner.AnalyzeFile(ctx, path, opts)
+	if err != nil {
+		return domain.AnalysisResult{}, err
+	}
+
+	_ = a.cache.Set(ctx, key, result)
+	return result, nil
+}
+
+// hashFile returns the hex-encoded SHA-256 of the file at path,
+// followed by the layout mode unless it is the default one.
+func hashFile(path, layout string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	if layout != "" && layout != "raw" {
+		h.Write([]byte("\x00layout=" + layout))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ExtractForms is not cached: it reads only the form dictionaries and
+// is cheap compared to a full analysis.
+func (a *CachingAnalyzer) ExtractForms(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.FormField, error) {
+	return a.inner.ExtractForms(ctx, path, opts)
+}
+
+// ExtractImage is not cached: images are fetched one at a time and
+// would crowd analysis results out of the cache.
+func (a *CachingAnalyzer) ExtractImage(ctx context.Context, path string, opts port.AnalyzeOptions, n int) (domain.ImageData, error) {
+	return a.inner.ExtractImage(ctx, path, opts, n)
+}
+
+// ExtractScans is not cached: OCR runs after the cache, so results
+// do not depend on the engine available when they were stored.
+func (a *CachingAnalyzer) ExtractScans(ctx context.Context, path string, opts port.AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error) {
+	return a.inner.ExtractScans(ctx, path, opts, scans)
+}
+
+// CountContent reads no file and is not cached.
+func (a *CachingAnalyzer) CountContent(pageTexts []string) domain.ContentStats {
+	return a.inner.CountContent(pageTexts)
+}
+
+// ExtractTables is not cached: its results depend on the page ranges
+// and it is requested far less often than an analysis.
+func (a *CachingAnalyzer) ExtractTables(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.Table, error) {
+	return a.inner.ExtractTables(ctx, path, opts)
+}
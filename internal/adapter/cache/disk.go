@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// Ensure interface compliance
+var _ port.ResultCachePort = (*DiskCache)(nil)
+
+// DiskCache stores results as JSON files named after their key,
+// so cached analyses survive restarts. The total size of the
+// directory is bounded by maxBytes; when it is exceeded, the
+// least recently used files are removed first. Reads refresh the
+// file modification time, which is what recency is based on.
+type DiskCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// diskEntry is the on-disk representation of a cached result.
+type diskEntry struct {
+	StoredAt time.Time             `json:"stored_at"`
+	Result   domain.AnalysisResult `json:"result"`
+}
+
+// NewDiskCache creates the cache directory if needed. A maxBytes
+// or ttl of zero disables the corresponding limit.
+func NewDiskCache(dir string, maxBytes int64, ttl time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir, maxBytes: maxBytes, ttl: ttl, now: time.Now}, nil
+}
+
+func (c *DiskCache) Get(ctx context.Context, key string) (domain.AnalysisResult, bool, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return domain.AnalysisResult{}, false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.AnalysisResult{}, false, nil
+	}
+	if err != nil {
+		return domain.AnalysisResult{}, false, err
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		// A corrupt entry is dropped and treated as a miss.
+		_ = os.Remove(path)
+		return domain.AnalysisResult{}, false, nil
+	}
+
+	if c.ttl > 0 && c.now().Sub(entry.StoredAt) > c.ttl {
+		_ = os.Remove(path)
+		return domain.AnalysisResult{}, false, nil
+	}
+
+	now := c.now()
+	_ = os.Chtimes(path, now, now)
+
+	return entry.Result, true, nil
+}
+
+func (c *DiskCache) Set(ctx context.Context, key string, result domain.AnalysisResult) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(diskEntry{StoredAt: c.now(), Result: result})
+	if err != nil {
+		return err
+	}
+	if c.maxBytes > 0 && int64(len(data)) > c.maxBytes {
+		// Larger than the whole cache: not worth evicting everything for.
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Write to a temporary file first so readers never see a
+	// partially written entry.
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return c.evictLocked()
+}
+
+// evictLocked removes the least recently used entries until the
+// directory fits in maxBytes. The caller must hold c.mu.
+func (c *DiskCache) evictLocked() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type file struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var (
+		files []file
+		total int64
+	)
+	for _, de := range dirEntries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{filepath.Join(c.dir, de.Name()), info.Size(), info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(f.path); err == nil {
+			total -= f.size
+		}
+	}
+	return nil
+}
+
+// path maps a key to its file. Keys are hex digests; anything else
+// is rejected so a key can never point outside the cache directory.
+func (c *DiskCache) path(key string) (string, error) {
+	if key == "" || strings.Trim(key, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	return filepath.Join(c.dir, key+".json"), nil
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// Ensure interface compliance
+var _ port.ResultCachePort = (*MemoryCache)(nil)
+
+// MemoryCache is an in-process LRU cache bounded by entry count,
+// with an optional time-to-live per entry.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	order      *list.List // front = most recently used
+	entries    map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key      string
+	result   domain.AnalysisResult
+	storedAt time.Time
+}
+
+// NewMemoryCache creates an LRU cache holding at most maxEntries
+// results. A ttl of zero disables expiration.
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (domain.AnalysisResult, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return domain.AnalysisResult{}, false, nil
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if c.expired(entry.storedAt) {
+		c.removeLocked(el)
+		return domain.AnalysisResult{}, false, nil
+	}
+
+	c.order.MoveToFront(el)
+	return entry.result, true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, result domain.AnalysisResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.result = result
+		entry.storedAt = c.now()
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryEntry{key: key, result: result, storedAt: c.now()})
+
+	for c.order.Len() > c.maxEntries {
+		c.removeLocked(c.order.Back())
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, expired or not.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryCache) expired(storedAt time.Time) bool {
+	return c.ttl > 0 && c.now().Sub(storedAt) > c.ttl
+}
+
+// removeLocked drops an entry. The caller must hold c.mu.
+func (c *MemoryCache) removeLocked(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,97 @@
+package jobstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+)
+
+// Ensure interface compliance
+var _ port.JobStorePort = (*MemoryJobStore)(nil)
+
+// MemoryJobStore keeps jobs in process memory. Jobs are lost
+// on restart, which is acceptable while a single instance
+// serves the API.
+//
+// Finished jobs are evicted once they are older than the
+// retention period, so the store does not grow without bound:
+// all of them on the next insertion, and an expired job as soon
+// as it is read.
+type MemoryJobStore struct {
+	mu        sync.Mutex
+	jobs      map[string]dto.JobDTO
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewMemoryJobStore creates an empty store. A retention of
+// zero keeps finished jobs forever.
+func NewMemoryJobStore(retention time.Duration) *MemoryJobStore {
+	return &MemoryJobStore{
+		jobs:      make(map[string]dto.JobDTO),
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryJobStore) Create(ctx context.Context, job dto.JobDTO) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictLocked()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *MemoryJobStore) Get(ctx context.Context, id string) (dto.JobDTO, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return dto.JobDTO{}, dto.ErrJobNotFound
+	}
+	if s.expired(job, s.now()) {
+		delete(s.jobs, id)
+		return dto.JobDTO{}, dto.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (s *MemoryJobStore) Update(ctx context.Context, id string, fn func(job *dto.JobDTO)) (dto.JobDTO, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return dto.JobDTO{}, dto.ErrJobNotFound
+	}
+
+	fn(&job)
+	s.jobs[id] = job
+	return job, nil
+}
+
+// evictLocked drops finished jobs past the retention period.
+// The caller must hold s.mu.
+func (s *MemoryJobStore) evictLocked() {
+	if s.retention <= 0 {
+		return
+	}
+
+	now := s.now()
+	for id, job := range s.jobs {
+		if s.expired(job, now) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// expired reports whether job finished more than the retention
+// period before now.
+func (s *MemoryJobStore) expired(job dto.JobDTO, now time.Time) bool {
+	return s.retention > 0 && job.Status.IsTerminal() && job.FinishedAt.Before(now.Add(-s.retention))
+}
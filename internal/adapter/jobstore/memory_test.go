@@ -0,0 +1,58 @@
+package jobstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+func TestMemoryJobStore_EvictsFinishedJobs(t *testing.T) {
+	store := NewMemoryJobStore(time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	_ = store.Create(ctx, dto.JobDTO{ID: "old", Status: dto.JobSucceeded, FinishedAt: now.Add(-2 * time.Minute)})
+	_ = store.Create(ctx, dto.JobDTO{ID: "recent", Status: dto.JobFailed, FinishedAt: now.Add(-30 * time.Second)})
+	_ = store.Create(ctx, dto.JobDTO{ID: "running", Status: dto.JobRunning})
+
+	// Eviction happens on the next insertion.
+	_ = store.Create(ctx, dto.JobDTO{ID: "new", Status: dto.JobQueued})
+
+	if _, err := store.Get(ctx, "old"); !errors.Is(err, dto.ErrJobNotFound) {
+		t.Errorf("expected old job to be evicted, got %v", err)
+	}
+	for _, id := range []string{"recent", "running", "new"} {
+		if _, err := store.Get(ctx, id); err != nil {
+			t.Errorf("expected job %s to be kept, got %v", id, err)
+		}
+	}
+
+	// Without insertions, an expired job is evicted when read.
+	now = now.Add(time.Minute)
+	if _, err := store.Get(ctx, "recent"); !errors.Is(err, dto.ErrJobNotFound) {
+		t.Errorf("expected expired job to be evicted, got %v", err)
+	}
+	if _, ok := store.jobs["recent"]; ok {
+		t.Errorf("expected expired job to be removed from the store")
+	}
+}
+
+func TestMemoryJobStore_Update(t *testing.T) {
+	store := NewMemoryJobStore(0)
+	ctx := context.Background()
+
+	_ = store.Create(ctx, dto.JobDTO{ID: "a", Status: dto.JobQueued})
+
+	job, err := store.Update(ctx, "a", func(job *dto.JobDTO) { job.Status = dto.JobRunning })
+	if err != nil || job.Status != dto.JobRunning {
+		t.Fatalf("expected running job, got %+v (%v)", job, err)
+	}
+
+	if _, err := store.Update(ctx, "missing", func(*dto.JobDTO) {}); !errors.Is(err, dto.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
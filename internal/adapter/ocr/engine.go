@@ -0,0 +1,34 @@
+package ocr
+
+import (
+	"fmt"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+)
+
+// Engine names, as set in OCR_ENGINE.
+const (
+	EngineTesseract = "tesseract"
+	EngineFake      = "fake"
+	EngineNone      = "none"
+)
+
+// New builds the engine selected by name. It returns a nil port for
+// EngineNone, and an error when the engine is unknown or cannot run,
+// so callers can run without OCR.
+func New(name, tesseractPath, languages string) (port.OCRPort, error) {
+	switch name {
+	case EngineTesseract:
+		t, err := NewTesseract(tesseractPath, languages)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+	case EngineFake:
+		return Fake{}, nil
+	case EngineNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown OCR engine %q", name)
+	}
+}
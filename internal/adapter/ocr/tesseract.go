@@ -0,0 +1,121 @@
+package ocr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// Ensure interface compliance
+var _ port.OCRPort = (*Tesseract)(nil)
+
+// ErrUnsupportedImage is returned for image formats the engine cannot
+// read, such as raw JBIG2 or CCITT streams.
+var ErrUnsupportedImage = errors.New("image format not supported by the OCR engine")
+
+// Tesseract runs a locally installed tesseract binary, one process
+// per image. The image is piped on stdin and the words are read back
+// as TSV, which carries their confidence.
+type Tesseract struct {
+	binary    string
+	languages string // e.g. "eng+por"
+}
+
+// NewTesseract locates binary, a name looked up in PATH or a path, and
+// fails when it cannot be found.
+func NewTesseract(binary, languages string) (*Tesseract, error) {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("tesseract not found: %w", err)
+	}
+	if languages == "" {
+		languages = "eng"
+	}
+	return &Tesseract{binary: path, languages: languages}, nil
+}
+
+// Recognize runs tesseract on img. The process is killed once ctx is
+// done.
+func (t *Tesseract) Recognize(ctx context.Context, img domain.ImageData) (domain.OCRResult, error) {
+	switch img.ContentType {
+	case "image/jpeg", "image/png", "image/jp2", "image/tiff":
+	default:
+		return domain.OCRResult{}, fmt.Errorf("%w: %s", ErrUnsupportedImage, img.ContentType)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.binary, "stdin", "stdout", "-l", t.languages, "tsv")
+	cmd.Stdin = bytes.NewReader(img.Data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return domain.OCRResult{}, ctx.Err()
+		}
+		return domain.OCRResult{}, fmt.Errorf("tesseract: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseTSV(&stdout)
+}
+
+// parseTSV rebuilds the text from tesseract's TSV output: words of a
+// line are joined by spaces, lines end with a newline and paragraphs
+// are separated by an empty line. The confidence is the mean of the
+// word confidences.
+func parseTSV(out *bytes.Buffer) (domain.OCRResult, error) {
+	var (
+		text             strings.Builder
+		lastPar, lastLin string
+		confSum          float64
+		words            int
+	)
+
+	sc := bufio.NewScanner(out)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		// level page block par line word left top width height conf text
+		cols := strings.SplitN(sc.Text(), "\t", 12)
+		if len(cols) != 12 || cols[0] != "5" {
+			continue // header and page, block, paragraph, line rows
+		}
+		word := strings.TrimSpace(cols[11])
+		conf, err := strconv.ParseFloat(cols[10], 64)
+		if word == "" || err != nil || conf < 0 {
+			continue
+		}
+
+		par := cols[2] + "." + cols[3]
+		line := par + "." + cols[4]
+		switch {
+		case words == 0:
+		case par != lastPar:
+			text.WriteString("\n\n")
+		case line != lastLin:
+			text.WriteString("\n")
+		default:
+			text.WriteString(" ")
+		}
+		text.WriteString(word)
+		lastPar, lastLin = par, line
+
+		confSum += conf
+		words++
+	}
+	if err := sc.Err(); err != nil {
+		return domain.OCRResult{}, fmt.Errorf("tesseract output: %w", err)
+	}
+	if words == 0 {
+		return domain.OCRResult{}, nil
+	}
+
+	text.WriteString("\n")
+	return domain.OCRResult{Text: text.String(), Confidence: confSum / float64(words) / 100}, nil
+}
@@ -0,0 +1,84 @@
+package ocr
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// tsv is tesseract output for two paragraphs, the first on two lines.
+const tsv = "level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n" +
+	"1\t1\t0\t0\t0\t0\t0\t0\t2550\t3300\t-1\t\n" +
+	"4\t1\t1\t1\t1\t0\t100\t100\t800\t40\t-1\t\n" +
+	"5\t1\t1\t1\t1\t1\t100\t100\t300\t40\t96\tService\n" +
+	"5\t1\t1\t1\t1\t2\t420\t100\t400\t40\t90\tagreement\n" +
+	"5\t1\t1\t1\t2\t1\t100\t160\t300\t40\t84\tbetween\n" +
+	"5\t1\t1\t2\t1\t1\t100\t260\t300\t40\t-1\t \n" +
+	"5\t1\t2\t1\t1\t1\t100\t400\t300\t40\t70\tparties.\n"
+
+// fakeTesseract writes a script that checks its arguments and prints
+// out, standing in for the tesseract binary.
+func fakeTesseract(t *testing.T, out string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on Windows")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "out.tsv"), []byte(out), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\n" +
+		"[ \"$*\" = \"stdin stdout -l eng+por tsv\" ] || { echo \"bad arguments: $*\" >&2; exit 1; }\n" +
+		"cat > /dev/null\n" +
+		"cat \"" + filepath.Join(dir, "out.tsv") + "\"\n"
+	bin := filepath.Join(dir, "tesseract")
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return bin
+}
+
+func TestTesseract_Recognize(t *testing.T) {
+	engine, err := NewTesseract(fakeTesseract(t, tsv), "eng+por")
+	if err != nil {
+		t.Fatalf("NewTesseract: %v", err)
+	}
+
+	res, err := engine.Recognize(context.Background(), domain.ImageData{ContentType: "image/png", Data: []byte("png")})
+	if err != nil {
+		t.Fatalf("Recognize: %v", err)
+	}
+	if want := "Service agreement\nbetween\n\nparties.\n"; res.Text != want {
+		t.Errorf("text = %q, want %q", res.Text, want)
+	}
+	if res.Confidence != 0.85 {
+		t.Errorf("confidence = %v, want 0.85", res.Confidence)
+	}
+
+	// Raw JBIG2 or CCITT streams never reach the binary.
+	_, err = engine.Recognize(context.Background(), domain.ImageData{ContentType: "application/octet-stream"})
+	if !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("expected ErrUnsupportedImage, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if engine, err := New(EngineNone, "", ""); engine != nil || err != nil {
+		t.Errorf("none: got %v, %v", engine, err)
+	}
+	if engine, err := New(EngineFake, "", ""); engine == nil || err != nil {
+		t.Errorf("fake: got %v, %v", engine, err)
+	}
+	// A missing binary yields a nil interface, not a nil *Tesseract.
+	if engine, err := New(EngineTesseract, filepath.Join(t.TempDir(), "missing"), "eng"); engine != nil || err == nil {
+		t.Errorf("missing tesseract: got %v, %v", engine, err)
+	}
+	if _, err := New("abbyy", "", ""); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
@@ -0,0 +1,169 @@
+package pdf
+
+import (
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+	"github.com/jorgediasdsg/pdf-expert/internal/pdfanalyzer"
+)
+
+// The functions below translate infra types from internal/pdfanalyzer
+// into domain types. They are kept apart from the adapter so that the
+// adapter itself reads as a plain port implementation.
+
+func toDomainPages(pages []pdfanalyzer.PageAnalysis) []domain.PageAnalysis {
+	out := make([]domain.PageAnalysis, 0, len(pages))
+	for _, p := range pages {
+		out = append(out, domain.PageAnalysis{
+			Number:    p.Number,
+			Text:      p.Text,
+			WordCount: p.WordCount,
+			CharCount: p.CharCount,
+			Width:     p.Width,
+			Height:    p.Height,
+			Rotation:  p.Rotation,
+			Language:  toDomainLanguage(p.Language),
+		})
+	}
+	return out
+}
+
+func toDomainLanguage(l pdfanalyzer.Language) domain.Language {
+	return domain.Language{Code: l.Code, Confidence: l.Confidence}
+}
+
+func toDomainMetadata(md pdfanalyzer.Metadata) domain.Metadata {
+	return domain.Metadata{
+		Title:            md.Title,
+		Author:           md.Author,
+		Subject:          md.Subject,
+		Keywords:         md.Keywords,
+		Creator:          md.Creator,
+		Producer:         md.Producer,
+		CreationDate:     md.CreationDate,
+		ModificationDate: md.ModificationDate,
+		PDFVersion:       md.PDFVersion,
+		PageCount:        md.PageCount,
+		XMP:              md.XMP,
+	}
+}
+
+func toDomainEncryption(e pdfanalyzer.Encryption) domain.Encryption {
+	return domain.Encryption{
+		Encrypted: e.Encrypted,
+		Filter:    e.Filter,
+		Version:   e.Version,
+		Revision:  e.Revision,
+		KeyLength: e.KeyLength,
+		Algorithm: e.Algorithm,
+		Permissions: domain.Permissions{
+			Print:            e.Permissions.Print,
+			Modify:           e.Permissions.Modify,
+			Copy:             e.Permissions.Copy,
+			Annotate:         e.Permissions.Annotate,
+			FillForms:        e.Permissions.FillForms,
+			ExtractForAccess: e.Permissions.ExtractForAccess,
+			Assemble:         e.Permissions.Assemble,
+			PrintHighQuality: e.Permissions.PrintHighQuality,
+		},
+	}
+}
+
+func toDomainOutline(o pdfanalyzer.Outline) domain.Outline {
+	return domain.Outline{Source: o.Source, Items: toDomainOutlineItems(o.Items)}
+}
+
+func toDomainOutlineItems(items []pdfanalyzer.OutlineItem) []domain.OutlineItem {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]domain.OutlineItem, 0, len(items))
+	for _, it := range items {
+		out = append(out, domain.OutlineItem{
+			Title:    it.Title,
+			Level:    it.Level,
+			Page:     it.Page,
+			Children: toDomainOutlineItems(it.Children),
+		})
+	}
+	return out
+}
+
+func toDomainAnnotations(annotations []pdfanalyzer.Annotation) []domain.Annotation {
+	out := make([]domain.Annotation, 0, len(annotations))
+	for _, a := range annotations {
+		out = append(out, domain.Annotation{
+			Page:            a.Page,
+			Type:            a.Type,
+			Rect:            domain.Rect(a.Rect),
+			Contents:        a.Contents,
+			Author:          a.Author,
+			Modified:        a.Modified,
+			URI:             a.URI,
+			DestinationPage: a.DestinationPage,
+			MarkedText:      a.MarkedText,
+			Stamp:           a.Stamp,
+		})
+	}
+	return out
+}
+
+func toDomainImages(images []pdfanalyzer.Image) []domain.Image {
+	out := make([]domain.Image, 0, len(images))
+	for _, img := range images {
+		out = append(out, domain.Image(img))
+	}
+	return out
+}
+
+func toDomainFonts(fonts []pdfanalyzer.Font) []domain.Font {
+	out := make([]domain.Font, 0, len(fonts))
+	for _, f := range fonts {
+		out = append(out, domain.Font(f))
+	}
+	return out
+}
+
+func toDomainTables(tables []pdfanalyzer.Table) []domain.Table {
+	out := make([]domain.Table, 0, len(tables))
+	for _, t := range tables {
+		table := domain.Table{
+			Page:    t.Page,
+			Method:  t.Method,
+			Rect:    domain.Rect(t.Rect),
+			Rows:    t.Rows,
+			Columns: t.Cols,
+			Cells:   make([]domain.TableCell, 0, len(t.Cells)),
+		}
+		for _, c := range t.Cells {
+			table.Cells = append(table.Cells, domain.TableCell{
+				Row:        c.Row,
+				Column:     c.Col,
+				RowSpan:    c.RowSpan,
+				ColumnSpan: c.ColSpan,
+				Text:       c.Text,
+			})
+		}
+		out = append(out, table)
+	}
+	return out
+}
+
+func toDomainFormFields(fields []pdfanalyzer.FormField) []domain.FormField {
+	out := make([]domain.FormField, 0, len(fields))
+	for _, f := range fields {
+		field := domain.FormField{
+			Name:     f.Name,
+			Type:     f.Type,
+			Value:    f.Value,
+			Values:   f.Values,
+			Required: f.Required,
+			ReadOnly: f.ReadOnly,
+			Page:     f.Page,
+			Rect:     domain.Rect(f.Rect),
+		}
+		for _, o := range f.Options {
+			field.Options = append(field.Options, domain.FormOption(o))
+		}
+		out = append(out, field)
+	}
+	return out
+}
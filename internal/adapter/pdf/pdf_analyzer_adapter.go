@@ -1,6 +1,10 @@
 package pdf
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
 	"github.com/jorgediasdsg/pdf-expert/internal/domain"
 	"github.com/jorgediasdsg/pdf-expert/internal/pdfanalyzer"
@@ -27,14 +31,111 @@ func NewPDFAnalyzerAdapter(inner *pdfanalyzer.PDFAnalyzer) port.PDFAnalyzerPort
 
 // AnalyzeFile calls the underlying PDFAnalyzer and
 // maps its result into the domain.AnalysisResult type.
-func (a *PDFAnalyzerAdapter) AnalyzeFile(path string) (domain.AnalysisResult, error) {
-	res, err := a.inner.AnalyzeFile(path)
+func (a *PDFAnalyzerAdapter) AnalyzeFile(ctx context.Context, path string, opts port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	res, err := a.inner.AnalyzeFile(ctx, path, pdfanalyzer.WithPassword(opts.Password), pdfanalyzer.WithLayout(opts.Layout))
 	if err != nil {
-		return domain.AnalysisResult{}, err
+		return domain.AnalysisResult{}, toDomainError(err)
 	}
 
 	return domain.AnalysisResult{
-		Content:   res.Content,
-		WordCount: res.WordCount,
+		Content:        res.Content,
+		WordCount:      res.WordCount,
+		TokenCount:     res.TokenCount,
+		SentenceCount:  res.SentenceCount,
+		ParagraphCount: res.ParagraphCount,
+		Pages:          toDomainPages(res.Pages),
+		Metadata:       toDomainMetadata(res.Metadata),
+		Encryption:     toDomainEncryption(res.Encryption),
+		Outline:        toDomainOutline(res.Outline),
+		Annotations:    toDomainAnnotations(res.Annotations),
+		Images:         toDomainImages(res.Images),
+		Fonts:          toDomainFonts(res.Fonts),
+		FormFields:     toDomainFormFields(res.FormFields),
+		Language:       toDomainLanguage(res.Language),
 	}, nil
 }
+
+// ExtractForms calls the underlying PDFAnalyzer and maps the fields
+// into domain.FormField values.
+func (a *PDFAnalyzerAdapter) ExtractForms(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.FormField, error) {
+	fields, err := a.inner.ExtractForms(ctx, path, pdfanalyzer.WithPassword(opts.Password))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainFormFields(fields), nil
+}
+
+// ExtractImage calls the underlying PDFAnalyzer and returns the
+// exported image.
+func (a *PDFAnalyzerAdapter) ExtractImage(ctx context.Context, path string, opts port.AnalyzeOptions, n int) (domain.ImageData, error) {
+	img, err := a.inner.ExtractImage(ctx, path, n, pdfanalyzer.WithPassword(opts.Password))
+	if err != nil {
+		return domain.ImageData{}, toDomainError(err)
+	}
+	return domain.ImageData{ContentType: img.ContentType, Extension: img.Extension, Data: img.Data}, nil
+}
+
+// ExtractTables calls the underlying PDFAnalyzer and maps the tables
+// into domain.Table values.
+func (a *PDFAnalyzerAdapter) ExtractTables(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.Table, error) {
+	pages := make([]pdfanalyzer.PageRange, 0, len(opts.Pages))
+	for _, r := range opts.Pages {
+		pages = append(pages, pdfanalyzer.PageRange(r))
+	}
+	tables, err := a.inner.ExtractTables(ctx, path, pdfanalyzer.WithPassword(opts.Password), pdfanalyzer.WithPages(pages...))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainTables(tables), nil
+}
+
+// ExtractScans calls the underlying PDFAnalyzer and returns the
+// exported scans.
+func (a *PDFAnalyzerAdapter) ExtractScans(ctx context.Context, path string, opts port.AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error) {
+	images, err := a.inner.ExportScans(ctx, path, scans, pdfanalyzer.WithPassword(opts.Password))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	out := make(map[int]domain.ImageData, len(images))
+	for page, img := range images {
+		out[page] = domain.ImageData{ContentType: img.ContentType, Extension: img.Extension, Data: img.Data}
+	}
+	return out, nil
+}
+
+// CountContent counts the page texts with the underlying analyzer.
+func (a *PDFAnalyzerAdapter) CountContent(pageTexts []string) domain.ContentStats {
+	stats := pdfanalyzer.CountContent(pageTexts)
+	return domain.ContentStats{
+		Content:        stats.Content,
+		WordCount:      stats.WordCount,
+		TokenCount:     stats.TokenCount,
+		SentenceCount:  stats.SentenceCount,
+		ParagraphCount: stats.ParagraphCount,
+		CharCount:      stats.CharCount,
+		Language:       toDomainLanguage(stats.Language),
+	}
+}
+
+// toDomainError translates analyzer errors that have a domain
+// meaning. Other errors are returned unchanged.
+func toDomainError(err error) error {
+	switch {
+	case errors.Is(err, pdfanalyzer.ErrTooManyPages), errors.Is(err, pdfanalyzer.ErrStreamTooLarge):
+		return fmt.Errorf("%w: %v", domain.ErrDocumentTooLarge, err)
+	case errors.Is(err, pdfanalyzer.ErrImageNotFound):
+		return fmt.Errorf("%w: %v", domain.ErrImageNotFound, err)
+	case errors.Is(err, pdfanalyzer.ErrPasswordRequired):
+		return fmt.Errorf("%w: %v", domain.ErrPasswordRequired, err)
+	case errors.Is(err, pdfanalyzer.ErrIncorrectPassword):
+		return fmt.Errorf("%w: %v", domain.ErrIncorrectPassword, err)
+	case errors.Is(err, pdfanalyzer.ErrEncrypted):
+		return fmt.Errorf("%w: %v", domain.ErrEncryptedDocument, err)
+	case errors.Is(err, pdfanalyzer.ErrCorrupt):
+		return fmt.Errorf("%w: %v", domain.ErrCorruptDocument, err)
+	case errors.Is(err, pdfanalyzer.ErrUnsupported):
+		return fmt.Errorf("%w: %v", domain.ErrUnsupportedDocument, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,191 @@
+// Package pii implements port.PIIDetectorPort with regular expression
+// rules, each checked by the validation of its number scheme (check
+// digits, checksums, issued ranges) to keep false positives down.
+package pii
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// Ensure interface compliance
+var _ port.PIIDetectorPort = (*RuleDetector)(nil)
+
+// Detector names, as set in PII_DETECTOR.
+const (
+	DetectorRules = "rules"
+	DetectorNone  = "none"
+)
+
+// New builds the detector selected by name. It returns a nil port for
+// DetectorNone, and an error when the detector is unknown.
+func New(name string) (port.PIIDetectorPort, error) {
+	switch name {
+	case DetectorRules:
+		return NewRuleDetector(DefaultRules()...), nil
+	case DetectorNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown PII detector %q", name)
+	}
+}
+
+// Rule finds one kind of personal data.
+type Rule struct {
+	Type string
+	// Pattern finds the candidates. When it has a capturing group,
+	// the first group is the value and the rest of the match is
+	// context.
+	Pattern *regexp.Regexp
+	// Valid checks a candidate, for instance its check digits; nil
+	// accepts every candidate.
+	Valid func(value string) bool
+	// Mask renders the preview of a value; nil keeps the last four
+	// letters and digits.
+	Mask func(value string) string
+	// Standalone drops candidates that are part of a longer number:
+	// digits right before or after them, past one separator.
+	Standalone bool
+}
+
+// RuleDetector runs rules in order. A candidate overlapping a match of
+// an earlier rule is dropped, so the most specific rules go first.
+type RuleDetector struct {
+	rules []Rule
+}
+
+// NewRuleDetector creates a detector running rules in order.
+func NewRuleDetector(rules ...Rule) *RuleDetector {
+	return &RuleDetector{rules: rules}
+}
+
+type span struct {
+	start, end int // byte offsets in the text
+	rule       *Rule
+}
+
+// Detect returns the matches of the rules in text, in text order. The
+// context is checked between rules; once it is done, ctx.Err() is
+// returned.
+func (d *RuleDetector) Detect(ctx context.Context, text string) ([]domain.PIIMatch, error) {
+	// found is kept sorted and free of overlaps, as overlaps
+	// requires. The matches of one rule do not overlap each other,
+	// so they are only checked against the earlier rules, then merged.
+	var found []span
+	for i := range d.rules {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r := &d.rules[i]
+		var added []span
+		for _, loc := range r.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			if r.Standalone && !standalone(text, start, end) {
+				continue
+			}
+			if r.Valid != nil && !r.Valid(text[start:end]) {
+				continue
+			}
+			if overlaps(found, start, end) {
+				continue
+			}
+			added = append(added, span{start: start, end: end, rule: r})
+		}
+		if len(added) > 0 {
+			found = append(found, added...)
+			sort.Slice(found, func(i, j int) bool { return found[i].start < found[j].start })
+		}
+	}
+
+	matches := make([]domain.PIIMatch, 0, len(found))
+	chars, last := 0, 0
+	for _, s := range found {
+		chars += utf8.RuneCountInString(text[last:s.start])
+		last = s.start
+		value := text[s.start:s.end]
+		mask := s.rule.Mask
+		if mask == nil {
+			mask = maskKeeping(4)
+		}
+		matches = append(matches, domain.PIIMatch{
+			Type:    s.rule.Type,
+			Offset:  chars,
+			Length:  utf8.RuneCountInString(value),
+			Preview: mask(value),
+		})
+	}
+	return matches, nil
+}
+
+// standalone reports whether text[start:end] is neither preceded nor
+// followed by a digit, ignoring one separator in between: the groups
+// of "1234 5678 9012 3456" are not phone numbers.
+func standalone(text string, start, end int) bool {
+	isSeparator := func(r rune) bool { return strings.ContainsRune(" .-/", r) }
+
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	if isSeparator(before) {
+		before, _ = utf8.DecodeLastRuneInString(strings.TrimSuffix(text[:start], string(before)))
+	}
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	if isSeparator(after) {
+		after, _ = utf8.DecodeRuneInString(text[end+utf8.RuneLen(after):])
+	}
+	return !unicode.IsDigit(before) && !unicode.IsDigit(after)
+}
+
+// overlaps reports whether text[start:end] overlaps a span of found.
+// found is sorted and free of overlaps, so its ends are sorted too:
+// only the first span ending after start can overlap.
+func overlaps(found []span, start, end int) bool {
+	i := sort.Search(len(found), func(i int) bool { return found[i].end > start })
+	return i < len(found) && found[i].start < end
+}
+
+// maskKeeping returns a mask that replaces every letter and digit of a
+// value with '*', except the last keep ones, and leaves separators in
+// place: "***.***.***-25".
+func maskKeeping(keep int) func(string) string {
+	return func(value string) string {
+		alnum := 0
+		for _, r := range value {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				alnum++
+			}
+		}
+		var sb strings.Builder
+		seen := 0
+		for _, r := range value {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				seen++
+				if seen <= alnum-keep {
+					r = '*'
+				}
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String()
+	}
+}
+
+// maskEmail keeps the first character of the local part and the
+// domain: "j***@example.com".
+func maskEmail(value string) string {
+	at := strings.LastIndexByte(value, '@')
+	if at < 1 {
+		return maskKeeping(0)(value)
+	}
+	first, size := utf8.DecodeRuneInString(value)
+	return string(first) + strings.Repeat("*", utf8.RuneCountInString(value[size:at])) + value[at:]
+}
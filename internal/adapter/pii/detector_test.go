@@ -0,0 +1,110 @@
+package pii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func describe(matches []domain.PIIMatch) string {
+	var s []string
+	for _, m := range matches {
+		s = append(s, fmt.Sprintf("%s@%d+%d:%s", m.Type, m.Offset, m.Length, m.Preview))
+	}
+	return strings.Join(s, " ")
+}
+
+func TestRuleDetector_DefaultRules(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Contact: joão.silva@example.com.br", "email@9+25:j*********@example.com.br"},
+		{"IBAN DE89 3704 0044 0532 0130 00, EUR", "iban@5+27:**** **** **** **** **30 00"},
+		{"IBAN GB82WEST12345698765432", "iban@5+22:******************5432"},
+		{"Card 4111 1111 1111 1111 exp 12/27", "credit_card@5+19:**** **** **** 1111"},
+		{"CPF: 529.982.247-25", "cpf@5+14:***.***.***-25"},
+		{"CPF 52998224725.", "cpf@4+11:*********25"},
+		{"CNPJ 11.222.333/0001-81", "cnpj@5+18:**.***.***/****-81"},
+		// Also a valid Visa number by its prefix and the Luhn check.
+		{"CNPJ 41122233001620", "cnpj@5+14:************20"},
+		{"SSN 123-45-6789", "ssn@4+11:***-**-6789"},
+		{"Call +1 (555) 123-4567 or (11) 98765-4321", "phone@5+17:+* (***) ***-**67 phone@26+15:(**) *****-**21"},
+		{"Tel. +44 20 7946 0958", "phone@5+16:+** ** **** **58"},
+
+		// Numbers failing their checks are not reported.
+		{"Card 4111 1111 1111 1112", ""},
+		{"Order 1234 5678 9012 3456", ""},
+		{"CPF 529.982.247-24, 111.111.111-11", ""},
+		{"CNPJ 11.222.333/0001-82", ""},
+		{"SSN 000-12-3456, 666-12-3456, 900-12-3456", ""},
+		{"IBAN DE89 3704 0044 0532 0130 01", ""},
+		{"Invoice 2024-01-15, total 1.234,56", ""},
+	}
+	d := NewRuleDetector(DefaultRules()...)
+	for _, tc := range tests {
+		got, err := d.Detect(context.Background(), tc.text)
+		if err != nil {
+			t.Fatalf("Detect(%q): %v", tc.text, err)
+		}
+		if s := describe(got); s != tc.want {
+			t.Errorf("Detect(%q):\n got %s\nwant %s", tc.text, s, tc.want)
+		}
+	}
+}
+
+func TestRuleDetector_CustomRules(t *testing.T) {
+	// Earlier rules win overlapping candidates; offsets count
+	// characters, not bytes.
+	d := NewRuleDetector(
+		Rule{Type: "badge", Pattern: regexp.MustCompile(`B-([0-9]{4})`)},
+		Rule{Type: "number", Pattern: regexp.MustCompile(`[0-9]{4}`), Mask: func(string) string { return "####" }},
+	)
+	got, err := d.Detect(context.Background(), "Crachá B-1234, sala 5678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := describe(got); s != "badge@9+4:1234 number@20+4:####" {
+		t.Errorf("got %s", s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.Detect(ctx, "B-1234"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRuleDetector_ManyMatches(t *testing.T) {
+	// Candidates are checked against the earlier matches by binary
+	// search; a linear scan would run into the test timeout here.
+	d := NewRuleDetector(
+		Rule{Type: "badge", Pattern: regexp.MustCompile(`B-[0-9]`)},
+		Rule{Type: "number", Pattern: regexp.MustCompile(`[0-9]`)},
+	)
+	text := strings.Repeat("B-1 2 ", 100000)
+	got, err := d.Detect(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 200000 || got[1].Type != "number" || got[199999].Offset != 99999*6+4 {
+		t.Errorf("got %d matches, last %+v", len(got), got[len(got)-1])
+	}
+}
+
+func TestNew(t *testing.T) {
+	if d, err := New(DetectorRules); err != nil || d == nil {
+		t.Errorf("New(rules) = %v, %v", d, err)
+	}
+	if d, err := New(DetectorNone); err != nil || d != nil {
+		t.Errorf("New(none) = %v, %v; want a nil detector", d, err)
+	}
+	if _, err := New("regex"); err == nil {
+		t.Error("expected an error for an unknown detector")
+	}
+}
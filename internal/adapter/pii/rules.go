@@ -0,0 +1,220 @@
+package pii
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// DefaultRules returns the built-in rules, most specific first:
+// emails, IBANs, CPF and CNPJ numbers, card numbers, SSNs, then phone
+// numbers, whose loose format would otherwise claim the others. The
+// check digits of an unformatted CNPJ can pass the Luhn check of
+// cards, so the tax numbers go before the cards.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Type:    domain.PIIEmail,
+			Pattern: regexp.MustCompile(`[\p{L}\p{N}._%+\-]+@[\p{L}\p{N}\-]+(?:\.[\p{L}\p{N}\-]+)*\.\p{L}{2,}`),
+			Mask:    maskEmail,
+		},
+		{
+			Type:    domain.PIIIBAN,
+			Pattern: regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`),
+			Valid:   validIBAN,
+		},
+		{
+			Type:       domain.PIICPF,
+			Pattern:    regexp.MustCompile(`\b(?:[0-9]{3}\.[0-9]{3}\.[0-9]{3}-[0-9]{2}|[0-9]{11})\b`),
+			Valid:      validCPF,
+			Mask:       maskKeeping(2),
+			Standalone: true,
+		},
+		{
+			Type:       domain.PIICNPJ,
+			Pattern:    regexp.MustCompile(`\b(?:[0-9]{2}\.[0-9]{3}\.[0-9]{3}/[0-9]{4}-[0-9]{2}|[0-9]{14})\b`),
+			Valid:      validCNPJ,
+			Mask:       maskKeeping(2),
+			Standalone: true,
+		},
+		{
+			Type:       domain.PIICreditCard,
+			Pattern:    regexp.MustCompile(`\b(?:[0-9][ \-]?){12,18}[0-9]\b`),
+			Valid:      validCard,
+			Standalone: true,
+		},
+		{
+			Type:       domain.PIISSN,
+			Pattern:    regexp.MustCompile(`\b[0-9]{3}-[0-9]{2}-[0-9]{4}\b`),
+			Valid:      validSSN,
+			Standalone: true,
+		},
+		{
+			Type: domain.PIIPhone,
+			// An optional country code, an area code, in brackets or
+			// followed by a separator, and the subscriber number:
+			// "+1 (555) 123-4567", "(11) 98765-4321", "+44 20 7946 0958".
+			Pattern:    regexp.MustCompile(`(?:\+[0-9]{1,3}[ .\-]?)?(?:\([0-9]{2,4}\)[ .\-]?|\b[0-9]{2,4}[ .\-])[0-9]{3,5}[ .\-]?[0-9]{4}\b`),
+			Valid:      validPhone,
+			Mask:       maskKeeping(2),
+			Standalone: true,
+		},
+	}
+}
+
+// digits returns the ASCII digits of s.
+func digits(s string) []int {
+	var ds []int
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			ds = append(ds, int(r-'0'))
+		}
+	}
+	return ds
+}
+
+// allSame reports whether every digit is the same, as in the
+// placeholder "000.000.000-00", which passes the CPF check.
+func allSame(ds []int) bool {
+	for _, d := range ds {
+		if d != ds[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// validIBAN checks the length and the ISO 13616 mod-97 checksum.
+func validIBAN(value string) bool {
+	iban := strings.ReplaceAll(value, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	rearranged := iban[4:] + iban[:4]
+	rem := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			rem = (rem*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			rem = (rem*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return rem == 1
+}
+
+// validCard checks the length, the issuer prefix of the major card
+// networks and the Luhn checksum. The prefix check rejects most of
+// the long numbers that pass Luhn by chance.
+func validCard(value string) bool {
+	ds := digits(value)
+	if len(ds) < 13 || len(ds) > 19 || !cardPrefix(ds) {
+		return false
+	}
+	sum := 0
+	for i := range ds {
+		d := ds[len(ds)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// cardPrefix reports whether ds starts with the issuer identification
+// number of Visa, Mastercard, American Express, Discover, JCB or
+// Diners Club.
+func cardPrefix(ds []int) bool {
+	prefix := func(n int) int {
+		p := 0
+		for _, d := range ds[:n] {
+			p = p*10 + d
+		}
+		return p
+	}
+	switch p2, p4 := prefix(2), prefix(4); {
+	case ds[0] == 4: // Visa
+		return true
+	case p2 >= 51 && p2 <= 55, p4 >= 2221 && p4 <= 2720: // Mastercard
+		return true
+	case p2 == 34 || p2 == 37: // American Express
+		return true
+	case p4 == 6011, p2 == 65, p4/10 >= 644 && p4/10 <= 649: // Discover
+		return true
+	case p2 == 35: // JCB
+		return true
+	case p2 == 36 || p2 == 38 || p2 == 30: // Diners Club
+		return true
+	}
+	return false
+}
+
+// validCPF checks the two CPF check digits (mod 11, weights 10..2 and
+// 11..2).
+func validCPF(value string) bool {
+	ds := digits(value)
+	if len(ds) != 11 || allSame(ds) {
+		return false
+	}
+	return ds[9] == mod11(ds[:9], 10) && ds[10] == mod11(ds[:10], 11)
+}
+
+// validCNPJ checks the two CNPJ check digits (mod 11, weights cycling
+// from 2 to 9 from the right).
+func validCNPJ(value string) bool {
+	ds := digits(value)
+	if len(ds) != 14 || allSame(ds) {
+		return false
+	}
+	check := func(body []int) int {
+		sum, w := 0, 2
+		for i := len(body) - 1; i >= 0; i-- {
+			sum += body[i] * w
+			if w++; w > 9 {
+				w = 2
+			}
+		}
+		if r := sum % 11; r >= 2 {
+			return 11 - r
+		}
+		return 0
+	}
+	return ds[12] == check(ds[:12]) && ds[13] == check(ds[:13])
+}
+
+// mod11 is the CPF check digit of body with weights counting down from
+// first.
+func mod11(body []int, first int) int {
+	sum := 0
+	for i, d := range body {
+		sum += d * (first - i)
+	}
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+	return 0
+}
+
+// validSSN rejects the numbers never issued: area 000, 666 or 900 and
+// above, group 00 and serial 0000.
+func validSSN(value string) bool {
+	ds := digits(value)
+	area := ds[0]*100 + ds[1]*10 + ds[2]
+	group := ds[3]*10 + ds[4]
+	serial := ds[5]*1000 + ds[6]*100 + ds[7]*10 + ds[8]
+	return area != 0 && area != 666 && area < 900 && group != 0 && serial != 0
+}
+
+// validPhone bounds the number of digits: 10 for a national number
+// with area code, up to 15 for E.164.
+func validPhone(value string) bool {
+	n := len(digits(value))
+	return n >= 10 && n <= 15
+}
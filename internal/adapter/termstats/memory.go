@@ -0,0 +1,74 @@
+package termstats
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+)
+
+// Ensure interface compliance
+var _ port.TermStatsPort = (*MemoryTermStats)(nil)
+
+// MemoryTermStats keeps document frequencies in process memory. They
+// are lost on restart, which only makes the weighting start over.
+//
+// The store stops growing at its limits: once maxDocuments documents
+// are recorded, new ones are ignored, and once maxTerms terms are
+// known, new terms are not counted.
+type MemoryTermStats struct {
+	mu           sync.Mutex
+	documents    map[string]struct{}
+	df           map[string]int
+	maxDocuments int
+	maxTerms     int
+}
+
+// NewMemoryTermStats creates an empty store. Zero limits disable them.
+func NewMemoryTermStats(maxDocuments, maxTerms int) *MemoryTermStats {
+	return &MemoryTermStats{
+		documents:    make(map[string]struct{}),
+		df:           make(map[string]int),
+		maxDocuments: maxDocuments,
+		maxTerms:     maxTerms,
+	}
+}
+
+func (s *MemoryTermStats) AddDocument(ctx context.Context, id string, terms []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.documents[id]; ok {
+		return nil
+	}
+	if s.maxDocuments > 0 && len(s.documents) >= s.maxDocuments {
+		return nil
+	}
+	s.documents[id] = struct{}{}
+
+	seen := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		if _, known := s.df[t]; !known && s.maxTerms > 0 && len(s.df) >= s.maxTerms {
+			continue
+		}
+		s.df[t]++
+	}
+	return nil
+}
+
+func (s *MemoryTermStats) Frequencies(ctx context.Context, terms []string) (int, map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	df := make(map[string]int, len(terms))
+	for _, t := range terms {
+		if n, ok := s.df[t]; ok {
+			df[t] = n
+		}
+	}
+	return len(s.documents), df, nil
+}
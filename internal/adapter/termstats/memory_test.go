@@ -0,0 +1,29 @@
+package termstats
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestMemoryTermStats(t *testing.T) {
+	store := NewMemoryTermStats(3, 4)
+	ctx := context.Background()
+
+	_ = store.AddDocument(ctx, "a", []string{"invoice", "total", "total"})
+	_ = store.AddDocument(ctx, "b", []string{"invoice", "contract"})
+	// Recording a document again does not count it twice.
+	_ = store.AddDocument(ctx, "a", []string{"invoice", "total"})
+	// The term limit keeps "refund" out, known terms still count.
+	_ = store.AddDocument(ctx, "c", []string{"invoice", "clause", "refund"})
+	// The document limit ignores new documents.
+	_ = store.AddDocument(ctx, "d", []string{"invoice"})
+
+	docs, df, err := store.Frequencies(ctx, []string{"invoice", "total", "contract", "clause", "refund"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(docs, df); got != "3 map[clause:1 contract:1 invoice:3 total:1]" {
+		t.Errorf("frequencies = %s", got)
+	}
+}
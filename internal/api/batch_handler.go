@@ -0,0 +1,184 @@
+package api
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+	"github.com/jorgediasdsg/pdf-expert/internal/upload"
+)
+
+type BatchHandler struct {
+	batch *usecase.AnalyzeBatchUseCase
+}
+
+func NewBatchHandler(batch *usecase.AnalyzeBatchUseCase) *BatchHandler {
+	return &BatchHandler{batch: batch}
+}
+
+// AnalyzeBatch godoc
+// @Summary Analyze several PDFs in one request
+// @Description Upload many PDF files as "files" parts, or a single ZIP archive, and receive one result per file. A failed file does not fail the batch: each item carries its own success flag and error code.
+// @Tags analysis
+// @Accept multipart/form-data
+// @Produce json
+// @Param files formData file true "PDF files, or one ZIP archive"
+// @Param password formData string false "Password for encrypted PDFs, tried on every file"
+// @Param ocr query string false "OCR of image-only pages: auto (default), force or off"
+// @Param layout query string false "Text extraction: raw (default, content stream order), reading or physical"
+// @Param analyses query string false "Comma-separated text analyses to run: keywords"
+// @Param keywords_top query int false "Number of keywords and keyphrases returned, 1 to 100 (default 10)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /analyze/batch [post]
+func (h *BatchHandler) AnalyzeBatch(c *gin.Context) {
+	cfg := config.Load()
+
+	// Invalid settings would fail every item; reject the batch instead.
+	settings, err := analyzeInput(c)
+	if err == nil {
+		err = settings.ValidateOptions()
+	}
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	if cfg.BatchMaxBytes > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, cfg.BatchMaxBytes+multipartOverhead)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if !errors.As(err, &maxBytesErr) {
+			err = errFilesRequired
+		}
+		writeProblem(c, err)
+		return
+	}
+
+	headers := form.File["files"]
+	if len(headers) == 0 {
+		headers = form.File["file"]
+	}
+	if len(headers) == 0 {
+		writeProblem(c, errFilesRequired)
+		return
+	}
+
+	items, err := stageBatch(cfg, headers)
+	// Staged files are removed even if the analysis panics.
+	defer func() {
+		for _, item := range items {
+			if item.staged != nil {
+				_ = item.staged.Remove()
+			}
+		}
+	}()
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	settings.Password = c.PostForm("password")
+	input := dto.AnalyzeBatchInputDTO{Timeout: cfg.AnalysisTimeout}
+	for _, item := range items {
+		path := item.Analyze.FilePath
+		item.Analyze = settings
+		item.Analyze.FilePath = path
+		input.Items = append(input.Items, item.BatchItemDTO)
+	}
+
+	output, err := h.batch.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	for _, item := range output.Items {
+		switch {
+		case errors.Is(item.Err, domain.ErrAnalysisTimeout):
+			recordCancellation(cancelReasonTimeout)
+		case errors.Is(item.Err, domain.ErrAnalysisCancelled):
+			recordCancellation(cancelReasonDisconnect)
+		}
+	}
+
+	writeSuccess(c, presentBatch(output))
+}
+
+// stagedItem pairs a batch item with the file backing it, if any.
+type stagedItem struct {
+	dto.BatchItemDTO
+	staged *upload.StagedFile
+}
+
+// stageBatch stages the uploaded parts. A single ZIP part is
+// expanded into its entries; otherwise every part must be a PDF.
+// Files that cannot be staged become failed items rather than
+// failing the batch.
+func stageBatch(cfg config.Config, headers []*multipart.FileHeader) ([]stagedItem, error) {
+	stager := upload.NewStager(cfg.TempFolder, cfg.MaxUploadBytes)
+
+	if len(headers) == 1 {
+		items, isZip, err := stageZipUpload(cfg, stager, headers[0])
+		if isZip {
+			return items, err
+		}
+	}
+
+	if cfg.BatchMaxFiles > 0 && len(headers) > cfg.BatchMaxFiles {
+		return nil, dto.ErrBatchTooLarge
+	}
+
+	items := make([]stagedItem, 0, len(headers))
+	for _, fh := range headers {
+		item := stagedItem{BatchItemDTO: dto.BatchItemDTO{Name: fh.Filename}}
+		item.staged, item.Err = stager.StageMultipart(fh)
+		if item.staged != nil {
+			item.Analyze.FilePath = item.staged.Path
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// stageZipUpload expands fh when it is a ZIP archive. isZip is false,
+// and nothing is staged, for any other file.
+func stageZipUpload(cfg config.Config, stager *upload.Stager, fh *multipart.FileHeader) (items []stagedItem, isZip bool, err error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, false, nil
+	}
+	defer f.Close()
+
+	if !upload.IsZip(f) {
+		return nil, false, nil
+	}
+
+	entries, err := stager.StageZip(f, fh.Size, upload.ArchiveLimits{
+		MaxEntries:    cfg.BatchMaxFiles,
+		MaxTotalBytes: cfg.BatchMaxBytes,
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	items = make([]stagedItem, 0, len(entries))
+	for _, e := range entries {
+		item := stagedItem{BatchItemDTO: dto.BatchItemDTO{Name: e.Name, Err: e.Err}, staged: e.File}
+		if e.File != nil {
+			item.Analyze.FilePath = e.File.Path
+		}
+		items = append(items, item)
+	}
+	return items, true, nil
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+type batchResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Total     int `json:"total"`
+		Succeeded int `json:"succeeded"`
+		Failed    int `json:"failed"`
+		Files     []struct {
+			File    string `json:"file"`
+			Success bool   `json:"success"`
+			Data    struct {
+				WordCount int `json:"word_count"`
+			} `json:"data"`
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		} `json:"files"`
+	} `json:"data"`
+}
+
+func newBatchRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello world", WordCount: 2},
+	}
+	batch := usecase.NewAnalyzeBatchUseCase(usecase.NewAnalyzePDFUseCase(mockPort), 2)
+
+	router := gin.New()
+	router.POST("/analyze/batch", NewBatchHandler(batch).AnalyzeBatch)
+	return router
+}
+
+// postBatch sends parts as "files" fields, keyed by file name.
+func postBatch(t *testing.T, router *gin.Engine, parts ...[2]string) (int, batchResponse) {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for _, p := range parts {
+		part, _ := writer.CreateFormFile("files", p[0])
+		part.Write([]byte(p[1]))
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/analyze/batch", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp batchResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return w.Code, resp
+}
+
+func TestBatchHandler_MultipleFiles(t *testing.T) {
+	router := newBatchRouter(t)
+
+	code, resp := postBatch(t, router,
+		[2]string{"a.pdf", "%PDF-1.4 a"},
+		[2]string{"b.txt", "plain text"},
+		[2]string{"c.pdf", "%PDF-1.4 c"},
+	)
+	if code != 200 {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+
+	d := resp.Data
+	if d.Total != 3 || d.Succeeded != 2 || d.Failed != 1 {
+		t.Errorf("unexpected totals: %+v", d)
+	}
+	if !d.Files[0].Success || d.Files[0].Data.WordCount != 2 {
+		t.Errorf("expected a.pdf to succeed, got %+v", d.Files[0])
+	}
+	if d.Files[1].Success || d.Files[1].Error.Code != "unsupported_media_type" {
+		t.Errorf("expected b.txt to fail as unsupported_media_type, got %+v", d.Files[1])
+	}
+}
+
+func TestBatchHandler_ZipArchive(t *testing.T) {
+	router := newBatchRouter(t)
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"in/a.pdf":      "%PDF-1.4 a",
+		"../escape.pdf": "%PDF-1.4 escape",
+	} {
+		f, _ := zw.Create(name)
+		f.Write([]byte(content))
+	}
+	zw.Close()
+
+	code, resp := postBatch(t, router, [2]string{"docs.zip", buf.String()})
+	if code != 200 {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+	if resp.Data.Total != 2 || resp.Data.Succeeded != 1 {
+		t.Fatalf("unexpected totals: %+v", resp.Data)
+	}
+	for _, f := range resp.Data.Files {
+		if f.File == "../escape.pdf" && (f.Success || f.Error.Code != "unsafe_path") {
+			t.Errorf("expected zip-slip entry to be rejected, got %+v", f)
+		}
+	}
+}
+
+func TestBatchHandler_TooManyFiles(t *testing.T) {
+	router := newBatchRouter(t)
+	t.Setenv("BATCH_MAX_FILES", "1")
+
+	code, _ := postBatch(t, router,
+		[2]string{"a.pdf", "%PDF-1.4 a"},
+		[2]string{"b.pdf", "%PDF-1.4 b"},
+	)
+	if code != 413 {
+		t.Errorf("expected status 413, got %d", code)
+	}
+}
+
+func TestBatchHandler_MissingFiles(t *testing.T) {
+	router := newBatchRouter(t)
+
+	code, _ := postBatch(t, router)
+	if code != 400 {
+		t.Errorf("expected status 400, got %d", code)
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// Request errors raised by the HTTP layer itself.
+var (
+	errFileRequired  = errors.New("file is required")
+	errFilesRequired = errors.New("files are required")
+	errInvalidFormat = errors.New("unsupported response format")
+)
+
+// apiError describes how an error is reported to clients: a stable,
+// machine-readable code, the HTTP status and a short title that does
+// not change between occurrences.
+type apiError struct {
+	Code   string
+	Status int
+	Title  string
+}
+
+// statusClientClosedRequest is the non-standard status logged when
+// the client went away before the response (nginx convention).
+const statusClientClosedRequest = 499
+
+// requestErrors are the errors of the HTTP layer, reported like
+// those of dto.ErrorClasses.
+var requestErrors = []dto.ErrorClass{
+	{Target: errFileRequired, Code: "file_required", Kind: dto.KindInvalidInput, Title: "File is required"},
+	{Target: errFilesRequired, Code: "file_required", Kind: dto.KindInvalidInput, Title: "File is required"},
+	{Target: errInvalidFormat, Code: "invalid_format", Kind: dto.KindInvalidInput, Title: "Invalid format"},
+}
+
+// kindStatus is the HTTP status of each error kind.
+var kindStatus = map[dto.ErrorKind]int{
+	dto.KindInternal:         http.StatusInternalServerError,
+	dto.KindInvalidInput:     http.StatusBadRequest,
+	dto.KindUnsupportedMedia: http.StatusUnsupportedMediaType,
+	dto.KindTooLarge:         http.StatusRequestEntityTooLarge,
+	dto.KindNotFound:         http.StatusNotFound,
+	dto.KindConflict:         http.StatusConflict,
+	dto.KindUnavailable:      http.StatusServiceUnavailable,
+	dto.KindUnauthorized:     http.StatusUnauthorized,
+	dto.KindUnprocessable:    http.StatusUnprocessableEntity,
+	dto.KindNotImplemented:   http.StatusNotImplemented,
+	dto.KindTimeout:          http.StatusGatewayTimeout,
+	dto.KindCancelled:        statusClientClosedRequest,
+}
+
+// lookupError returns the API representation of err. A body cut off
+// by http.MaxBytesReader counts as dto.ErrFileTooLarge; anything not
+// in requestErrors or dto.ErrorClasses is an internal error.
+func lookupError(err error) apiError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = dto.ErrFileTooLarge
+	}
+
+	class := dto.ClassifyError(err)
+	for _, c := range requestErrors {
+		if errors.Is(err, c.Target) {
+			class = c
+			break
+		}
+	}
+	return apiError{Code: class.Code, Status: kindStatus[class.Kind], Title: class.Title}
+}
+
+// errorCode classifies the error of a failed job or batch item
+// into a stable, machine-readable code.
+func errorCode(err error) string {
+	return lookupError(err).Code
+}
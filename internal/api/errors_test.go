@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func TestLookupError(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   string
+		status int
+	}{
+		{dto.ErrInvalidPath, "invalid_input", 400},
+		{fmt.Errorf("analyze: %w", domain.ErrEmptyContent), "empty_content", 422},
+		{fmt.Errorf("analyze: %w", domain.ErrInvalidWordCount), "invalid_word_count", 422},
+		{fmt.Errorf("%w: cannot decrypt file", domain.ErrEncryptedDocument), "encrypted_document", 422},
+		{fmt.Errorf("%w: cannot decrypt file", domain.ErrPasswordRequired), "password_required", 401},
+		{fmt.Errorf("%w: cannot decrypt file", domain.ErrIncorrectPassword), "incorrect_password", 422},
+		{fmt.Errorf("%w: cannot parse file", domain.ErrCorruptDocument), "corrupt_document", 422},
+		{fmt.Errorf("%w: unsupported feature", domain.ErrUnsupportedDocument), "unsupported_document", 422},
+		{fmt.Errorf("read body: %w", &http.MaxBytesError{Limit: 10}), "file_too_large", 413},
+		{dto.ErrJobCancelled, "job_cancelled", 409},
+		{fmt.Errorf("%w: context canceled", domain.ErrAnalysisCancelled), "analysis_cancelled", 499},
+		{errors.New("boom"), "internal_error", 500},
+	}
+
+	for _, tc := range tests {
+		got := lookupError(tc.err)
+		if got.Code != tc.code || got.Status != tc.status {
+			t.Errorf("lookupError(%v) = %s/%d; want %s/%d", tc.err, got.Code, got.Status, tc.code, tc.status)
+		}
+	}
+}
+
+func TestErrorClasses_Complete(t *testing.T) {
+	seen := make(map[error]bool)
+	kinds := make(map[string]dto.ErrorKind)
+	for _, c := range append(requestErrors, dto.ErrorClasses...) {
+		if seen[c.Target] {
+			t.Errorf("error %q registered twice", c.Target)
+		}
+		seen[c.Target] = true
+		// A code names one kind of failure: clients must not see it
+		// with two statuses.
+		if kind, ok := kinds[c.Code]; ok && kind != c.Kind {
+			t.Errorf("code %q used for two error kinds", c.Code)
+		}
+		kinds[c.Code] = c.Kind
+		if c.Code == "" || c.Title == "" || kindStatus[c.Kind] == 0 {
+			t.Errorf("incomplete error class for %q: %+v", c.Target, c)
+		}
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("request_id", "1b4e28ba-2fa1-11d2-883f-0016d3cca427") })
+	router.GET("/", func(c *gin.Context) {
+		writeProblem(c, fmt.Errorf("%w: 3000 pages", domain.ErrDocumentTooLarge))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != 413 {
+		t.Fatalf("expected status 413, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != problemContentType {
+		t.Errorf("expected Content-Type %s, got %s", problemContentType, ct)
+	}
+
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	want := Problem{
+		Type:     "urn:pdf-expert:problem:document_too_large",
+		Title:    "Document too large",
+		Status:   413,
+		Detail:   "document exceeds processing limits: 3000 pages",
+		Instance: "urn:uuid:1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+		Code:     "document_too_large",
+	}
+	if p != want {
+		t.Errorf("got %+v; want %+v", p, want)
+	}
+}
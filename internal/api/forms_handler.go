@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+type FormsHandler struct {
+	forms *usecase.ExtractFormsUseCase
+}
+
+func NewFormsHandler(forms *usecase.ExtractFormsUseCase) *FormsHandler {
+	return &FormsHandler{forms: forms}
+}
+
+// ExtractForms godoc
+// @Summary List the interactive form fields of a PDF
+// @Description Upload a PDF and receive every AcroForm field: qualified name, type, value, options, flags and position. Use format=csv, or Accept: text/csv, for one CSV row per field.
+// @Tags forms
+// @Accept multipart/form-data
+// @Produce json
+// @Produce text/csv
+// @Param file formData file true "PDF file"
+// @Param password formData string false "Password for encrypted PDFs"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /forms/extract [post]
+func (h *FormsHandler) ExtractForms(c *gin.Context) {
+	cfg := config.Load()
+
+	format, err := responseFormat(c, formatCSV)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	fileName, staged, ok := stageUpload(c, cfg)
+	if !ok {
+		return
+	}
+	defer staged.Remove()
+
+	ctx, cancel := cfg.AnalysisContext(c.Request.Context())
+	defer cancel()
+
+	output, err := h.forms.Execute(ctx, dto.ExtractFormsInputDTO{
+		FilePath: staged.Path,
+		Password: c.PostForm("password"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAnalysisTimeout):
+			recordCancellation(cancelReasonTimeout)
+		case errors.Is(err, domain.ErrAnalysisCancelled):
+			recordCancellation(cancelReasonDisconnect)
+		}
+
+		writeProblem(c, err)
+		return
+	}
+
+	if format == formatJSON {
+		writeSuccess(c, presentForms(fileName, output))
+		return
+	}
+
+	body, err := presentFormsCSV(output)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+	c.Data(200, "text/csv; charset=utf-8", body)
+}
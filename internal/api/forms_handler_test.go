@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func newFormsRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Forms: []domain.FormField{
+			{Name: "applicant.name", Type: domain.FieldText, Value: "=HYPERLINK(\"x\")", Required: true, Page: 1,
+				Rect: domain.Rect{X1: 100, Y1: 680, X2: 300, Y2: 700}},
+			{Name: "langs", Type: domain.FieldChoice, Value: "en", Values: []string{"en", "pt"},
+				Options: []domain.FormOption{{Value: "en", Label: "English"}, {Value: "pt", Label: "pt"}}},
+		},
+	}
+
+	router := gin.New()
+	router.POST("/forms/extract", NewFormsHandler(usecase.NewExtractFormsUseCase(mockPort)).ExtractForms)
+	return router
+}
+
+func postForm(router *gin.Engine, target, accept string) *httptest.ResponseRecorder {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "form.pdf")
+	part.Write([]byte("%PDF-1.7 form"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", target, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestExtractFormsHandler_JSON(t *testing.T) {
+	w := postForm(newFormsRouter(t), "/forms/extract", "")
+
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, want := range []string{
+		`"name":"applicant.name"`, `"required":true`, `"rect":[100,680,300,700]`,
+		`"values":["en","pt"]`, `{"label":"English","value":"en"}`, `"total":2`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("expected %s in response, got %s", want, w.Body.String())
+		}
+	}
+}
+
+func TestExtractFormsHandler_CSV(t *testing.T) {
+	router := newFormsRouter(t)
+
+	for _, tc := range []struct{ target, accept string }{
+		{"/forms/extract?format=csv", ""},
+		{"/forms/extract", "text/csv"},
+	} {
+		w := postForm(router, tc.target, tc.accept)
+		if w.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Errorf("expected text/csv, got %q", ct)
+		}
+
+		want := "name,type,value,options,required,read_only,page,x1,y1,x2,y2\n" +
+			"applicant.name,text,\"'=HYPERLINK(\"\"x\"\")\",,true,false,1,100,680,300,700\n" +
+			"langs,choice,en|pt,en=English|pt,false,false,,0,0,0,0\n"
+		if got := w.Body.String(); got != want {
+			t.Errorf("csv:\n%s\nwant:\n%s", got, want)
+		}
+	}
+}
+
+func TestExtractFormsHandler_InvalidFormat(t *testing.T) {
+	w := postForm(newFormsRouter(t), "/forms/extract?format=xml", "")
+
+	if w.Code != 400 || !strings.Contains(w.Body.String(), `"code":"invalid_format"`) {
+		t.Fatalf("expected invalid_format 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
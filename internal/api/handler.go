@@ -1,8 +1,8 @@
 package api
 
 import (
-	"fmt"
-	"os"
+	"errors"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
@@ -21,54 +21,87 @@ func NewHandler(uc *usecase.AnalyzePDFUseCase) *Handler {
 
 // AnalyzePDF godoc
 // @Summary Analyze a PDF and count its words
-// @Description Upload a PDF file and receive the word count
+// @Description Upload a PDF file and receive the word count, per-page statistics and document metadata
 // @Tags analysis
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "PDF file"
+// @Param password formData string false "Password for encrypted PDFs"
+// @Param ocr query string false "OCR of image-only pages: auto (default), force or off"
+// @Param layout query string false "Text extraction: raw (default, content stream order), reading or physical"
+// @Param analyses query string false "Comma-separated text analyses to run: keywords"
+// @Param keywords_top query int false "Number of keywords and keyphrases returned, 1 to 100 (default 10)"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
-// @Failure 422 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 501 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /analyze [post]
 func (h *Handler) AnalyzePDF(c *gin.Context) {
 	cfg := config.Load()
 
-	fileHeader, err := c.FormFile("file")
+	input, err := analyzeInput(c)
 	if err != nil {
-		writeError(c, 400, "file is required")
+		writeProblem(c, err)
 		return
 	}
 
-	tmpPath := fmt.Sprintf("%s/%s", cfg.TempFolder, fileHeader.Filename)
-	if err := c.SaveUploadedFile(fileHeader, tmpPath); err != nil {
-		writeError(c, 500, fmt.Sprintf("failed to save file: %v", err))
+	fileName, staged, ok := stageUpload(c, cfg)
+	if !ok {
 		return
 	}
+	// The staged file is removed even if the analysis panics.
+	defer staged.Remove()
+	input.FilePath = staged.Path
+	input.Password = c.PostForm("password")
 
-	input := dto.AnalyzePDFInputDTO{FilePath: tmpPath}
+	// The request context is cancelled when the client disconnects.
+	ctx, cancel := cfg.AnalysisContext(c.Request.Context())
+	defer cancel()
 
-	output, err := h.usecase.Execute(c.Request.Context(), input)
+	output, err := h.usecase.Execute(ctx, input)
 	if err != nil {
-
-		switch err {
-		case dto.ErrInvalidPath:
-			writeError(c, 400, err.Error())
-		case domain.ErrEmptyContent, domain.ErrInvalidWordCount:
-			writeError(c, 422, err.Error())
-		default:
-			writeError(c, 500, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrAnalysisTimeout):
+			recordCancellation(cancelReasonTimeout)
+		case errors.Is(err, domain.ErrAnalysisCancelled):
+			// Nobody is listening anymore; the status is for logs and metrics.
+			recordCancellation(cancelReasonDisconnect)
 		}
 
-		_ = os.Remove(tmpPath)
+		writeProblem(c, err)
 		return
 	}
 
-	writeSuccess(c, gin.H{
-		"file":       fileHeader.Filename,
-		"word_count": output.WordCount,
-		"status":     "completed",
-	})
+	if cfg.CacheEnabled() {
+		recordCacheResult(c, output.Cached)
+	}
+
+	writeSuccess(c, presentAnalysis(fileName, output))
+}
 
-	_ = os.Remove(tmpPath)
+// analyzeInput reads the analysis settings shared by /analyze, /jobs
+// and /analyze/batch from the query string; the caller sets the file
+// path and the password. Form values are left to the caller: reading
+// one parses the whole body, before the upload limit is installed.
+// Only values that cannot be parsed are rejected here, the use case
+// validates the rest.
+func analyzeInput(c *gin.Context) (dto.AnalyzePDFInputDTO, error) {
+	input := dto.AnalyzePDFInputDTO{
+		OCR:      dto.OCRMode(c.Query("ocr")),
+		Layout:   dto.LayoutMode(c.Query("layout")),
+		Analyses: dto.ParseAnalyses(c.Query("analyses")),
+	}
+	if top := c.Query("keywords_top"); top != "" {
+		n, err := strconv.Atoi(top)
+		if err != nil || n < 1 {
+			return input, dto.ErrInvalidKeywordsTop
+		}
+		input.KeywordsTop = n
+	}
+	return input, nil
 }
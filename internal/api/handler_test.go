@@ -2,11 +2,21 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/cache"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/pii"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
 	"github.com/jorgediasdsg/pdf-expert/internal/domain"
@@ -20,6 +30,10 @@ func TestAnalyzePDFHandler_Success(t *testing.T) {
 		Result: domain.AnalysisResult{
 			Content:   "hello world",
 			WordCount: 2,
+			Pages: []domain.PageAnalysis{
+				{Number: 1, Text: "hello world", WordCount: 2, CharCount: 10, Width: 612, Height: 792},
+			},
+			Language: domain.Language{Code: "en", Confidence: 0.75},
 		},
 	}
 
@@ -34,7 +48,7 @@ func TestAnalyzePDFHandler_Success(t *testing.T) {
 	body := new(bytes.Buffer)
 	writer := multipart.NewWriter(body)
 	part, _ := writer.CreateFormFile("file", "test.pdf")
-	part.Write([]byte("dummy pdf content"))
+	part.Write([]byte("%PDF-1.4 dummy pdf content"))
 	writer.Close()
 
 	req := httptest.NewRequest("POST", "/analyze", body)
@@ -51,6 +65,60 @@ func TestAnalyzePDFHandler_Success(t *testing.T) {
 	if !bytes.Contains(w.Body.Bytes(), []byte(`"word_count":2`)) {
 		t.Errorf("expected word_count=2 in response")
 	}
+
+	if !bytes.Contains(w.Body.Bytes(), []byte(`"pages":[{`)) {
+		t.Errorf("expected pages in response, got %s", w.Body.String())
+	}
+
+	if !bytes.Contains(w.Body.Bytes(), []byte(`"metadata":{`)) {
+		t.Errorf("expected metadata in response, got %s", w.Body.String())
+	}
+
+	// Pages too short to identify have no language.
+	for _, want := range []string{
+		`"language":{"code":"en","confidence":0.75}`,
+		`"kind":"text","language":null`,
+		`"readability":{"average_sentence_length":2,"language":"en","reading_time_seconds":1,"scores":{"flesch_kincaid_grade":2.89,"flesch_reading_ease":77.91,"gunning_fog":0.8},"sentence_count":1,"speaking_time_seconds":1,"syllables_per_word":1.5}`,
+		`"pii":null`,
+	} {
+		if !bytes.Contains(w.Body.Bytes(), []byte(want)) {
+			t.Errorf("expected %s in response, got %s", want, w.Body.String())
+		}
+	}
+}
+
+func TestAnalyzePDFHandler_PII(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "Write to ana@example.com",
+			WordCount: 3,
+			Pages:     []domain.PageAnalysis{{Number: 1, Text: "Write to ana@example.com", WordCount: 3, Width: 612, Height: 792}},
+		},
+	}
+	uc := usecase.NewAnalyzePDFUseCase(mockPort, usecase.WithPIIDetector(pii.NewRuleDetector(pii.DefaultRules()...)))
+	router := gin.New()
+	router.POST("/analyze", NewHandler(uc).AnalyzePDF)
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "test.pdf")
+	part.Write([]byte("%PDF-1.4 dummy pdf content"))
+	writer.Close()
+	req := httptest.NewRequest("POST", "/analyze", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := `"pii":{"by_type":{"email":1},"count":1,"found":true,"matches":[{"length":15,"offset":9,"page":1,"preview":"a**@example.com","source":"text","type":"email"}],"pages":[1],"truncated":false}`
+	if !bytes.Contains(w.Body.Bytes(), []byte(want)) {
+		t.Errorf("expected %s in response, got %s", want, w.Body.String())
+	}
 }
 
 func TestAnalyzePDFHandler_InvalidRequest(t *testing.T) {
@@ -72,3 +140,391 @@ func TestAnalyzePDFHandler_InvalidRequest(t *testing.T) {
 		t.Fatalf("expected status 400, got %d", w.Code)
 	}
 }
+
+func TestAnalyzePDFHandler_OCRMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Pages: []domain.PageAnalysis{{Number: 1, Width: 612, Height: 792, ImageCoverage: 1}}},
+	}
+	router := gin.New()
+	router.POST("/analyze", NewHandler(usecase.NewAnalyzePDFUseCase(mockPort)).AnalyzePDF)
+
+	for query, want := range map[string]string{
+		"ocr=always": `"code":"invalid_ocr_mode"`,
+		"ocr=force":  `"code":"ocr_unavailable"`,
+		"ocr=off":    `"code":"scanned_document"`,
+	} {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "scan.pdf")
+		part.Write([]byte("%PDF-1.4 scan"))
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/analyze?"+query, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("%s: expected %s, got %d %s", query, want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAnalyzePDFHandler_Keywords(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "solar panels and solar power", WordCount: 5},
+	}
+	router := gin.New()
+	router.POST("/analyze", NewHandler(usecase.NewAnalyzePDFUseCase(mockPort)).AnalyzePDF)
+
+	for query, want := range map[string]string{
+		"":                                 `"keywords":null`,
+		"analyses=keywords&keywords_top=1": `"keywords":{"documents":0,"keyphrases":[{"count":1,"score":1,"text":"solar panels"}],"keywords":[{"count":2,"score":1,"text":"solar"}],"language":"en"}`,
+		"analyses=summary":                 `"code":"invalid_analysis"`,
+		"analyses=keywords&keywords_top=x": `"code":"invalid_keywords_top"`,
+		"keywords_top=500":                 `"code":"invalid_keywords_top"`,
+	} {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "test.pdf")
+		part.Write([]byte("%PDF-1.4 dummy pdf content"))
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/analyze?"+query, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("%q: expected %s, got %d %s", query, want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAnalyzePDFHandler_CacheHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+	t.Setenv("CACHE_BACKEND", "memory")
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello world", WordCount: 2},
+	}
+	analyzer := cache.NewCachingAnalyzer(mockPort, cache.NewMemoryCache(10, 0))
+	handler := NewHandler(usecase.NewAnalyzePDFUseCase(analyzer))
+
+	router := gin.New()
+	router.POST("/analyze", handler.AnalyzePDF)
+
+	post := func() *httptest.ResponseRecorder {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "test.pdf")
+		part.Write([]byte("%PDF-1.4 cached content"))
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/analyze", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if got := post().Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("expected X-Cache MISS on first upload, got %q", got)
+	}
+
+	w := post()
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("expected X-Cache HIT on second upload, got %q", got)
+	}
+	if !bytes.Contains(w.Body.Bytes(), []byte(`"cached":true`)) {
+		t.Errorf("expected cached=true in response, got %s", w.Body.String())
+	}
+}
+
+// panickingAnalyzer simulates a crash inside the analysis.
+type panickingAnalyzer struct{}
+
+func (panickingAnalyzer) AnalyzeFile(ctx context.Context, path string, opts port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	panic("analyzer crashed")
+}
+
+func (panickingAnalyzer) ExtractForms(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.FormField, error) {
+	panic("analyzer crashed")
+}
+
+func (panickingAnalyzer) ExtractImage(ctx context.Context, path string, opts port.AnalyzeOptions, n int) (domain.ImageData, error) {
+	panic("analyzer crashed")
+}
+
+func (panickingAnalyzer) ExtractTables(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.Table, error) {
+	panic("analyzer crashed")
+}
+
+func (panickingAnalyzer) ExtractScans(ctx context.Context, path string, opts port.AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error) {
+	panic("analyzer crashed")
+}
+
+func (panickingAnalyzer) CountContent(pageTexts []string) domain.ContentStats {
+	panic("analyzer crashed")
+}
+
+func TestAnalyzePDFHandler_UploadIsStagedSafely(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	root := t.TempDir()
+	tempFolder := filepath.Join(root, "tmp")
+	t.Setenv("TEMP_FOLDER", tempFolder)
+
+	tests := []struct {
+		name     string
+		analyzer port.PDFAnalyzerPort
+		filename string
+	}{
+		{"path traversal", &mock.MockPDFAnalyzer{Result: domain.AnalysisResult{Content: "hello", WordCount: 1}}, "../escape.pdf"},
+		{"analyzer error", &mock.MockPDFAnalyzer{Err: errors.New("boom")}, "report.pdf"},
+		{"analyzer panic", panickingAnalyzer{}, "report.pdf"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(usecase.NewAnalyzePDFUseCase(tc.analyzer))
+
+			router := gin.New()
+			router.Use(gin.Recovery())
+			router.POST("/analyze", handler.AnalyzePDF)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("file", tc.filename)
+			part.Write([]byte("%PDF-1.4 content"))
+			writer.Close()
+
+			req := httptest.NewRequest("POST", "/analyze", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if _, err := os.Stat(filepath.Join(root, "escape.pdf")); !os.IsNotExist(err) {
+				t.Errorf("upload escaped the temp folder")
+			}
+
+			entries, _ := os.ReadDir(tempFolder)
+			if len(entries) != 0 {
+				t.Errorf("expected temp folder to be empty, found %d files", len(entries))
+			}
+		})
+	}
+}
+
+func TestAnalyzePDFHandler_UploadLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+	t.Setenv("MAX_UPLOAD_BYTES", "64")
+
+	tests := []struct {
+		name     string
+		content  string
+		analyzer port.PDFAnalyzerPort
+		expected int
+	}{
+		{"not a pdf", "dummy pdf content", &mock.MockPDFAnalyzer{}, 415},
+		{"too large", "%PDF-1.4 " + strings.Repeat("x", 100), &mock.MockPDFAnalyzer{}, 413},
+		{"too many pages", "%PDF-1.4", &mock.MockPDFAnalyzer{Err: fmt.Errorf("%w: 5000 pages", domain.ErrDocumentTooLarge)}, 413},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(usecase.NewAnalyzePDFUseCase(tc.analyzer))
+
+			router := gin.New()
+			router.POST("/analyze", handler.AnalyzePDF)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("file", "test.pdf")
+			part.Write([]byte(tc.content))
+			writer.Close()
+
+			req := httptest.NewRequest("POST", "/analyze", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expected {
+				t.Fatalf("expected status %d, got %d: %s", tc.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// An oversized upload is rejected once the limit is reached, not after
+// the whole body has been read.
+func TestAnalyzePDFHandler_UploadLimitStopsReading(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+	t.Setenv("MAX_UPLOAD_BYTES", "64")
+	t.Setenv("BATCH_MAX_BYTES", "64")
+
+	uc := usecase.NewAnalyzePDFUseCase(&mock.MockPDFAnalyzer{})
+	router := gin.New()
+	router.POST("/analyze", NewHandler(uc).AnalyzePDF)
+	router.POST("/analyze/batch", NewBatchHandler(usecase.NewAnalyzeBatchUseCase(uc, 1)).AnalyzeBatch)
+
+	const size = 20 << 20
+	for _, tc := range []struct{ path, field string }{
+		{"/analyze?ocr=off", "file"},
+		{"/analyze/batch?ocr=off", "files"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			writer.WriteField("password", "s3cret")
+			part, _ := writer.CreateFormFile(tc.field, "big.pdf")
+			part.Write(append([]byte("%PDF-1.4 "), make([]byte, size)...))
+			writer.Close()
+
+			counter := &countingReader{r: body}
+			req := httptest.NewRequest("POST", tc.path, counter)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != 413 {
+				t.Fatalf("expected status 413, got %d: %s", w.Code, w.Body.String())
+			}
+			if counter.n > 2<<20 {
+				t.Errorf("read %d bytes of a %d byte body before rejecting it", counter.n, size)
+			}
+		})
+	}
+}
+
+// lockedAnalyzer opens only with its password, like an encrypted PDF.
+type lockedAnalyzer struct{ password string }
+
+func (a lockedAnalyzer) AnalyzeFile(ctx context.Context, path string, opts port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	switch opts.Password {
+	case "":
+		return domain.AnalysisResult{}, domain.ErrPasswordRequired
+	case a.password:
+		return domain.AnalysisResult{
+			Content:    "secret",
+			WordCount:  1,
+			Encryption: domain.Encryption{Encrypted: true, Filter: "Standard", KeyLength: 128, Permissions: domain.Permissions{Print: true}},
+		}, nil
+	default:
+		return domain.AnalysisResult{}, domain.ErrIncorrectPassword
+	}
+}
+
+func (lockedAnalyzer) ExtractForms(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.FormField, error) {
+	return nil, domain.ErrPasswordRequired
+}
+
+func (lockedAnalyzer) ExtractImage(ctx context.Context, path string, opts port.AnalyzeOptions, n int) (domain.ImageData, error) {
+	return domain.ImageData{}, domain.ErrPasswordRequired
+}
+
+func (lockedAnalyzer) ExtractTables(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.Table, error) {
+	return nil, domain.ErrPasswordRequired
+}
+
+func (lockedAnalyzer) ExtractScans(ctx context.Context, path string, opts port.AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error) {
+	return nil, domain.ErrPasswordRequired
+}
+
+func (lockedAnalyzer) CountContent(pageTexts []string) domain.ContentStats {
+	return domain.ContentStats{}
+}
+
+func TestAnalyzePDFHandler_Password(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	tests := []struct {
+		name     string
+		password string
+		expected int
+		contains string
+	}{
+		{"missing", "", 401, `"code":"password_required"`},
+		{"incorrect", "wrong", 422, `"code":"incorrect_password"`},
+		{"correct", "s3cret", 200, `"key_length":128`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(usecase.NewAnalyzePDFUseCase(lockedAnalyzer{password: "s3cret"}))
+
+			router := gin.New()
+			router.POST("/analyze", handler.AnalyzePDF)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("file", "locked.pdf")
+			part.Write([]byte("%PDF-1.4 encrypted"))
+			if tc.password != "" {
+				writer.WriteField("password", tc.password)
+			}
+			writer.Close()
+
+			req := httptest.NewRequest("POST", "/analyze", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expected {
+				t.Fatalf("expected status %d, got %d: %s", tc.expected, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Errorf("expected %s in response, got %s", tc.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAnalyzePDFHandler_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+	t.Setenv("ANALYSIS_TIMEOUT", "10ms")
+
+	mockPort := &mock.MockPDFAnalyzer{Wait: make(chan struct{})}
+	defer close(mockPort.Wait)
+
+	handler := NewHandler(usecase.NewAnalyzePDFUseCase(mockPort))
+
+	router := gin.New()
+	router.POST("/analyze", handler.AnalyzePDF)
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "slow.pdf")
+	part.Write([]byte("%PDF-1.4 slow"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/analyze", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != 504 {
+		t.Fatalf("expected status 504, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"mime"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+type JobHandler struct {
+	jobs *usecase.AnalysisJobsUseCase
+}
+
+func NewJobHandler(jobs *usecase.AnalysisJobsUseCase) *JobHandler {
+	return &JobHandler{jobs: jobs}
+}
+
+// SubmitJob godoc
+// @Summary Submit a PDF for asynchronous analysis
+// @Description Upload a PDF file and receive a job ID to poll for the result
+// @Tags jobs
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "PDF file"
+// @Param password formData string false "Password for encrypted PDFs"
+// @Param ocr query string false "OCR of image-only pages: auto (default), force or off"
+// @Param layout query string false "Text extraction: raw (default, content stream order), reading or physical"
+// @Param analyses query string false "Comma-separated text analyses to run: keywords"
+// @Param keywords_top query int false "Number of keywords and keyphrases returned, 1 to 100 (default 10)"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Router /jobs [post]
+func (h *JobHandler) SubmitJob(c *gin.Context) {
+	cfg := config.Load()
+
+	input, err := analyzeInput(c)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	fileName, staged, ok := stageUpload(c, cfg)
+	if !ok {
+		return
+	}
+	input.FilePath = staged.Path
+	input.Password = c.PostForm("password")
+
+	// The upload outlives this request: the job removes it when it
+	// finishes.
+	job, err := h.jobs.Submit(c.Request.Context(), dto.SubmitJobInputDTO{
+		Analyze:  input,
+		FileName: fileName,
+		Timeout:  cfg.AnalysisTimeout,
+		Release:  func() { _ = staged.Remove() },
+	})
+	if err != nil {
+		_ = staged.Remove()
+		writeProblem(c, err)
+		return
+	}
+
+	writeSuccessStatus(c, 202, presentJob(job))
+}
+
+// GetJob godoc
+// @Summary Get the status of an analysis job
+// @Description Returns the job status and, once finished, its result or error
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} Problem
+// @Router /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	job, err := h.jobs.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	writeSuccess(c, presentJob(job))
+}
+
+// CancelJob godoc
+// @Summary Cancel an analysis job
+// @Description Cancels a queued or running job
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Router /jobs/{id} [delete]
+func (h *JobHandler) CancelJob(c *gin.Context) {
+	job, err := h.jobs.Cancel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+	recordCancellation(cancelReasonJob)
+
+	writeSuccess(c, presentJob(job))
+}
+
+// GetImage godoc
+// @Summary Download an image of an analyzed document
+// @Description Returns the n-th image of the inventory of a succeeded job, while the job is retained
+// @Tags jobs
+// @Produce octet-stream
+// @Param id path string true "Job ID"
+// @Param n path int true "1-based image index"
+// @Success 200 {file} binary
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /analyses/{id}/images/{n} [get]
+func (h *JobHandler) GetImage(c *gin.Context) {
+	n, err := strconv.Atoi(c.Param("n"))
+	if err != nil {
+		writeProblem(c, domain.ErrImageNotFound)
+		return
+	}
+
+	ctx, cancel := config.Load().AnalysisContext(c.Request.Context())
+	defer cancel()
+
+	img, err := h.jobs.Image(ctx, c.Param("id"), n)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": img.FileName}))
+	c.Data(200, img.ContentType, img.Data)
+}
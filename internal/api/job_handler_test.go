@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/jobstore"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+type jobResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		Result struct {
+			WordCount int `json:"word_count"`
+		} `json:"result"`
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	} `json:"data"`
+}
+
+func newJobRouter(t *testing.T, mockPort *mock.MockPDFAnalyzer) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	jobs := usecase.NewAnalysisJobsUseCase(usecase.NewAnalyzePDFUseCase(mockPort), jobstore.NewMemoryJobStore(0), 1, 10)
+	t.Cleanup(func() { _ = jobs.Close(context.Background()) })
+
+	handler := NewJobHandler(jobs)
+
+	router := gin.New()
+	router.POST("/jobs", handler.SubmitJob)
+	router.GET("/jobs/:id", handler.GetJob)
+	router.DELETE("/jobs/:id", handler.CancelJob)
+	router.GET("/analyses/:id/images/:n", handler.GetImage)
+	return router
+}
+
+func submitJob(t *testing.T, router *gin.Engine) jobResponse {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "test.pdf")
+	part.Write([]byte("%PDF-1.4 dummy pdf content"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/jobs", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp jobResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return resp
+}
+
+func getJob(t *testing.T, router *gin.Engine, method, id string) (int, jobResponse) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/jobs/"+id, nil))
+
+	var resp jobResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return w.Code, resp
+}
+
+func TestJobHandler_SubmitAndPoll(t *testing.T) {
+	router := newJobRouter(t, &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello world", WordCount: 2},
+	})
+
+	submitted := submitJob(t, router)
+	if submitted.Data.ID == "" || submitted.Data.Status != "queued" {
+		t.Fatalf("expected a queued job, got %+v", submitted.Data)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		code, resp := getJob(t, router, "GET", submitted.Data.ID)
+		if code != 200 {
+			t.Fatalf("expected status 200, got %d", code)
+		}
+		if resp.Data.Status == "succeeded" {
+			if resp.Data.Result.WordCount != 2 {
+				t.Errorf("expected word_count=2 in result, got %d", resp.Data.Result.WordCount)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not succeed, last status %q", resp.Data.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestJobHandler_Cancel(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello", WordCount: 1},
+		Wait:   make(chan struct{}),
+	}
+	router := newJobRouter(t, mockPort)
+	defer close(mockPort.Wait)
+
+	submitted := submitJob(t, router)
+
+	code, resp := getJob(t, router, "DELETE", submitted.Data.ID)
+	if code != 200 {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+	if resp.Data.Status != "cancelled" || resp.Data.Error.Code != "job_cancelled" {
+		t.Errorf("expected cancelled job, got %+v", resp.Data)
+	}
+
+	if code, _ := getJob(t, router, "DELETE", submitted.Data.ID); code != 409 {
+		t.Errorf("expected status 409 when cancelling twice, got %d", code)
+	}
+}
+
+func TestJobHandler_GetImage(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages:     []domain.PageAnalysis{{Number: 1, WordCount: 1}},
+			Images:    []domain.Image{{Page: 1, Name: "Im1", Width: 16, Height: 16, Filter: "DCT"}},
+		},
+		Images: []domain.ImageData{{ContentType: "image/jpeg", Extension: ".jpg", Data: []byte("jpeg")}},
+	}
+	router := newJobRouter(t, mockPort)
+
+	id := submitJob(t, router).Data.ID
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, resp := getJob(t, router, "GET", id)
+		if resp.Data.Status == "succeeded" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not succeed, last status %q", resp.Data.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/analyses/"+id+"/images/1", nil))
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "image/jpeg" || w.Body.String() != "jpeg" {
+		t.Errorf("unexpected image %q: %q", w.Header().Get("Content-Type"), w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != "attachment; filename=test-image-1.jpg" {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+
+	for _, path := range []string{"/analyses/" + id + "/images/2", "/analyses/" + id + "/images/x", "/analyses/missing/images/1"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != 404 {
+			t.Errorf("GET %s: expected status 404, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestJobHandler_NotFound(t *testing.T) {
+	router := newJobRouter(t, &mock.MockPDFAnalyzer{})
+
+	if code, _ := getJob(t, router, "GET", "missing"); code != 404 {
+		t.Errorf("expected status 404, got %d", code)
+	}
+}
+
+func TestJobHandler_MissingFile(t *testing.T) {
+	router := newJobRouter(t, &mock.MockPDFAnalyzer{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs", nil))
+
+	if w.Code != 400 {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
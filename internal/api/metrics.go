@@ -34,12 +34,44 @@ var (
 		},
 		[]string{"method", "path"},
 	)
+
+	cacheHitCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "analysis_cache_hits_total",
+			Help: "Total analyses served from the result cache",
+		},
+	)
+
+	cacheMissCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "analysis_cache_misses_total",
+			Help: "Total analyses that were not found in the result cache",
+		},
+	)
+
+	cancelledCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "analysis_cancelled_total",
+			Help: "Total analyses stopped before completion",
+		},
+		[]string{"reason"},
+	)
+)
+
+// Reasons recorded by analysis_cancelled_total.
+const (
+	cancelReasonTimeout    = "timeout"
+	cancelReasonDisconnect = "client_disconnect"
+	cancelReasonJob        = "job_cancelled"
 )
 
 func init() {
 	prometheus.MustRegister(requestCounter)
 	prometheus.MustRegister(errorCounter)
 	prometheus.MustRegister(latencyHistogram)
+	prometheus.MustRegister(cacheHitCounter)
+	prometheus.MustRegister(cacheMissCounter)
+	prometheus.MustRegister(cancelledCounter)
 }
 
 func MetricsMiddleware() gin.HandlerFunc {
@@ -64,3 +96,20 @@ func MetricsMiddleware() gin.HandlerFunc {
 func MetricsHandler() gin.HandlerFunc {
 	return gin.WrapH(promhttp.Handler())
 }
+
+// recordCacheResult counts a cache lookup and reports it to the
+// client through the X-Cache header.
+func recordCacheResult(c *gin.Context, hit bool) {
+	if hit {
+		cacheHitCounter.Inc()
+		c.Header("X-Cache", "HIT")
+		return
+	}
+	cacheMissCounter.Inc()
+	c.Header("X-Cache", "MISS")
+}
+
+// recordCancellation counts an analysis that did not run to completion.
+func recordCancellation(reason string) {
+	cancelledCounter.WithLabelValues(reason).Inc()
+}
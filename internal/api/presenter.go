@@ -0,0 +1,574 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// Presenters convert use case output DTOs into the JSON
+// shapes exposed by the HTTP API.
+
+func presentAnalysis(fileName string, output dto.AnalyzePDFOutputDTO) gin.H {
+	return gin.H{
+		"file":            fileName,
+		"word_count":      output.WordCount,
+		"token_count":     output.TokenCount,
+		"sentence_count":  output.SentenceCount,
+		"paragraph_count": output.ParagraphCount,
+		"needs_ocr":       output.NeedsOCR,
+		"text_coverage":   output.TextCoverage,
+		"pages":           presentPages(output.Pages),
+		"metadata":        presentMetadata(output.Metadata),
+		"encryption":      presentEncryption(output.Encryption),
+		"outline":         presentOutline(output.Outline),
+		"annotations":     presentAnnotations(output.Annotations),
+		"images":          presentImages(output.Images),
+		"fonts":           presentFonts(output.Fonts),
+		"language":        presentLanguage(output.Language),
+		"readability":     presentReadability(output.Readability),
+		"keywords":        presentKeywords(output.Keywords),
+		"pii":             presentPII(output.PII),
+		"cached":          output.Cached,
+		"status":          "completed",
+	}
+}
+
+func presentEncryption(e dto.EncryptionDTO) gin.H {
+	if !e.Encrypted {
+		return gin.H{"encrypted": false}
+	}
+	p := e.Permissions
+	return gin.H{
+		"encrypted":  true,
+		"filter":     e.Filter,
+		"version":    e.Version,
+		"revision":   e.Revision,
+		"key_length": e.KeyLength,
+		"algorithm":  e.Algorithm,
+		"permissions": gin.H{
+			"print":                     p.Print,
+			"print_high_quality":        p.PrintHighQuality,
+			"modify":                    p.Modify,
+			"copy":                      p.Copy,
+			"annotate":                  p.Annotate,
+			"fill_forms":                p.FillForms,
+			"extract_for_accessibility": p.ExtractForAccess,
+			"assemble":                  p.Assemble,
+		},
+	}
+}
+
+// presentOutline renders the outline tree. Unknown destination pages
+// are null.
+func presentOutline(o dto.OutlineDTO) gin.H {
+	var source any
+	if o.Source != "" {
+		source = o.Source
+	}
+	return gin.H{"source": source, "items": presentOutlineItems(o.Items)}
+}
+
+func presentOutlineItems(items []dto.OutlineItemDTO) []gin.H {
+	out := make([]gin.H, 0, len(items))
+	for _, it := range items {
+		var page any
+		if it.Page > 0 {
+			page = it.Page
+		}
+		out = append(out, gin.H{
+			"title":    it.Title,
+			"level":    it.Level,
+			"page":     page,
+			"children": presentOutlineItems(it.Children),
+		})
+	}
+	return out
+}
+
+// presentAnnotations renders annotations with the fields of their
+// type only: links carry uri or destination_page, markup carries
+// marked_text and stamps carry stamp.
+func presentAnnotations(annotations []dto.AnnotationDTO) []gin.H {
+	out := make([]gin.H, 0, len(annotations))
+	for _, a := range annotations {
+		item := gin.H{
+			"page": a.Page,
+			"type": a.Type,
+			"rect": []float64{a.Rect.X1, a.Rect.Y1, a.Rect.X2, a.Rect.Y2},
+		}
+		if a.Type != "link" {
+			item["contents"] = a.Contents
+			item["author"] = a.Author
+			item["modified"] = a.Modified
+		}
+		switch a.Type {
+		case "link":
+			item["uri"] = a.URI
+			var dest any
+			if a.DestinationPage > 0 {
+				dest = a.DestinationPage
+			}
+			item["destination_page"] = dest
+		case "highlight", "underline", "strike_out", "squiggly":
+			item["marked_text"] = a.MarkedText
+		case "stamp":
+			item["stamp"] = a.Stamp
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// presentImages renders the image inventory with its totals. Missing
+// filters and resolutions of images that are not drawn are null.
+func presentImages(images []dto.ImageDTO) gin.H {
+	items := make([]gin.H, 0, len(images))
+	var total int64
+	for i, img := range images {
+		total += img.ByteSize
+		item := gin.H{
+			"index":              i + 1,
+			"page":               img.Page,
+			"name":               img.Name,
+			"width":              img.Width,
+			"height":             img.Height,
+			"bits_per_component": img.BitsPerComponent,
+			"color_space":        img.ColorSpace,
+			"filter":             nil,
+			"byte_size":          img.ByteSize,
+			"dpi_x":              nil,
+			"dpi_y":              nil,
+		}
+		if img.Filter != "" {
+			item["filter"] = img.Filter
+		}
+		if img.DPIX > 0 && img.DPIY > 0 {
+			item["dpi_x"], item["dpi_y"] = img.DPIX, img.DPIY
+		}
+		items = append(items, item)
+	}
+	return gin.H{"count": len(images), "total_bytes": total, "items": items}
+}
+
+// presentFonts renders the font inventory with the number of fonts
+// print vendors reject and of fonts whose text cannot be extracted.
+func presentFonts(fonts []dto.FontDTO) gin.H {
+	items := make([]gin.H, 0, len(fonts))
+	notEmbedded, notExtractable := 0, 0
+	for _, f := range fonts {
+		if !f.Embedded {
+			notEmbedded++
+		}
+		if !f.TextExtractable {
+			notExtractable++
+		}
+		item := gin.H{
+			"name":             f.Name,
+			"subtype":          f.Subtype,
+			"embedded":         f.Embedded,
+			"subset":           f.Subset,
+			"encoding":         nil,
+			"to_unicode":       f.ToUnicode,
+			"text_extractable": f.TextExtractable,
+			"pages":            f.Pages,
+		}
+		if f.Encoding != "" {
+			item["encoding"] = f.Encoding
+		}
+		items = append(items, item)
+	}
+	return gin.H{"count": len(fonts), "not_embedded": notEmbedded, "not_extractable": notExtractable, "items": items}
+}
+
+// presentReadability renders the readability metrics, with the times
+// in seconds. language is null when the content language is
+// undetermined, and scores is then empty.
+func presentReadability(r dto.ReadabilityDTO) gin.H {
+	out := gin.H{
+		"language":                nil,
+		"sentence_count":          r.SentenceCount,
+		"average_sentence_length": r.AverageSentenceLength,
+		"syllables_per_word":      r.SyllablesPerWord,
+		"scores":                  r.Scores,
+		"reading_time_seconds":    int(r.ReadingTime.Seconds()),
+		"speaking_time_seconds":   int(r.SpeakingTime.Seconds()),
+	}
+	if r.Language != "" {
+		out["language"] = r.Language
+	}
+	return out
+}
+
+// presentKeywords renders the keywords and keyphrases, or null when
+// they were not requested.
+func presentKeywords(k *dto.KeywordsDTO) gin.H {
+	if k == nil {
+		return nil
+	}
+	return gin.H{
+		"language":   k.Language,
+		"documents":  k.Documents,
+		"keywords":   presentKeywordItems(k.Keywords),
+		"keyphrases": presentKeywordItems(k.Keyphrases),
+	}
+}
+
+func presentKeywordItems(items []dto.KeywordDTO) []gin.H {
+	out := make([]gin.H, 0, len(items))
+	for _, k := range items {
+		out = append(out, gin.H{"text": k.Text, "score": k.Score, "count": k.Count})
+	}
+	return out
+}
+
+// presentPII renders the personal data report, or null when no
+// detector is configured. "found" is what a compliance check reads.
+func presentPII(r *dto.PIIReportDTO) gin.H {
+	if r == nil {
+		return nil
+	}
+	matches := make([]gin.H, 0, len(r.Matches))
+	for _, m := range r.Matches {
+		match := gin.H{
+			"type":    m.Type,
+			"source":  m.Source,
+			"page":    nil,
+			"offset":  m.Offset,
+			"length":  m.Length,
+			"preview": m.Preview,
+		}
+		if m.Page > 0 {
+			match["page"] = m.Page
+		}
+		if m.Field != "" {
+			match["field"] = m.Field
+		}
+		matches = append(matches, match)
+	}
+	return gin.H{
+		"found":     r.Count > 0,
+		"count":     r.Count,
+		"by_type":   r.ByType,
+		"pages":     r.Pages,
+		"matches":   matches,
+		"truncated": r.Truncated,
+	}
+}
+
+// presentLanguage renders an identified language, or null when the
+// text was too short to tell.
+func presentLanguage(l dto.LanguageDTO) gin.H {
+	if l.Code == "" {
+		return nil
+	}
+	return gin.H{"code": l.Code, "confidence": l.Confidence}
+}
+
+func presentPages(pages []dto.PageDTO) []gin.H {
+	out := make([]gin.H, 0, len(pages))
+	for _, p := range pages {
+		page := gin.H{
+			"number":         p.Number,
+			"text":           p.Text,
+			"word_count":     p.WordCount,
+			"char_count":     p.CharCount,
+			"width":          p.Width,
+			"height":         p.Height,
+			"rotation":       p.Rotation,
+			"blank":          p.Blank,
+			"kind":           p.Kind,
+			"image_coverage": p.ImageCoverage,
+			"needs_ocr":      p.NeedsOCR,
+			"ocr":            p.OCR,
+			"ocr_confidence": nil,
+			"language":       presentLanguage(p.Language),
+		}
+		if p.OCR {
+			page["ocr_confidence"] = p.OCRConfidence
+		}
+		out = append(out, page)
+	}
+	return out
+}
+
+func presentMetadata(md dto.MetadataDTO) gin.H {
+	xmp := md.XMP
+	if xmp == nil {
+		xmp = map[string]string{}
+	}
+
+	return gin.H{
+		"title":             md.Title,
+		"author":            md.Author,
+		"subject":           md.Subject,
+		"keywords":          md.Keywords,
+		"creator":           md.Creator,
+		"producer":          md.Producer,
+		"creation_date":     md.CreationDate,
+		"modification_date": md.ModificationDate,
+		"pdf_version":       md.PDFVersion,
+		"page_count":        md.PageCount,
+		"xmp":               xmp,
+	}
+}
+
+func presentJob(job dto.JobDTO) gin.H {
+	out := gin.H{
+		"id":          job.ID,
+		"status":      job.Status,
+		"file":        job.FileName,
+		"created_at":  job.CreatedAt.Format(time.RFC3339),
+		"started_at":  presentTime(job.StartedAt),
+		"finished_at": presentTime(job.FinishedAt),
+	}
+
+	if job.Output != nil {
+		out["result"] = presentAnalysis(job.FileName, *job.Output)
+	}
+	if job.Err != nil {
+		out["error"] = presentError(job.Err)
+	}
+
+	return out
+}
+
+func presentBatch(output dto.AnalyzeBatchOutputDTO) gin.H {
+	files := make([]gin.H, 0, len(output.Items))
+	for _, item := range output.Items {
+		if item.Err != nil {
+			files = append(files, gin.H{
+				"file":    item.Name,
+				"success": false,
+				"error":   presentError(item.Err),
+			})
+			continue
+		}
+		files = append(files, gin.H{
+			"file":    item.Name,
+			"success": true,
+			"data":    presentAnalysis(item.Name, *item.Output),
+		})
+	}
+
+	succeeded := output.Succeeded()
+	return gin.H{
+		"files":     files,
+		"total":     len(output.Items),
+		"succeeded": succeeded,
+		"failed":    len(output.Items) - succeeded,
+	}
+}
+
+func presentForms(fileName string, output dto.ExtractFormsOutputDTO) gin.H {
+	fields := make([]gin.H, 0, len(output.Fields))
+	for _, f := range output.Fields {
+		options := make([]gin.H, 0, len(f.Options))
+		for _, o := range f.Options {
+			options = append(options, gin.H{"value": o.Value, "label": o.Label})
+		}
+		var page any
+		if f.Page > 0 {
+			page = f.Page
+		}
+		field := gin.H{
+			"name":      f.Name,
+			"type":      f.Type,
+			"value":     f.Value,
+			"options":   options,
+			"required":  f.Required,
+			"read_only": f.ReadOnly,
+			"page":      page,
+			"rect":      []float64{f.Rect.X1, f.Rect.Y1, f.Rect.X2, f.Rect.Y2},
+		}
+		if f.Values != nil {
+			field["values"] = f.Values
+		}
+		fields = append(fields, field)
+	}
+
+	return gin.H{
+		"file":   fileName,
+		"fields": fields,
+		"total":  len(fields),
+	}
+}
+
+// formsCSVHeader is the first row of the CSV rendering of form fields.
+var formsCSVHeader = []string{"name", "type", "value", "options", "required", "read_only", "page", "x1", "y1", "x2", "y2"}
+
+// presentFormsCSV renders form fields as one CSV row each. Selected
+// values and options are joined with "|"; an option whose label
+// differs from its value is written as value=label.
+func presentFormsCSV(output dto.ExtractFormsOutputDTO) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(formsCSVHeader)
+
+	for _, f := range output.Fields {
+		value := f.Value
+		if f.Values != nil {
+			value = strings.Join(f.Values, "|")
+		}
+		options := make([]string, 0, len(f.Options))
+		for _, o := range f.Options {
+			if o.Label != "" && o.Label != o.Value {
+				options = append(options, o.Value+"="+o.Label)
+			} else {
+				options = append(options, o.Value)
+			}
+		}
+		page := ""
+		if f.Page > 0 {
+			page = strconv.Itoa(f.Page)
+		}
+
+		_ = w.Write([]string{
+			csvCell(f.Name), f.Type, csvCell(value), csvCell(strings.Join(options, "|")),
+			strconv.FormatBool(f.Required), strconv.FormatBool(f.ReadOnly), page,
+			formatPoint(f.Rect.X1), formatPoint(f.Rect.Y1), formatPoint(f.Rect.X2), formatPoint(f.Rect.Y2),
+		})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvCell neutralizes text that spreadsheets would evaluate as a
+// formula, since cell and field values come from untrusted documents.
+// Signed numbers, such as the negative amounts of financial tables,
+// are left as they are: spreadsheets read them as numbers.
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) && !signedNumber.MatchString(s) {
+		return "'" + s
+	}
+	return s
+}
+
+// signedNumber matches a number with a leading sign and an optional
+// currency symbol, grouped and with a decimal part in either
+// convention: "-12.50", "+3", "-1.234,56", "-$ 1,234.56", "-5%".
+var signedNumber = regexp.MustCompile(`^[+-]\p{Sc}?\s?[0-9][0-9.,]*%?$`)
+
+func formatPoint(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func presentTables(fileName string, output dto.ExtractTablesOutputDTO) gin.H {
+	tables := make([]gin.H, 0, len(output.Tables))
+	for i, t := range output.Tables {
+		cells := make([]gin.H, 0, len(t.Cells))
+		for _, c := range t.Cells {
+			cells = append(cells, gin.H{
+				"row":         c.Row,
+				"column":      c.Column,
+				"row_span":    c.RowSpan,
+				"column_span": c.ColumnSpan,
+				"text":        c.Text,
+			})
+		}
+		tables = append(tables, gin.H{
+			"index":   i + 1,
+			"page":    t.Page,
+			"method":  t.Method,
+			"rect":    []float64{t.Rect.X1, t.Rect.Y1, t.Rect.X2, t.Rect.Y2},
+			"rows":    t.Rows,
+			"columns": t.Columns,
+			"cells":   cells,
+			"grid":    t.Grid(),
+		})
+	}
+
+	return gin.H{
+		"file":   fileName,
+		"tables": tables,
+		"total":  len(tables),
+	}
+}
+
+// presentTablesCSV renders every table in one CSV document: a row per
+// table row, led by the table index, its page and the row number, all
+// 1-based. Rows are padded to the widest table; a spanning cell's text
+// is written at its top-left position.
+func presentTablesCSV(output dto.ExtractTablesOutputDTO) ([]byte, error) {
+	width := 0
+	for _, t := range output.Tables {
+		width = max(width, t.Columns)
+	}
+	header := []string{"table", "page", "row"}
+	for c := 1; c <= width; c++ {
+		header = append(header, "column_"+strconv.Itoa(c))
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(header)
+
+	for i, t := range output.Tables {
+		for r, cells := range t.Grid() {
+			record := make([]string, 0, len(header))
+			record = append(record, strconv.Itoa(i+1), strconv.Itoa(t.Page), strconv.Itoa(r+1))
+			for _, text := range cells {
+				record = append(record, csvCell(text))
+			}
+			for len(record) < len(header) {
+				record = append(record, "")
+			}
+			_ = w.Write(record)
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// presentTablesMarkdown renders each table as a GitHub-flavored
+// Markdown table under a heading naming its page. The first row is
+// the header row, since Markdown tables require one.
+func presentTablesMarkdown(output dto.ExtractTablesOutputDTO) []byte {
+	var b strings.Builder
+	for i, t := range output.Tables {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "### Table %d (page %d)\n\n", i+1, t.Page)
+		for r, cells := range t.Grid() {
+			b.WriteByte('|')
+			for _, text := range cells {
+				b.WriteString(" " + markdownCell(text) + " |")
+			}
+			b.WriteByte('\n')
+			if r == 0 {
+				b.WriteString("|" + strings.Repeat(" --- |", t.Columns) + "\n")
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+// markdownCell escapes the characters that would break a table row.
+var markdownCell = strings.NewReplacer("\\", "\\\\", "|", "\\|", "\r\n", "<br>", "\n", "<br>", "\r", "<br>").Replace
+
+// presentError renders an error reported inside a successful
+// response, such as a failed job or batch item.
+func presentError(err error) gin.H {
+	return gin.H{
+		"code":    errorCode(err),
+		"message": err.Error(),
+	}
+}
+
+// presentTime renders t as RFC 3339, or nil for the zero time.
+func presentTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
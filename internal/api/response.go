@@ -1,21 +1,101 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
 
 func writeSuccess(c *gin.Context, data interface{}) {
+	writeSuccessStatus(c, 200, data)
+}
+
+func writeSuccessStatus(c *gin.Context, status int, data interface{}) {
 	reqID := c.GetString("request_id")
-	c.JSON(200, gin.H{
+	c.JSON(status, gin.H{
 		"success":    true,
 		"data":       data,
 		"request_id": reqID,
 	})
 }
 
-func writeError(c *gin.Context, status int, message string) {
-	reqID := c.GetString("request_id")
-	c.JSON(status, gin.H{
-		"success":    false,
-		"error":      message,
-		"request_id": reqID,
-	})
+// problemContentType is the media type of RFC 7807 error bodies.
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error body. Code repeats the last segment
+// of Type so clients can switch on it without parsing URNs.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+// writeProblem reports err as an RFC 7807 problem. Status, code and
+// title come from the error registry; detail is the error message
+// and instance identifies the request, so it can be found in logs.
+func writeProblem(c *gin.Context, err error) {
+	e := lookupError(err)
+
+	problem := Problem{
+		Type:   "urn:pdf-expert:problem:" + e.Code,
+		Title:  e.Title,
+		Status: e.Status,
+		Detail: err.Error(),
+		Code:   e.Code,
+	}
+	if reqID := c.GetString("request_id"); reqID != "" {
+		problem.Instance = "urn:uuid:" + reqID
+	}
+
+	// c.JSON keeps a Content-Type that is already set.
+	c.Header("Content-Type", problemContentType)
+	c.JSON(e.Status, problem)
+}
+
+// Response formats of the extraction endpoints. JSON is always
+// available; the others are offered per endpoint.
+const (
+	formatJSON     = "json"
+	formatCSV      = "csv"
+	formatMarkdown = "markdown"
+)
+
+// formatMediaTypes maps the formats to the media types that select
+// them in the Accept header.
+var formatMediaTypes = map[string]string{
+	formatCSV:      "text/csv",
+	formatMarkdown: "text/markdown",
+}
+
+// responseFormat picks the response format from the format query
+// parameter or, when it is absent, from the Accept header. It only
+// returns JSON or one of formats.
+func responseFormat(c *gin.Context, formats ...string) (string, error) {
+	format := c.Query("format")
+	if format == "" {
+		accept := c.GetHeader("Accept")
+		for _, f := range formats {
+			if strings.Contains(accept, formatMediaTypes[f]) {
+				return f, nil
+			}
+		}
+		return formatJSON, nil
+	}
+	if format == formatJSON {
+		return format, nil
+	}
+	for _, f := range formats {
+		if format == f {
+			return format, nil
+		}
+	}
+	names := append([]string{formatJSON}, formats...)
+	if len(names) > 1 {
+		names = append(names[:len(names)-2], names[len(names)-2]+" or "+names[len(names)-1])
+	}
+	return "", fmt.Errorf("%w: format must be %s", errInvalidFormat, strings.Join(names, ", "))
 }
@@ -9,15 +9,28 @@ import (
 	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
 )
 
-func NewRouter(uc *usecase.AnalyzePDFUseCase) *gin.Engine {
+func NewRouter(uc *usecase.AnalyzePDFUseCase, jobs *usecase.AnalysisJobsUseCase, batch *usecase.AnalyzeBatchUseCase, forms *usecase.ExtractFormsUseCase, tables *usecase.ExtractTablesUseCase) *gin.Engine {
 	router := gin.New()
 
+	router.Use(gin.Recovery())
 	router.Use(GinMiddleware())
 	router.Use(MetricsMiddleware())
 
 	handler := NewHandler(uc)
 
 	router.POST("/analyze", handler.AnalyzePDF)
+	router.POST("/analyze/batch", NewBatchHandler(batch).AnalyzeBatch)
+	router.POST("/forms/extract", NewFormsHandler(forms).ExtractForms)
+	router.POST("/tables/extract", NewTablesHandler(tables).ExtractTables)
+
+	// Asynchronous analysis jobs
+	jobHandler := NewJobHandler(jobs)
+	router.POST("/jobs", jobHandler.SubmitJob)
+	router.GET("/jobs/:id", jobHandler.GetJob)
+	router.DELETE("/jobs/:id", jobHandler.CancelJob)
+
+	// Analyses are jobs seen through their results
+	router.GET("/analyses/:id/images/:n", jobHandler.GetImage)
 
 	// Prometheus metrics endpoint
 	router.GET("/metrics", MetricsHandler())
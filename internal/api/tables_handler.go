@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+type TablesHandler struct {
+	tables *usecase.ExtractTablesUseCase
+}
+
+func NewTablesHandler(tables *usecase.ExtractTablesUseCase) *TablesHandler {
+	return &TablesHandler{tables: tables}
+}
+
+// ExtractTables godoc
+// @Summary Extract the tables of a PDF
+// @Description Upload a PDF and receive the tables found from its ruling lines and the alignment of its words, as cell grids with row and column spans. Use format=csv or format=markdown, or Accept: text/csv or text/markdown, for the other renderings.
+// @Tags tables
+// @Accept multipart/form-data
+// @Produce json
+// @Produce text/csv
+// @Produce text/markdown
+// @Param file formData file true "PDF file"
+// @Param password formData string false "Password for encrypted PDFs"
+// @Param pages query string false "Pages to search, e.g. 1-3,5,8- (default: all)"
+// @Param format query string false "json (default), csv or markdown"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /tables/extract [post]
+func (h *TablesHandler) ExtractTables(c *gin.Context) {
+	cfg := config.Load()
+
+	format, err := responseFormat(c, formatCSV, formatMarkdown)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+	pages, err := dto.ParsePageRanges(c.Query("pages"))
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	fileName, staged, ok := stageUpload(c, cfg)
+	if !ok {
+		return
+	}
+	defer staged.Remove()
+
+	ctx, cancel := cfg.AnalysisContext(c.Request.Context())
+	defer cancel()
+
+	output, err := h.tables.Execute(ctx, dto.ExtractTablesInputDTO{
+		FilePath: staged.Path,
+		Password: c.PostForm("password"),
+		Pages:    pages,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAnalysisTimeout):
+			recordCancellation(cancelReasonTimeout)
+		case errors.Is(err, domain.ErrAnalysisCancelled):
+			recordCancellation(cancelReasonDisconnect)
+		}
+
+		writeProblem(c, err)
+		return
+	}
+
+	switch format {
+	case formatCSV:
+		body, err := presentTablesCSV(output)
+		if err != nil {
+			writeProblem(c, err)
+			return
+		}
+		c.Data(200, "text/csv; charset=utf-8", body)
+	case formatMarkdown:
+		c.Data(200, "text/markdown; charset=utf-8", presentTablesMarkdown(output))
+	default:
+		writeSuccess(c, presentTables(fileName, output))
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/usecase"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func newTablesRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TEMP_FOLDER", t.TempDir())
+
+	mockPort := &mock.MockPDFAnalyzer{
+		Tables: []domain.Table{
+			{Page: 1, Method: domain.TableRuled, Rect: domain.Rect{X1: 100, Y1: 610, X2: 400, Y2: 700}, Rows: 2, Columns: 3, Cells: []domain.TableCell{
+				{Row: 0, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "Name"},
+				{Row: 0, Column: 1, RowSpan: 1, ColumnSpan: 2, Text: "Totals"},
+				{Row: 1, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "Widgets\nblue"},
+				{Row: 1, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "=1+2"},
+				{Row: 1, Column: 2, RowSpan: 1, ColumnSpan: 1, Text: "a|b"},
+			}},
+			{Page: 3, Method: domain.TableAligned, Rows: 5, Columns: 2, Cells: []domain.TableCell{
+				{Row: 0, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "Item"},
+				{Row: 0, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "Qty"},
+				{Row: 1, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "Pears"},
+				{Row: 1, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "2"},
+				{Row: 2, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "-12.50"},
+				{Row: 2, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "+3"},
+				{Row: 3, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "-1.234,56"},
+				{Row: 3, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "-$1,234.56"},
+				{Row: 4, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "-2+3"},
+				{Row: 4, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "+cmd|' /C calc'!A0"},
+			}},
+		},
+	}
+
+	router := gin.New()
+	router.POST("/tables/extract", NewTablesHandler(usecase.NewExtractTablesUseCase(mockPort)).ExtractTables)
+	return router
+}
+
+func TestExtractTablesHandler_JSON(t *testing.T) {
+	w := postForm(newTablesRouter(t), "/tables/extract", "")
+
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, want := range []string{
+		`"method":"ruled"`, `"rect":[100,610,400,700]`, `"rows":2`, `"columns":3`,
+		`{"column":1,"column_span":2,"row":0,"row_span":1,"text":"Totals"}`,
+		`"grid":[["Name","Totals",""],["Widgets\nblue","=1+2","a|b"]]`, `"total":2`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("expected %s in response, got %s", want, w.Body.String())
+		}
+	}
+}
+
+func TestExtractTablesHandler_CSV(t *testing.T) {
+	w := postForm(newTablesRouter(t), "/tables/extract", "text/csv")
+
+	if ct := w.Header().Get("Content-Type"); w.Code != 200 || !strings.HasPrefix(ct, "text/csv") {
+		t.Fatalf("expected a CSV response, got %d %q: %s", w.Code, ct, w.Body.String())
+	}
+	want := "table,page,row,column_1,column_2,column_3\n" +
+		"1,1,1,Name,Totals,\n" +
+		"1,1,2,\"Widgets\nblue\",'=1+2,a|b\n" +
+		"2,3,1,Item,Qty,\n" +
+		"2,3,2,Pears,2,\n" +
+		"2,3,3,-12.50,+3,\n" +
+		"2,3,4,\"-1.234,56\",\"-$1,234.56\",\n" +
+		"2,3,5,'-2+3,'+cmd|' /C calc'!A0,\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExtractTablesHandler_Markdown(t *testing.T) {
+	w := postForm(newTablesRouter(t), "/tables/extract?format=markdown&pages=1-2", "")
+
+	if ct := w.Header().Get("Content-Type"); w.Code != 200 || !strings.HasPrefix(ct, "text/markdown") {
+		t.Fatalf("expected a Markdown response, got %d %q: %s", w.Code, ct, w.Body.String())
+	}
+	want := "### Table 1 (page 1)\n\n" +
+		"| Name | Totals |  |\n" +
+		"| --- | --- | --- |\n" +
+		"| Widgets<br>blue | =1+2 | a\\|b |\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExtractTablesHandler_InvalidOptions(t *testing.T) {
+	router := newTablesRouter(t)
+
+	for _, tc := range []struct{ target, code string }{
+		{"/tables/extract?format=xml", "invalid_format"},
+		{"/tables/extract?pages=3-1", "invalid_page_range"},
+		{"/tables/extract?pages=1,,2", "invalid_page_range"},
+		{"/tables/extract?pages=0", "invalid_page_range"},
+	} {
+		w := postForm(router, tc.target, "")
+		if w.Code != 400 || !strings.Contains(w.Body.String(), `"code":"`+tc.code+`"`) {
+			t.Errorf("%s: expected %s 400, got %d: %s", tc.target, tc.code, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jorgediasdsg/pdf-expert/internal/config"
+	"github.com/jorgediasdsg/pdf-expert/internal/upload"
+)
+
+// multipartOverhead is the room left on top of MaxUploadBytes for
+// multipart boundaries, headers and other form fields.
+const multipartOverhead = 1 << 20
+
+// limitRequestBody caps how much of the request body can be read,
+// so oversized uploads are cut off while streaming instead of being
+// spooled to disk by the multipart parser.
+func limitRequestBody(c *gin.Context, cfg config.Config) {
+	if cfg.MaxUploadBytes > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, cfg.MaxUploadBytes+multipartOverhead)
+	}
+}
+
+// stageUpload reads the "file" form field and stages it on disk.
+// On failure it writes the error response and returns ok=false.
+// On success the caller owns the staged file and must remove it.
+func stageUpload(c *gin.Context, cfg config.Config) (name string, staged *upload.StagedFile, ok bool) {
+	limitRequestBody(c, cfg)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if !errors.As(err, &maxBytesErr) {
+			err = errFileRequired
+		}
+		writeProblem(c, err)
+		return "", nil, false
+	}
+
+	staged, err = upload.NewStager(cfg.TempFolder, cfg.MaxUploadBytes).StageMultipart(fileHeader)
+	if err != nil {
+		writeProblem(c, err)
+		return "", nil, false
+	}
+
+	return fileHeader.Filename, staged, true
+}
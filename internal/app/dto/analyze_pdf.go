@@ -1,15 +1,298 @@
 package dto
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
 // AnalyzePDFInputDTO represents the external input passed
 // into the AnalyzePDFUseCase. It is stable, explicit,
 // and independent from HTTP or file system concerns.
 type AnalyzePDFInputDTO struct {
 	FilePath string
+	Password string     // optional, for encrypted documents
+	OCR      OCRMode    // empty means OCRAuto
+	Layout   LayoutMode // empty means LayoutRaw
+
+	// Analyses lists the optional text analyses to run on the
+	// extracted content.
+	Analyses []Analysis
+	// KeywordsTop is the number of keywords and keyphrases returned
+	// by AnalysisKeywords, from 1 to MaxKeywordsTop; 0 means 10.
+	KeywordsTop int
+}
+
+// Analysis names an optional text analysis of the extracted content.
+type Analysis string
+
+const (
+	// AnalysisKeywords extracts the main keywords and keyphrases.
+	AnalysisKeywords Analysis = "keywords"
+)
+
+// MaxKeywordsTop bounds AnalyzePDFInputDTO.KeywordsTop.
+const MaxKeywordsTop = 100
+
+// ParseAnalyses splits a comma-separated list of analyses. Unknown
+// names are kept, for Validate to reject.
+func ParseAnalyses(s string) []Analysis {
+	var analyses []Analysis
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			analyses = append(analyses, Analysis(name))
+		}
+	}
+	return analyses
+}
+
+// Wants reports whether analysis a was requested.
+func (in AnalyzePDFInputDTO) Wants(a Analysis) bool {
+	return slices.Contains(in.Analyses, a)
 }
 
+// OCRMode selects the pages whose text is recognized by OCR.
+type OCRMode string
+
+const (
+	// OCRAuto recognizes image-only pages, when OCR is available.
+	OCRAuto OCRMode = "auto"
+	// OCRForce also recognizes pages with a text layer over large
+	// images, replacing that layer, and fails without OCR.
+	OCRForce OCRMode = "force"
+	// OCROff keeps the text layer only.
+	OCROff OCRMode = "off"
+)
+
+// LayoutMode selects how the text of each page is extracted.
+type LayoutMode string
+
+const (
+	// LayoutRaw keeps the content stream order.
+	LayoutRaw LayoutMode = "raw"
+	// LayoutReading rebuilds lines and columns in reading order.
+	LayoutReading LayoutMode = "reading"
+	// LayoutPhysical also keeps the horizontal spacing of the page.
+	LayoutPhysical LayoutMode = "physical"
+)
+
 // AnalyzePDFOutputDTO is the structure returned by the
 // use case, without exposing domain internals.
 type AnalyzePDFOutputDTO struct {
-	Content   string
+	Content        string
+	WordCount      int
+	TokenCount     int
+	SentenceCount  int
+	ParagraphCount int
+	NeedsOCR       bool    // some page is an image without a text layer
+	TextCoverage   float64 // fraction of non-blank pages with a text layer
+	Pages          []PageDTO
+	Metadata       MetadataDTO
+	Encryption     EncryptionDTO
+	Outline        OutlineDTO
+	Annotations    []AnnotationDTO
+	Images         []ImageDTO
+	Fonts          []FontDTO
+	Language       LanguageDTO // of Content
+	Readability    ReadabilityDTO
+	Keywords       *KeywordsDTO  // nil unless AnalysisKeywords was requested
+	PII            *PIIReportDTO // nil when no PII detector is configured
+	Cached         bool          // served from the result cache
+}
+
+// PageDTO describes a single page of the analyzed document.
+type PageDTO struct {
+	Number    int
+	Text      string
 	WordCount int
+	CharCount int
+	Width     float64
+	Height    float64
+	Rotation  int
+	Blank     bool
+
+	Kind          string // "text", "image", "mixed" or "blank"
+	ImageCoverage float64
+	NeedsOCR      bool
+	OCR           bool    // Text was recognized by OCR
+	OCRConfidence float64 // from 0 to 1, when OCR is set
+
+	Language LanguageDTO
+}
+
+// LanguageDTO is the language of a text as an ISO 639-1 code, empty
+// when undetermined, with the confidence of the identification from 0
+// to 1.
+type LanguageDTO struct {
+	Code       string
+	Confidence float64
+}
+
+// MetadataDTO carries the document metadata. Dates are
+// formatted as RFC 3339 and left empty when unknown.
+type MetadataDTO struct {
+	Title            string
+	Author           string
+	Subject          string
+	Keywords         string
+	Creator          string
+	Producer         string
+	CreationDate     string
+	ModificationDate string
+	PDFVersion       string
+	PageCount        int
+	XMP              map[string]string
+}
+
+// EncryptionDTO describes the security handler of the document.
+// Encrypted is false, and the other fields empty, for plain files.
+type EncryptionDTO struct {
+	Encrypted   bool
+	Filter      string
+	Version     int
+	Revision    int
+	KeyLength   int
+	Algorithm   string
+	Permissions PermissionsDTO
+}
+
+// PermissionsDTO lists the operations allowed to document users.
+type PermissionsDTO struct {
+	Print            bool
+	Modify           bool
+	Copy             bool
+	Annotate         bool
+	FillForms        bool
+	ExtractForAccess bool
+	Assemble         bool
+	PrintHighQuality bool
+}
+
+// OutlineDTO is the navigation tree of the document. Source is
+// "bookmarks", "headings", or empty when no outline was found.
+type OutlineDTO struct {
+	Source string
+	Items  []OutlineItemDTO
+}
+
+// OutlineItemDTO is one outline entry. Page is 0 when unknown.
+type OutlineItemDTO struct {
+	Title    string
+	Level    int
+	Page     int
+	Children []OutlineItemDTO
+}
+
+// AnnotationDTO is a link, comment or text markup on a page. Modified
+// is RFC 3339, empty when unknown; DestinationPage is 0 for links
+// without an internal target.
+type AnnotationDTO struct {
+	Page            int
+	Type            string
+	Rect            RectDTO
+	Contents        string
+	Author          string
+	Modified        string
+	URI             string
+	DestinationPage int
+	MarkedText      string
+	Stamp           string
+}
+
+// ImageDTO describes an image placed on a page. DPIX and DPIY are 0
+// when the image is not drawn.
+type ImageDTO struct {
+	Page             int
+	Name             string
+	Width            int
+	Height           int
+	BitsPerComponent int
+	ColorSpace       string
+	Filter           string
+	ByteSize         int64
+	DPIX             float64
+	DPIY             float64
+}
+
+// ImageDataDTO is an image exported from an analyzed document.
+type ImageDataDTO struct {
+	ContentType string
+	FileName    string // suggested download name
+	Data        []byte
+}
+
+// FontDTO describes a font and the pages it is used on. Encoding is
+// empty for the font's built-in encoding.
+type FontDTO struct {
+	Name            string
+	Subtype         string
+	Embedded        bool
+	Subset          bool
+	Encoding        string
+	ToUnicode       bool
+	TextExtractable bool
+	Pages           []int
+}
+
+// ReadabilityDTO measures how hard the content is to read, with the
+// rules of Language, empty when undetermined. Scores holds the
+// formulas calibrated for that language, by name: none when it has
+// no formula.
+type ReadabilityDTO struct {
+	Language              string
+	SentenceCount         int
+	AverageSentenceLength float64 // words per sentence
+	SyllablesPerWord      float64
+	Scores                map[string]float64
+	ReadingTime           time.Duration // silent reading
+	SpeakingTime          time.Duration // reading aloud
+}
+
+// KeywordsDTO holds what a document is about. Language is the
+// stopword list used, an ISO 639-1 code. Documents is the number of
+// documents analyzed with keywords, which the terms were weighed
+// against; 0 means the terms are ranked by frequency only.
+type KeywordsDTO struct {
+	Language   string
+	Documents  int
+	Keywords   []KeywordDTO // single terms, by TF-IDF
+	Keyphrases []KeywordDTO // phrases of two to four words, by RAKE
+}
+
+// MaxPIIMatches bounds PIIReportDTO.Matches; the counts cover every
+// match.
+const MaxPIIMatches = 1000
+
+// PIIReportDTO summarizes the personal data found in the document:
+// the number of matches, by type, and the pages carrying any, in
+// ascending order. Matches lists them as the domain orders them, up to
+// MaxPIIMatches; Truncated reports that some were left out.
+type PIIReportDTO struct {
+	Count     int
+	ByType    map[string]int
+	Pages     []int
+	Matches   []PIIMatchDTO
+	Truncated bool
+}
+
+// PIIMatchDTO is one match. Offset and Length count characters in the
+// scanned text: the page text, the annotation contents, the field
+// value or the metadata entry named by Source and Field. Preview is
+// the masked value.
+type PIIMatchDTO struct {
+	Type    string
+	Source  string
+	Field   string // form field or metadata entry, "" otherwise
+	Page    int    // 0 when the match is not on a page
+	Offset  int
+	Length  int
+	Preview string
+}
+
+// KeywordDTO is a term or phrase, lowercased, with its score from 0
+// to 1 relative to the best one and its number of occurrences.
+type KeywordDTO struct {
+	Text  string
+	Score float64
+	Count int
 }
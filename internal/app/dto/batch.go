@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyBatch    = errors.New("batch contains no files")
+	ErrBatchTooLarge = errors.New("batch exceeds the maximum number of files")
+)
+
+// AnalyzeBatchInputDTO lists the files of a batch analysis.
+type AnalyzeBatchInputDTO struct {
+	Items   []BatchItemDTO
+	Timeout time.Duration // per item; 0 disables it
+}
+
+// BatchItemDTO is one file of a batch. Err is set when the file was
+// rejected before analysis (e.g. while staging the upload); such
+// items are reported as failed without reaching the analyzer.
+type BatchItemDTO struct {
+	Name    string // client-provided name, only echoed back
+	Analyze AnalyzePDFInputDTO
+	Err     error
+}
+
+// AnalyzeBatchOutputDTO holds one result per input item, in order.
+type AnalyzeBatchOutputDTO struct {
+	Items []BatchItemResultDTO
+}
+
+// BatchItemResultDTO is the outcome of one item: Output on success,
+// Err otherwise.
+type BatchItemResultDTO struct {
+	Name   string
+	Output *AnalyzePDFOutputDTO
+	Err    error
+}
+
+// Validate checks that the batch has at least one item.
+func (in AnalyzeBatchInputDTO) Validate() error {
+	if len(in.Items) == 0 {
+		return ErrEmptyBatch
+	}
+	return nil
+}
+
+// Succeeded counts the items that were analyzed successfully.
+func (out AnalyzeBatchOutputDTO) Succeeded() int {
+	n := 0
+	for _, item := range out.Items {
+		if item.Err == nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// ErrorKind groups errors by how they are reported: each kind is one
+// HTTP status and one CLI exit code.
+type ErrorKind int
+
+const (
+	KindInternal         ErrorKind = iota // unexpected error
+	KindInvalidInput                      // malformed request or settings
+	KindUnsupportedMedia                  // the file is not a PDF
+	KindTooLarge                          // upload, batch or document over a limit
+	KindNotFound                          // unknown job or image
+	KindConflict                          // the job is not in the required state
+	KindUnavailable                       // try again later
+	KindUnauthorized                      // a password is required
+	KindUnprocessable                     // a PDF that cannot be analyzed
+	KindNotImplemented                    // an optional engine is not configured
+	KindTimeout                           // the analysis ran out of time
+	KindCancelled                         // the client went away or the run was interrupted
+)
+
+// ErrorClass describes how an error is reported to clients: a stable,
+// machine-readable code, its kind and a short title that does not
+// change between occurrences.
+type ErrorClass struct {
+	Target error
+	Code   string
+	Kind   ErrorKind
+	Title  string
+}
+
+// ErrorClasses lists the known errors of the application. Errors are
+// matched with errors.Is, in order, so wrapped errors are found.
+// Codes are part of the API contract: never change an existing one.
+var ErrorClasses = []ErrorClass{
+	// Request and upload errors
+	{ErrInvalidPath, "invalid_input", KindInvalidInput, "Invalid input"},
+	{ErrInvalidOCRMode, "invalid_ocr_mode", KindInvalidInput, "Invalid OCR mode"},
+	{ErrInvalidLayout, "invalid_layout", KindInvalidInput, "Invalid layout mode"},
+	{ErrInvalidAnalysis, "invalid_analysis", KindInvalidInput, "Invalid analysis"},
+	{ErrInvalidKeywordsTop, "invalid_keywords_top", KindInvalidInput, "Invalid keywords_top"},
+	{ErrInvalidPageRange, "invalid_page_range", KindInvalidInput, "Invalid page range"},
+	{ErrFileTooLarge, "file_too_large", KindTooLarge, "File too large"},
+	{ErrUnsupportedMediaType, "unsupported_media_type", KindUnsupportedMedia, "Unsupported media type"},
+
+	// Batches and archives
+	{ErrEmptyBatch, "empty_batch", KindInvalidInput, "Empty batch"},
+	{ErrBatchTooLarge, "batch_too_large", KindTooLarge, "Batch too large"},
+	{ErrInvalidArchive, "invalid_archive", KindInvalidInput, "Invalid archive"},
+	{ErrArchiveTooLarge, "archive_too_large", KindTooLarge, "Archive too large"},
+	{ErrUnsafeArchivePath, "unsafe_path", KindInvalidInput, "Unsafe archive path"},
+
+	// Jobs
+	{ErrJobNotFound, "job_not_found", KindNotFound, "Job not found"},
+	{ErrJobFinished, "job_finished", KindConflict, "Job already finished"},
+	{ErrJobQueueFull, "queue_full", KindUnavailable, "Job queue is full"},
+	{ErrJobCancelled, "job_cancelled", KindConflict, "Job cancelled"},
+	{ErrJobNotSucceeded, "job_not_succeeded", KindConflict, "Job has not succeeded"},
+	{domain.ErrImageNotFound, "image_not_found", KindNotFound, "Image not found"},
+
+	// Documents the analyzer cannot process
+	{domain.ErrDocumentTooLarge, "document_too_large", KindTooLarge, "Document too large"},
+	{domain.ErrPasswordRequired, "password_required", KindUnauthorized, "Password required"},
+	{domain.ErrIncorrectPassword, "incorrect_password", KindUnprocessable, "Incorrect password"},
+	{domain.ErrEncryptedDocument, "encrypted_document", KindUnprocessable, "Encrypted document"},
+	{domain.ErrCorruptDocument, "corrupt_document", KindUnprocessable, "Corrupt document"},
+	{domain.ErrUnsupportedDocument, "unsupported_document", KindUnprocessable, "Unsupported document"},
+
+	// Domain invariants
+	{domain.ErrScannedDocument, "scanned_document", KindUnprocessable, "Scanned document"},
+	{domain.ErrEmptyContent, "empty_content", KindUnprocessable, "Empty content"},
+	{domain.ErrInvalidWordCount, "invalid_word_count", KindUnprocessable, "Invalid word count"},
+	{domain.ErrInvalidPage, "invalid_page", KindUnprocessable, "Invalid page"},
+	{domain.ErrInvalidOutline, "invalid_outline", KindUnprocessable, "Invalid outline"},
+	{domain.ErrInvalidFormField, "invalid_form_field", KindUnprocessable, "Invalid form field"},
+	{domain.ErrInvalidAnnotation, "invalid_annotation", KindUnprocessable, "Invalid annotation"},
+	{domain.ErrInvalidImage, "invalid_image", KindUnprocessable, "Invalid image"},
+	{domain.ErrInvalidFont, "invalid_font", KindUnprocessable, "Invalid font"},
+	{domain.ErrInvalidLanguage, "invalid_language", KindUnprocessable, "Invalid language"},
+	{domain.ErrInvalidTable, "invalid_table", KindUnprocessable, "Invalid table"},
+	{domain.ErrInvalidPII, "invalid_pii", KindUnprocessable, "Invalid personal data match"},
+
+	// Optional engines
+	{domain.ErrOCRUnavailable, "ocr_unavailable", KindNotImplemented, "OCR unavailable"},
+
+	// Analysis lifecycle
+	{domain.ErrAnalysisTimeout, "analysis_timeout", KindTimeout, "Analysis timed out"},
+	{domain.ErrAnalysisCancelled, "analysis_cancelled", KindCancelled, "Analysis cancelled"},
+}
+
+// InternalErrorClass is the class of the errors missing from ErrorClasses.
+var InternalErrorClass = ErrorClass{Code: "internal_error", Kind: KindInternal, Title: "Internal error"}
+
+// ClassifyError returns the first class of ErrorClasses that err
+// matches, or InternalErrorClass.
+func ClassifyError(err error) ErrorClass {
+	for _, c := range ErrorClasses {
+		if errors.Is(err, c.Target) {
+			return c
+		}
+	}
+	return InternalErrorClass
+}
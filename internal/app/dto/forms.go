@@ -0,0 +1,47 @@
+package dto
+
+// ExtractFormsInputDTO is the input of the ExtractFormsUseCase.
+type ExtractFormsInputDTO struct {
+	FilePath string
+	Password string // optional, for encrypted documents
+}
+
+// Validate checks whether the external input is minimally correct.
+func (in ExtractFormsInputDTO) Validate() error {
+	if in.FilePath == "" {
+		return ErrInvalidPath
+	}
+	return nil
+}
+
+// ExtractFormsOutputDTO lists the form fields of a document, in
+// field-tree order.
+type ExtractFormsOutputDTO struct {
+	Fields []FormFieldDTO
+}
+
+// FormFieldDTO describes one interactive form field. Type is one of
+// text, checkbox, radio, button, choice or signature. Values is only
+// set for multi-select choice fields.
+type FormFieldDTO struct {
+	Name     string
+	Type     string
+	Value    string
+	Values   []string
+	Options  []FormOptionDTO
+	Required bool
+	ReadOnly bool
+	Page     int // 0 when unknown
+	Rect     RectDTO
+}
+
+// FormOptionDTO is a selectable value and its displayed label.
+type FormOptionDTO struct {
+	Value string
+	Label string
+}
+
+// RectDTO is a rectangle in PDF user space, in points.
+type RectDTO struct {
+	X1, Y1, X2, Y2 float64
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobQueueFull = errors.New("job queue is full")
+	ErrJobFinished  = errors.New("job has already finished")
+	ErrJobCancelled = errors.New("job was cancelled")
+
+	// ErrJobNotSucceeded is returned for images of a job that is
+	// still running or did not succeed.
+	ErrJobNotSucceeded = errors.New("job has not succeeded")
+)
+
+// JobStatus is the lifecycle state of an analysis job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// IsTerminal reports whether the status can no longer change.
+func (s JobStatus) IsTerminal() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+// SubmitJobInputDTO is the input of AnalysisJobsUseCase.Submit.
+type SubmitJobInputDTO struct {
+	Analyze  AnalyzePDFInputDTO
+	FileName string // original name, for display only
+
+	// Timeout bounds the analysis once it starts running.
+	// Zero means no timeout.
+	Timeout time.Duration
+
+	// Release is called once the job reaches a terminal state,
+	// so the caller can free resources such as the uploaded file.
+	Release func()
+}
+
+// JobDTO is a snapshot of an analysis job.
+type JobDTO struct {
+	ID         string
+	Status     JobStatus
+	FileName   string
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Output     *AnalyzePDFOutputDTO // set when Status is JobSucceeded
+	Err        error                // set when Status is JobFailed
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPageRange reports a malformed page selection.
+var ErrInvalidPageRange = errors.New(`pages must be a list of page numbers and ranges, e.g. "1-3,5,8-"`)
+
+// ExtractTablesInputDTO is the input of the ExtractTablesUseCase.
+type ExtractTablesInputDTO struct {
+	FilePath string
+	Password string         // optional, for encrypted documents
+	Pages    []PageRangeDTO // empty means every page
+}
+
+// Validate checks whether the external input is minimally correct.
+func (in ExtractTablesInputDTO) Validate() error {
+	if in.FilePath == "" {
+		return ErrInvalidPath
+	}
+	for _, r := range in.Pages {
+		if r.First < 1 || (r.Last != 0 && r.Last < r.First) {
+			return ErrInvalidPageRange
+		}
+	}
+	return nil
+}
+
+// PageRangeDTO is an inclusive range of 1-based page numbers. A Last
+// of zero runs to the end of the document.
+type PageRangeDTO struct {
+	First, Last int
+}
+
+// ParsePageRanges reads a comma-separated list of pages ("5"), ranges
+// ("1-3") and open ranges ("8-"). The empty string selects every page.
+func ParsePageRanges(s string) ([]PageRangeDTO, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var ranges []PageRangeDTO
+	for _, part := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		r := PageRangeDTO{}
+		var err error
+		if r.First, err = strconv.Atoi(strings.TrimSpace(first)); err != nil || r.First < 1 {
+			return nil, ErrInvalidPageRange
+		}
+		switch {
+		case !isRange:
+			r.Last = r.First
+		case strings.TrimSpace(last) != "":
+			if r.Last, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || r.Last < r.First {
+				return nil, ErrInvalidPageRange
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// ExtractTablesOutputDTO lists the tables of a document, in page
+// order and top to bottom on each page.
+type ExtractTablesOutputDTO struct {
+	Tables []TableDTO
+}
+
+// TableDTO is a table found on a page. Method is "ruled" when the
+// cells are bounded by drawn lines, "aligned" when the columns were
+// found from the word positions.
+type TableDTO struct {
+	Page    int
+	Method  string
+	Rect    RectDTO
+	Rows    int
+	Columns int
+	Cells   []TableCellDTO
+}
+
+// TableCellDTO is a cell at a 0-based row and column, spanning
+// RowSpan rows and ColumnSpan columns.
+type TableCellDTO struct {
+	Row, Column         int
+	RowSpan, ColumnSpan int
+	Text                string
+}
+
+// Grid returns the text of the table as Rows × Columns strings. A
+// spanning cell fills its top-left position; the others stay empty.
+func (t TableDTO) Grid() [][]string {
+	grid := make([][]string, t.Rows)
+	for i := range grid {
+		grid[i] = make([]string, t.Columns)
+	}
+	for _, c := range t.Cells {
+		if c.Row >= 0 && c.Row < t.Rows && c.Column >= 0 && c.Column < t.Columns {
+			grid[c.Row][c.Column] = c.Text
+		}
+	}
+	return grid
+}
@@ -2,12 +2,80 @@ package dto
 
 import "errors"
 
-var ErrInvalidPath = errors.New("file path cannot be empty")
+var (
+	ErrInvalidPath    = errors.New("file path cannot be empty")
+	ErrInvalidOCRMode = errors.New("ocr must be auto, force or off")
+	ErrInvalidLayout  = errors.New("layout must be raw, reading or physical")
+
+	ErrInvalidAnalysis    = errors.New("analyses must be a comma-separated list of: keywords")
+	ErrInvalidKeywordsTop = errors.New("keywords_top must be between 1 and 100")
+
+	// Upload errors, raised before the file reaches the analyzer.
+	ErrFileTooLarge         = errors.New("file exceeds the maximum upload size")
+	ErrUnsupportedMediaType = errors.New("file is not a PDF")
+)
 
 // Validate checks whether the external input is minimally correct.
 func (in AnalyzePDFInputDTO) Validate() error {
 	if in.FilePath == "" {
 		return ErrInvalidPath
 	}
+	return in.ValidateOptions()
+}
+
+// ValidateOptions checks the analysis settings, without the file.
+// Batches use it to reject settings that would fail every item.
+func (in AnalyzePDFInputDTO) ValidateOptions() error {
+	if err := in.OCR.Validate(); err != nil {
+		return err
+	}
+	if err := in.Layout.Validate(); err != nil {
+		return err
+	}
+	for _, a := range in.Analyses {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	if in.KeywordsTop < 0 || in.KeywordsTop > MaxKeywordsTop {
+		return ErrInvalidKeywordsTop
+	}
 	return nil
 }
+
+// Validate rejects unknown OCR modes; the empty mode is OCRAuto.
+func (m OCRMode) Validate() error {
+	switch m {
+	case "", OCRAuto, OCRForce, OCROff:
+		return nil
+	default:
+		return ErrInvalidOCRMode
+	}
+}
+
+// Validate rejects unknown layout modes; the empty mode is LayoutRaw.
+func (m LayoutMode) Validate() error {
+	switch m {
+	case "", LayoutRaw, LayoutReading, LayoutPhysical:
+		return nil
+	default:
+		return ErrInvalidLayout
+	}
+}
+
+// Validate rejects unknown analyses.
+func (a Analysis) Validate() error {
+	switch a {
+	case AnalysisKeywords:
+		return nil
+	default:
+		return ErrInvalidAnalysis
+	}
+}
+
+// Archive errors, raised while extracting a ZIP upload.
+var (
+	ErrInvalidArchive    = errors.New("file is not a valid ZIP archive")
+	ErrArchiveTooLarge   = errors.New("archive exceeds the decompression limits")
+	ErrUnsafeArchivePath = errors.New("archive entry has an unsafe path")
+)
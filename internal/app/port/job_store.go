@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// JobStorePort persists analysis jobs.
+//
+// Implementations must be safe for concurrent use and return
+// dto.ErrJobNotFound for unknown IDs.
+type JobStorePort interface {
+	Create(ctx context.Context, job dto.JobDTO) error
+	Get(ctx context.Context, id string) (dto.JobDTO, error)
+
+	// Update applies fn to the stored job atomically and
+	// returns the updated snapshot.
+	Update(ctx context.Context, id string, fn func(job *dto.JobDTO)) (dto.JobDTO, error)
+}
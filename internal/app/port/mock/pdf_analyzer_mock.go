@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"context"
+	"strings"
+
 	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
 	"github.com/jorgediasdsg/pdf-expert/internal/domain"
 )
@@ -10,12 +13,90 @@ var _ port.PDFAnalyzerPort = (*MockPDFAnalyzer)(nil)
 
 type MockPDFAnalyzer struct {
 	Result domain.AnalysisResult
+	Forms  []domain.FormField
+	Images []domain.ImageData // served by ExtractImage and ExtractScans, 1-based
+	Tables []domain.Table     // filtered by the page ranges
 	Err    error
+
+	// Wait, when set, blocks AnalyzeFile until it is closed
+	// or the context is done. It lets tests observe work in
+	// progress.
+	Wait chan struct{}
 }
 
-func (m *MockPDFAnalyzer) AnalyzeFile(path string) (domain.AnalysisResult, error) {
-	if m.Err != nil {
-		return domain.AnalysisResult{}, m.Err
+func (m *MockPDFAnalyzer) AnalyzeFile(ctx context.Context, path string, opts port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	if err := m.wait(ctx); err != nil {
+		return domain.AnalysisResult{}, err
 	}
 	return m.Result, nil
 }
+
+func (m *MockPDFAnalyzer) ExtractForms(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.FormField, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	return m.Forms, nil
+}
+
+func (m *MockPDFAnalyzer) ExtractImage(ctx context.Context, path string, opts port.AnalyzeOptions, n int) (domain.ImageData, error) {
+	if err := m.wait(ctx); err != nil {
+		return domain.ImageData{}, err
+	}
+	if n < 1 || n > len(m.Images) {
+		return domain.ImageData{}, domain.ErrImageNotFound
+	}
+	return m.Images[n-1], nil
+}
+
+func (m *MockPDFAnalyzer) ExtractTables(ctx context.Context, path string, opts port.AnalyzeOptions) ([]domain.Table, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	if len(opts.Pages) == 0 {
+		return m.Tables, nil
+	}
+	var tables []domain.Table
+	for _, t := range m.Tables {
+		for _, r := range opts.Pages {
+			if r.Contains(t.Page) {
+				tables = append(tables, t)
+				break
+			}
+		}
+	}
+	return tables, nil
+}
+
+// ExtractScans serves the Images numbered by scans.
+func (m *MockPDFAnalyzer) ExtractScans(ctx context.Context, path string, opts port.AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	images := make(map[int]domain.ImageData, len(scans))
+	for page, n := range scans {
+		if n >= 1 && n <= len(m.Images) {
+			images[page] = m.Images[n-1]
+		}
+	}
+	return images, nil
+}
+
+// CountContent joins the page texts with a blank line. Unlike the real analyzer,
+// it counts words as runs of non-spaces and leaves the other counts
+// and the language alone.
+func (m *MockPDFAnalyzer) CountContent(pageTexts []string) domain.ContentStats {
+	content := strings.Join(pageTexts, "\n\n")
+	return domain.ContentStats{Content: content, WordCount: len(strings.Fields(content))}
+}
+
+// wait honors Wait and returns the configured error.
+func (m *MockPDFAnalyzer) wait(ctx context.Context) error {
+	if m.Wait != nil {
+		select {
+		case <-m.Wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return m.Err
+}
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// OCRPort recognizes text in images, such as the scans that make up
+// image-only pages.
+//
+// Implementations must stop working and return ctx.Err()
+// (possibly wrapped) once ctx is done.
+type OCRPort interface {
+	Recognize(ctx context.Context, img domain.ImageData) (domain.OCRResult, error)
+}
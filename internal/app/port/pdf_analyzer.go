@@ -1,6 +1,10 @@
 package port
 
-import "github.com/jorgediasdsg/pdf-expert/internal/domain"
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
 
 // PDFAnalyzerPort defines the interface (port) that
 // the application layer uses to analyze PDF files.
@@ -8,6 +12,48 @@ import "github.com/jorgediasdsg/pdf-expert/internal/domain"
 // Any concrete implementation (adapter) must satisfy
 // this contract, but the app layer only depends on this
 // interface, not on the library or storage details.
+//
+// Implementations must stop working and return ctx.Err()
+// (possibly wrapped) once ctx is done.
 type PDFAnalyzerPort interface {
-	AnalyzeFile(path string) (domain.AnalysisResult, error)
+	AnalyzeFile(ctx context.Context, path string, opts AnalyzeOptions) (domain.AnalysisResult, error)
+
+	// ExtractForms lists the interactive form fields of the file,
+	// or none when it has no form.
+	ExtractForms(ctx context.Context, path string, opts AnalyzeOptions) ([]domain.FormField, error)
+
+	// ExtractImage exports the n-th (1-based) image of
+	// AnalysisResult.Images, or fails with domain.ErrImageNotFound.
+	ExtractImage(ctx context.Context, path string, opts AnalyzeOptions, n int) (domain.ImageData, error)
+
+	// ExtractTables finds the tables of the pages selected by
+	// opts.Pages, in page order.
+	ExtractTables(ctx context.Context, path string, opts AnalyzeOptions) ([]domain.Table, error)
+
+	// ExtractScans exports the scans of pages in one pass over the
+	// file. scans maps a page number to the 1-based number of its
+	// scan in AnalysisResult.Images. The images are returned by page
+	// number; pages whose scan cannot be exported are left out.
+	ExtractScans(ctx context.Context, path string, opts AnalyzeOptions, scans map[int]int) (map[int]domain.ImageData, error)
+
+	// CountContent joins page texts and counts them as
+	// AnalyzeFile counts the document, for texts that replace a
+	// text layer.
+	CountContent(pageTexts []string) domain.ContentStats
+}
+
+// AnalyzeOptions carries per-call settings of an analysis.
+type AnalyzeOptions struct {
+	// Password opens encrypted documents. It is tried as the user
+	// password and then as the owner password. Documents with an
+	// empty user password open without it.
+	Password string
+
+	// Layout selects the text extraction: "raw" (content stream
+	// order, the default), "reading" or "physical".
+	Layout string
+
+	// Pages limits ExtractTables to the pages in any of the ranges;
+	// none means every page.
+	Pages []domain.PageRange
 }
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// PIIDetectorPort finds personal data in text, such as email
+// addresses or card numbers.
+//
+// Detect returns the matches of text in order, with their Offset and
+// Length in characters of text; the caller sets their Source, Field
+// and Page.
+// Implementations must stop working and return ctx.Err() (possibly
+// wrapped) once ctx is done.
+type PIIDetectorPort interface {
+	Detect(ctx context.Context, text string) ([]domain.PIIMatch, error)
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// ResultCachePort stores analysis results by content key
+// (the SHA-256 of the analyzed file).
+//
+// Get reports ok=false on a miss, including expired entries.
+// Implementations must be safe for concurrent use.
+type ResultCachePort interface {
+	Get(ctx context.Context, key string) (result domain.AnalysisResult, ok bool, err error)
+	Set(ctx context.Context, key string, result domain.AnalysisResult) error
+}
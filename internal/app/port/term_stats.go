@@ -0,0 +1,19 @@
+package port
+
+import "context"
+
+// TermStatsPort counts, over the analyzed documents, how many contain
+// each term. Keyword extraction weighs terms by their inverse document
+// frequency, so words common to every document rank below the words
+// that set one apart.
+//
+// Implementations must be safe for concurrent use.
+type TermStatsPort interface {
+	// AddDocument records the distinct terms of a document. A document
+	// already recorded under id is not counted again.
+	AddDocument(ctx context.Context, id string, terms []string) error
+
+	// Frequencies returns the number of recorded documents and, for
+	// each of terms, the number of them that contain it.
+	Frequencies(ctx context.Context, terms []string) (docs int, df map[string]int, err error)
+}
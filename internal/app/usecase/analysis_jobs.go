@@ -0,0 +1,403 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// releaseInterval is how often the uploads of expired jobs are
+// released.
+const releaseInterval = time.Minute
+
+// AnalysisJobsUseCase runs AnalyzePDFUseCase asynchronously.
+//
+// Submitted jobs are queued in a bounded channel and executed by
+// a fixed pool of workers. Each job owns a cancellable context,
+// so Cancel stops queued jobs and signals running ones.
+type AnalysisJobsUseCase struct {
+	analyze *AnalyzePDFUseCase
+	store   port.JobStorePort
+
+	queue chan queuedJob
+	wg    sync.WaitGroup
+	stop  chan struct{} // closed by Close to stop the release loop
+
+	// uploadRetention bounds how long uploads are kept for image
+	// downloads; zero keeps them while the store holds the job.
+	uploadRetention time.Duration
+	now             func() time.Time
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	sources map[string]dto.SubmitJobInputDTO // uploads kept for image downloads
+	closed  bool
+}
+
+// AnalysisJobsOption configures an AnalysisJobsUseCase.
+type AnalysisJobsOption func(*AnalysisJobsUseCase)
+
+// WithUploadRetention releases the upload kept for the image
+// downloads of a job once the job finished more than d ago, even if
+// the store still holds the job. Without it, uploads are released
+// when the store evicts their job.
+func WithUploadRetention(d time.Duration) AnalysisJobsOption {
+	return func(uc *AnalysisJobsUseCase) {
+		uc.uploadRetention = d
+	}
+}
+
+type queuedJob struct {
+	id    string
+	ctx   context.Context
+	input dto.SubmitJobInputDTO
+}
+
+// NewAnalysisJobsUseCase starts workers goroutines that consume a
+// queue holding at most queueSize pending jobs, and a goroutine that
+// releases the uploads of expired jobs.
+func NewAnalysisJobsUseCase(analyze *AnalyzePDFUseCase, store port.JobStorePort, workers, queueSize int, opts ...AnalysisJobsOption) *AnalysisJobsUseCase {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	uc := &AnalysisJobsUseCase{
+		analyze: analyze,
+		store:   store,
+		queue:   make(chan queuedJob, queueSize),
+		stop:    make(chan struct{}),
+		now:     time.Now,
+		cancels: make(map[string]context.CancelFunc),
+		sources: make(map[string]dto.SubmitJobInputDTO),
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+
+	uc.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go uc.worker()
+	}
+	go uc.releaseLoop()
+
+	return uc
+}
+
+// Submit validates the input, records a queued job and hands it to
+// the worker pool. It returns dto.ErrJobQueueFull when the queue
+// cannot take more work; in that case Release is not called.
+func (uc *AnalysisJobsUseCase) Submit(ctx context.Context, input dto.SubmitJobInputDTO) (dto.JobDTO, error) {
+	if err := input.Analyze.Validate(); err != nil {
+		return dto.JobDTO{}, err
+	}
+
+	job := dto.JobDTO{
+		ID:        uuid.NewString(),
+		Status:    dto.JobQueued,
+		FileName:  input.FileName,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.store.Create(ctx, job); err != nil {
+		return dto.JobDTO{}, err
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.closed {
+		cancel()
+		uc.fail(job.ID, dto.ErrJobQueueFull)
+		return dto.JobDTO{}, dto.ErrJobQueueFull
+	}
+
+	select {
+	case uc.queue <- queuedJob{id: job.ID, ctx: jobCtx, input: input}:
+		uc.cancels[job.ID] = cancel
+		return job, nil
+	default:
+		cancel()
+		uc.fail(job.ID, dto.ErrJobQueueFull)
+		return dto.JobDTO{}, dto.ErrJobQueueFull
+	}
+}
+
+// Get returns the current snapshot of a job.
+func (uc *AnalysisJobsUseCase) Get(ctx context.Context, id string) (dto.JobDTO, error) {
+	return uc.store.Get(ctx, id)
+}
+
+// Image exports the n-th (1-based) image of a succeeded job. Images
+// can be downloaded while the job is retained by the store and its
+// upload has not been released.
+func (uc *AnalysisJobsUseCase) Image(ctx context.Context, id string, n int) (dto.ImageDataDTO, error) {
+	job, err := uc.store.Get(ctx, id)
+	if err != nil {
+		return dto.ImageDataDTO{}, err
+	}
+	if job.Status != dto.JobSucceeded {
+		return dto.ImageDataDTO{}, dto.ErrJobNotSucceeded
+	}
+	if n < 1 || n > len(job.Output.Images) {
+		return dto.ImageDataDTO{}, domain.ErrImageNotFound
+	}
+
+	uc.mu.Lock()
+	source, ok := uc.sources[id]
+	uc.mu.Unlock()
+	if !ok {
+		return dto.ImageDataDTO{}, domain.ErrImageNotFound
+	}
+
+	img, err := uc.analyze.analyzer.ExtractImage(ctx, source.Analyze.FilePath, port.AnalyzeOptions{Password: source.Analyze.Password}, n)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Released by a concurrent eviction.
+		return dto.ImageDataDTO{}, domain.ErrImageNotFound
+	}
+	if err != nil {
+		return dto.ImageDataDTO{}, contextError(err)
+	}
+
+	base := strings.TrimSuffix(job.FileName, filepath.Ext(job.FileName))
+	if base == "" {
+		base = "document"
+	}
+	return dto.ImageDataDTO{
+		ContentType: img.ContentType,
+		FileName:    fmt.Sprintf("%s-image-%d%s", base, n, img.Extension),
+		Data:        img.Data,
+	}, nil
+}
+
+// Cancel marks a job as cancelled and cancels its context. Jobs that
+// already finished cannot be cancelled and yield dto.ErrJobFinished.
+func (uc *AnalysisJobsUseCase) Cancel(ctx context.Context, id string) (dto.JobDTO, error) {
+	finished := false
+	job, err := uc.store.Update(ctx, id, func(job *dto.JobDTO) {
+		if job.Status.IsTerminal() {
+			finished = true
+			return
+		}
+		job.Status = dto.JobCancelled
+		job.Err = dto.ErrJobCancelled
+		job.FinishedAt = time.Now()
+	})
+	if err != nil {
+		return dto.JobDTO{}, err
+	}
+	if finished {
+		return job, dto.ErrJobFinished
+	}
+
+	uc.mu.Lock()
+	if cancel, ok := uc.cancels[id]; ok {
+		cancel()
+	}
+	uc.mu.Unlock()
+
+	return job, nil
+}
+
+// Close stops accepting jobs and waits for the workers to drain
+// the queue or for ctx to be done.
+func (uc *AnalysisJobsUseCase) Close(ctx context.Context) error {
+	uc.mu.Lock()
+	if !uc.closed {
+		uc.closed = true
+		close(uc.queue)
+		close(uc.stop)
+	}
+	uc.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		uc.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for id, source := range uc.sources {
+		release(source)
+		delete(uc.sources, id)
+	}
+	return nil
+}
+
+// releaseLoop runs releaseExpired every releaseInterval until Close.
+func (uc *AnalysisJobsUseCase) releaseLoop() {
+	ticker := time.NewTicker(releaseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			uc.releaseExpired(context.Background())
+		case <-uc.stop:
+			return
+		}
+	}
+}
+
+// releaseExpired frees the uploads of jobs the store no longer holds,
+// and of jobs past the upload retention. Reading a job lets the store
+// evict it once it expires.
+func (uc *AnalysisJobsUseCase) releaseExpired(ctx context.Context) {
+	uc.mu.Lock()
+	ids := make([]string, 0, len(uc.sources))
+	for id := range uc.sources {
+		ids = append(ids, id)
+	}
+	uc.mu.Unlock()
+
+	now := uc.now()
+	for _, id := range ids {
+		job, err := uc.store.Get(ctx, id)
+		switch {
+		case errors.Is(err, dto.ErrJobNotFound):
+		case err != nil:
+			continue
+		case uc.uploadRetention <= 0 || !job.FinishedAt.Before(now.Add(-uc.uploadRetention)):
+			continue
+		}
+		uc.mu.Lock()
+		if source, ok := uc.sources[id]; ok {
+			release(source)
+			delete(uc.sources, id)
+		}
+		uc.mu.Unlock()
+	}
+}
+
+func release(input dto.SubmitJobInputDTO) {
+	if input.Release != nil {
+		input.Release()
+	}
+}
+
+func (uc *AnalysisJobsUseCase) worker() {
+	defer uc.wg.Done()
+
+	for q := range uc.queue {
+		uc.run(q)
+	}
+}
+
+// run executes a single job and records its outcome. A job that was
+// cancelled while queued is skipped; one cancelled while running
+// keeps its cancelled status whatever the analysis returns. The upload
+// of a succeeded job with images is kept for image downloads until
+// the store evicts the job or the upload retention ends.
+func (uc *AnalysisJobsUseCase) run(q queuedJob) {
+	retain := false
+	defer func() {
+		uc.mu.Lock()
+		if cancel, ok := uc.cancels[q.id]; ok {
+			cancel()
+			delete(uc.cancels, q.id)
+		}
+		if !retain {
+			delete(uc.sources, q.id)
+		}
+		uc.mu.Unlock()
+
+		if !retain {
+			release(q.input)
+		}
+	}()
+
+	ctx := context.Background()
+
+	started := false
+	_, err := uc.store.Update(ctx, q.id, func(job *dto.JobDTO) {
+		if job.Status != dto.JobQueued {
+			return
+		}
+		job.Status = dto.JobRunning
+		job.StartedAt = time.Now()
+		started = true
+	})
+	if err != nil || !started {
+		return
+	}
+
+	runCtx := q.ctx
+	if q.input.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(q.ctx, q.input.Timeout)
+		defer cancel()
+	}
+
+	output, err := uc.execute(runCtx, q.input.Analyze)
+
+	// The upload is registered before the job turns succeeded, so
+	// its images can be downloaded as soon as it is reported.
+	if err == nil && len(output.Images) > 0 {
+		uc.mu.Lock()
+		uc.sources[q.id] = q.input
+		uc.mu.Unlock()
+	}
+
+	_, _ = uc.store.Update(ctx, q.id, func(job *dto.JobDTO) {
+		if job.Status.IsTerminal() {
+			return
+		}
+
+		job.FinishedAt = time.Now()
+		// q.ctx is only done when the job was cancelled; a timeout
+		// ends runCtx alone and is reported as a failure.
+		switch {
+		case q.ctx.Err() != nil:
+			job.Status = dto.JobCancelled
+			job.Err = dto.ErrJobCancelled
+		case err != nil:
+			job.Status = dto.JobFailed
+			job.Err = err
+		default:
+			job.Status = dto.JobSucceeded
+			job.Output = &output
+			retain = len(output.Images) > 0
+		}
+	})
+}
+
+// execute runs the analysis of a job. A panic is returned as an
+// error, so the job fails and the worker keeps serving the queue.
+func (uc *AnalysisJobsUseCase) execute(ctx context.Context, input dto.AnalyzePDFInputDTO) (output dto.AnalyzePDFOutputDTO, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			output, err = dto.AnalyzePDFOutputDTO{}, fmt.Errorf("analysis panicked: %v", r)
+		}
+	}()
+	return uc.analyze.Execute(ctx, input)
+}
+
+// fail records err on a job that never reached the queue.
+func (uc *AnalysisJobsUseCase) fail(id string, err error) {
+	_, _ = uc.store.Update(context.Background(), id, func(job *dto.JobDTO) {
+		job.Status = dto.JobFailed
+		job.Err = err
+		job.FinishedAt = time.Now()
+	})
+}
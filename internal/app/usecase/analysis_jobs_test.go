@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/jobstore"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func newJobsUseCase(t *testing.T, mockPort *mock.MockPDFAnalyzer, workers, queueSize int) *AnalysisJobsUseCase {
+	t.Helper()
+
+	jobs := NewAnalysisJobsUseCase(NewAnalyzePDFUseCase(mockPort), jobstore.NewMemoryJobStore(0), workers, queueSize)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = jobs.Close(ctx)
+	})
+	return jobs
+}
+
+// waitForStatus polls the job until it reaches want or the test times out.
+func waitForStatus(t *testing.T, jobs *AnalysisJobsUseCase, id string, want dto.JobStatus) dto.JobDTO {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := jobs.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if job.Status == want {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach status %s", id, want)
+	return dto.JobDTO{}
+}
+
+// evictingStore is a job store that evicts every job on demand.
+type evictingStore struct {
+	*jobstore.MemoryJobStore
+	evicted atomic.Bool
+}
+
+func (s *evictingStore) Get(ctx context.Context, id string) (dto.JobDTO, error) {
+	if s.evicted.Load() {
+		return dto.JobDTO{}, dto.ErrJobNotFound
+	}
+	return s.MemoryJobStore.Get(ctx, id)
+}
+
+func submitInput(released *atomic.Int32) dto.SubmitJobInputDTO {
+	return dto.SubmitJobInputDTO{
+		Analyze:  dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"},
+		FileName: "test.pdf",
+		Release:  func() { released.Add(1) },
+	}
+}
+
+func TestAnalysisJobsUseCase_Success(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello world", WordCount: 2},
+	}
+	jobs := newJobsUseCase(t, mockPort, 2, 10)
+
+	var released atomic.Int32
+	job, err := jobs.Submit(context.Background(), submitInput(&released))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != dto.JobQueued || job.ID == "" {
+		t.Fatalf("expected a queued job with an ID, got %+v", job)
+	}
+
+	done := waitForStatus(t, jobs, job.ID, dto.JobSucceeded)
+	if done.Output == nil || done.Output.WordCount != 2 {
+		t.Errorf("expected output with 2 words, got %+v", done.Output)
+	}
+	if done.StartedAt.IsZero() || done.FinishedAt.IsZero() {
+		t.Errorf("expected start and finish times to be set")
+	}
+
+	if err := jobs.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if released.Load() != 1 {
+		t.Errorf("expected Release to be called once, got %d", released.Load())
+	}
+}
+
+func TestAnalysisJobsUseCase_Images(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages:     []domain.PageAnalysis{{Number: 1, WordCount: 1}},
+			Images:    []domain.Image{{Page: 1, Name: "Im1", Width: 20, Height: 10}},
+		},
+		Images: []domain.ImageData{{ContentType: "image/png", Extension: ".png", Data: []byte("png")}},
+	}
+	store := &evictingStore{MemoryJobStore: jobstore.NewMemoryJobStore(0)}
+	jobs := NewAnalysisJobsUseCase(NewAnalyzePDFUseCase(mockPort), store, 1, 10)
+	t.Cleanup(func() { _ = jobs.Close(context.Background()) })
+
+	var released atomic.Int32
+	job, err := jobs.Submit(context.Background(), submitInput(&released))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitForStatus(t, jobs, job.ID, dto.JobSucceeded)
+
+	img, err := jobs.Image(context.Background(), job.ID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if img.FileName != "test-image-1.png" || img.ContentType != "image/png" || string(img.Data) != "png" {
+		t.Errorf("unexpected image: %+v", img)
+	}
+	if _, err := jobs.Image(context.Background(), job.ID, 2); !errors.Is(err, domain.ErrImageNotFound) {
+		t.Errorf("expected ErrImageNotFound, got %v", err)
+	}
+	if released.Load() != 0 {
+		t.Fatalf("expected the upload to be kept for downloads")
+	}
+
+	// Once the store evicts the job, its upload is released by the
+	// next release pass, without waiting for a submission.
+	store.evicted.Store(true)
+	jobs.releaseExpired(context.Background())
+	if released.Load() != 1 {
+		t.Errorf("expected the evicted upload to be released, got %d releases", released.Load())
+	}
+	if _, err := jobs.Image(context.Background(), job.ID, 1); !errors.Is(err, dto.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestAnalysisJobsUseCase_UploadRetention(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages:     []domain.PageAnalysis{{Number: 1, WordCount: 1}},
+			Images:    []domain.Image{{Page: 1, Name: "Im1", Width: 20, Height: 10}},
+		},
+		Images: []domain.ImageData{{ContentType: "image/png", Extension: ".png", Data: []byte("png")}},
+	}
+	// The store keeps finished jobs forever; their uploads are
+	// released after the upload retention all the same.
+	jobs := NewAnalysisJobsUseCase(NewAnalyzePDFUseCase(mockPort), jobstore.NewMemoryJobStore(0), 1, 10, WithUploadRetention(time.Hour))
+	t.Cleanup(func() { _ = jobs.Close(context.Background()) })
+
+	var released atomic.Int32
+	job, err := jobs.Submit(context.Background(), submitInput(&released))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitForStatus(t, jobs, job.ID, dto.JobSucceeded)
+
+	jobs.releaseExpired(context.Background())
+	if released.Load() != 0 {
+		t.Fatalf("expected the upload to be kept within the retention")
+	}
+
+	jobs.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	jobs.releaseExpired(context.Background())
+	if released.Load() != 1 {
+		t.Errorf("expected the expired upload to be released, got %d releases", released.Load())
+	}
+	if _, err := jobs.Image(context.Background(), job.ID, 1); !errors.Is(err, domain.ErrImageNotFound) {
+		t.Errorf("expected ErrImageNotFound, got %v", err)
+	}
+	if _, err := jobs.Get(context.Background(), job.ID); err != nil {
+		t.Errorf("expected the job to be kept, got %v", err)
+	}
+}
+
+func TestAnalysisJobsUseCase_Failure(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: ""},
+	}
+	jobs := newJobsUseCase(t, mockPort, 1, 10)
+
+	var released atomic.Int32
+	job, err := jobs.Submit(context.Background(), submitInput(&released))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := waitForStatus(t, jobs, job.ID, dto.JobFailed)
+	if !errors.Is(done.Err, domain.ErrEmptyContent) {
+		t.Errorf("expected ErrEmptyContent, got %v", done.Err)
+	}
+}
+
+// panickingAnalyzer panics on every analysis.
+type panickingAnalyzer struct {
+	*mock.MockPDFAnalyzer
+}
+
+func (panickingAnalyzer) AnalyzeFile(context.Context, string, port.AnalyzeOptions) (domain.AnalysisResult, error) {
+	panic("boom")
+}
+
+func TestAnalysisJobsUseCase_Panic(t *testing.T) {
+	analyzer := panickingAnalyzer{MockPDFAnalyzer: &mock.MockPDFAnalyzer{}}
+	jobs := NewAnalysisJobsUseCase(NewAnalyzePDFUseCase(analyzer), jobstore.NewMemoryJobStore(0), 1, 10)
+	t.Cleanup(func() { _ = jobs.Close(context.Background()) })
+
+	// The job fails and the only worker survives to run the next one.
+	var released atomic.Int32
+	for range 2 {
+		job, err := jobs.Submit(context.Background(), submitInput(&released))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		done := waitForStatus(t, jobs, job.ID, dto.JobFailed)
+		if done.Err == nil || !strings.Contains(done.Err.Error(), "boom") {
+			t.Errorf("expected the panic as the job error, got %v", done.Err)
+		}
+	}
+	if err := jobs.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if released.Load() != 2 {
+		t.Errorf("expected both uploads to be released, got %d", released.Load())
+	}
+}
+
+func TestAnalysisJobsUseCase_InvalidInput(t *testing.T) {
+	jobs := newJobsUseCase(t, &mock.MockPDFAnalyzer{}, 1, 10)
+
+	_, err := jobs.Submit(context.Background(), dto.SubmitJobInputDTO{})
+	if !errors.Is(err, dto.ErrInvalidPath) {
+		t.Fatalf("expected ErrInvalidPath, got %v", err)
+	}
+}
+
+func TestAnalysisJobsUseCase_CancelRunningAndQueued(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello", WordCount: 1},
+		Wait:   make(chan struct{}),
+	}
+	jobs := newJobsUseCase(t, mockPort, 1, 10)
+
+	var released atomic.Int32
+	running, _ := jobs.Submit(context.Background(), submitInput(&released))
+	queued, _ := jobs.Submit(context.Background(), submitInput(&released))
+
+	waitForStatus(t, jobs, running.ID, dto.JobRunning)
+
+	if _, err := jobs.Cancel(context.Background(), queued.ID); err != nil {
+		t.Fatalf("unexpected error cancelling queued job: %v", err)
+	}
+	if _, err := jobs.Cancel(context.Background(), running.ID); err != nil {
+		t.Fatalf("unexpected error cancelling running job: %v", err)
+	}
+
+	close(mockPort.Wait)
+
+	if err := jobs.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	for _, id := range []string{running.ID, queued.ID} {
+		job, _ := jobs.Get(context.Background(), id)
+		if job.Status != dto.JobCancelled {
+			t.Errorf("expected job %s to be cancelled, got %s", id, job.Status)
+		}
+		if job.Output != nil {
+			t.Errorf("expected no output for cancelled job %s", id)
+		}
+	}
+	if released.Load() != 2 {
+		t.Errorf("expected Release to be called for both jobs, got %d", released.Load())
+	}
+
+	if _, err := jobs.Cancel(context.Background(), running.ID); !errors.Is(err, dto.ErrJobFinished) {
+		t.Errorf("expected ErrJobFinished, got %v", err)
+	}
+}
+
+func TestAnalysisJobsUseCase_QueueFull(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello", WordCount: 1},
+		Wait:   make(chan struct{}),
+	}
+	jobs := newJobsUseCase(t, mockPort, 1, 1)
+	defer close(mockPort.Wait)
+
+	var released atomic.Int32
+	first, _ := jobs.Submit(context.Background(), submitInput(&released))
+	waitForStatus(t, jobs, first.ID, dto.JobRunning)
+
+	if _, err := jobs.Submit(context.Background(), submitInput(&released)); err != nil {
+		t.Fatalf("expected second job to be queued, got %v", err)
+	}
+	if _, err := jobs.Submit(context.Background(), submitInput(&released)); !errors.Is(err, dto.ErrJobQueueFull) {
+		t.Fatalf("expected ErrJobQueueFull, got %v", err)
+	}
+}
+
+func TestAnalysisJobsUseCase_NotFound(t *testing.T) {
+	jobs := newJobsUseCase(t, &mock.MockPDFAnalyzer{}, 1, 1)
+
+	if _, err := jobs.Get(context.Background(), "missing"); !errors.Is(err, dto.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound from Get, got %v", err)
+	}
+	if _, err := jobs.Cancel(context.Background(), "missing"); !errors.Is(err, dto.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound from Cancel, got %v", err)
+	}
+}
+
+func TestAnalysisJobsUseCase_Timeout(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{Wait: make(chan struct{})}
+	defer close(mockPort.Wait)
+
+	jobs := newJobsUseCase(t, mockPort, 1, 1)
+
+	var released atomic.Int32
+	input := submitInput(&released)
+	input.Timeout = 10 * time.Millisecond
+
+	job, err := jobs.Submit(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := waitForStatus(t, jobs, job.ID, dto.JobFailed)
+	if !errors.Is(done.Err, domain.ErrAnalysisTimeout) {
+		t.Errorf("expected ErrAnalysisTimeout, got %v", done.Err)
+	}
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// AnalyzeBatchUseCase runs AnalyzePDFUseCase on several files at
+// once. Items of every batch share a single pool of slots, so the
+// number of concurrent analyses is bounded across requests. A failed
+// item never fails the batch: its error is reported next to the
+// other results.
+type AnalyzeBatchUseCase struct {
+	analyze *AnalyzePDFUseCase
+	slots   chan struct{}
+}
+
+// NewAnalyzeBatchUseCase allows at most concurrency analyses to run
+// at the same time.
+func NewAnalyzeBatchUseCase(analyze *AnalyzePDFUseCase, concurrency int) *AnalyzeBatchUseCase {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &AnalyzeBatchUseCase{
+		analyze: analyze,
+		slots:   make(chan struct{}, concurrency),
+	}
+}
+
+// Execute analyzes every item and returns their results in input
+// order. It only returns an error for an invalid batch.
+func (uc *AnalyzeBatchUseCase) Execute(ctx context.Context, input dto.AnalyzeBatchInputDTO) (dto.AnalyzeBatchOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return dto.AnalyzeBatchOutputDTO{}, err
+	}
+
+	results := make([]dto.BatchItemResultDTO, len(input.Items))
+
+	var wg sync.WaitGroup
+	for i, item := range input.Items {
+		results[i].Name = item.Name
+		if item.Err != nil {
+			results[i].Err = item.Err
+			continue
+		}
+
+		wg.Add(1)
+		go func(res *dto.BatchItemResultDTO, item dto.BatchItemDTO) {
+			defer wg.Done()
+			output, err := uc.run(ctx, item.Analyze, input.Timeout)
+			if err != nil {
+				res.Err = err
+				return
+			}
+			res.Output = &output
+		}(&results[i], item)
+	}
+	wg.Wait()
+
+	return dto.AnalyzeBatchOutputDTO{Items: results}, nil
+}
+
+// run waits for a free slot and analyzes a single item under the
+// per-item timeout.
+func (uc *AnalyzeBatchUseCase) run(ctx context.Context, in dto.AnalyzePDFInputDTO, timeout time.Duration) (dto.AnalyzePDFOutputDTO, error) {
+	select {
+	case uc.slots <- struct{}{}:
+		defer func() { <-uc.slots }()
+	case <-ctx.Done():
+		return dto.AnalyzePDFOutputDTO{}, contextError(ctx.Err())
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return uc.analyze.Execute(ctx, in)
+}
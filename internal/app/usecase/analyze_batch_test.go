@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func TestAnalyzeBatchUseCase_PartialFailure(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello world", WordCount: 2},
+	}
+	uc := NewAnalyzeBatchUseCase(NewAnalyzePDFUseCase(mockPort), 2)
+
+	out, err := uc.Execute(context.Background(), dto.AnalyzeBatchInputDTO{
+		Items: []dto.BatchItemDTO{
+			{Name: "a.pdf", Analyze: dto.AnalyzePDFInputDTO{FilePath: "/tmp/a.pdf"}},
+			{Name: "b.txt", Err: dto.ErrUnsupportedMediaType},
+			{Name: "c.pdf", Analyze: dto.AnalyzePDFInputDTO{FilePath: ""}},
+			{Name: "d.pdf", Analyze: dto.AnalyzePDFInputDTO{FilePath: "/tmp/d.pdf"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out.Items) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(out.Items))
+	}
+	for i, name := range []string{"a.pdf", "b.txt", "c.pdf", "d.pdf"} {
+		if out.Items[i].Name != name {
+			t.Errorf("item %d: expected %s, got %s", i, name, out.Items[i].Name)
+		}
+	}
+	if out.Items[0].Output == nil || out.Items[0].Output.WordCount != 2 {
+		t.Errorf("expected first item to succeed, got %+v", out.Items[0])
+	}
+	if !errors.Is(out.Items[1].Err, dto.ErrUnsupportedMediaType) {
+		t.Errorf("expected staging error to be kept, got %v", out.Items[1].Err)
+	}
+	if !errors.Is(out.Items[2].Err, dto.ErrInvalidPath) {
+		t.Errorf("expected ErrInvalidPath, got %v", out.Items[2].Err)
+	}
+	if got := out.Succeeded(); got != 2 {
+		t.Errorf("expected 2 successes, got %d", got)
+	}
+}
+
+func TestAnalyzeBatchUseCase_EmptyBatch(t *testing.T) {
+	uc := NewAnalyzeBatchUseCase(NewAnalyzePDFUseCase(&mock.MockPDFAnalyzer{}), 1)
+
+	_, err := uc.Execute(context.Background(), dto.AnalyzeBatchInputDTO{})
+	if !errors.Is(err, dto.ErrEmptyBatch) {
+		t.Fatalf("expected ErrEmptyBatch, got %v", err)
+	}
+}
+
+func TestAnalyzeBatchUseCase_PerItemTimeout(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{Wait: make(chan struct{})}
+	uc := NewAnalyzeBatchUseCase(NewAnalyzePDFUseCase(mockPort), 1)
+
+	out, err := uc.Execute(context.Background(), dto.AnalyzeBatchInputDTO{
+		Items: []dto.BatchItemDTO{
+			{Name: "a.pdf", Analyze: dto.AnalyzePDFInputDTO{FilePath: "/tmp/a.pdf"}},
+			{Name: "b.pdf", Analyze: dto.AnalyzePDFInputDTO{FilePath: "/tmp/b.pdf"}},
+		},
+		Timeout: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// With a single slot the second item only starts after the
+	// first timed out, so each gets its own deadline.
+	for _, item := range out.Items {
+		if !errors.Is(item.Err, domain.ErrAnalysisTimeout) {
+			t.Errorf("%s: expected ErrAnalysisTimeout, got %v", item.Name, item.Err)
+		}
+	}
+}
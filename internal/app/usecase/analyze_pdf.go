@@ -2,20 +2,64 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 
 	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+	"github.com/jorgediasdsg/pdf-expert/internal/readability"
 )
 
 type AnalyzePDFUseCase struct {
 	analyzer port.PDFAnalyzerPort
+	ocr      port.OCRPort         // nil when OCR is not available
+	terms    port.TermStatsPort   // nil when keywords are not weighed by IDF
+	pii      port.PIIDetectorPort // nil when personal data is not looked for
 }
 
-func NewAnalyzePDFUseCase(analyzer port.PDFAnalyzerPort) *AnalyzePDFUseCase {
-	return &AnalyzePDFUseCase{analyzer: analyzer}
+// AnalyzePDFOption configures an AnalyzePDFUseCase.
+type AnalyzePDFOption func(*AnalyzePDFUseCase)
+
+// WithOCR recognizes the text of image-only pages with ocr. A nil
+// engine leaves OCR disabled.
+func WithOCR(ocr port.OCRPort) AnalyzePDFOption {
+	return func(uc *AnalyzePDFUseCase) {
+		uc.ocr = ocr
+	}
+}
+
+// WithTermStats records the terms of the documents whose keywords are
+// requested in terms and weighs keywords by their inverse document
+// frequency. A nil store
+// ranks keywords by frequency only.
+func WithTermStats(terms port.TermStatsPort) AnalyzePDFOption {
+	return func(uc *AnalyzePDFUseCase) {
+		uc.terms = terms
+	}
+}
+
+// WithPIIDetector scans the pages, annotations, form fields and
+// metadata for personal data with detector and reports the matches.
+// A nil detector leaves the report out.
+func WithPIIDetector(detector port.PIIDetectorPort) AnalyzePDFOption {
+	return func(uc *AnalyzePDFUseCase) {
+		uc.pii = detector
+	}
+}
+
+func NewAnalyzePDFUseCase(analyzer port.PDFAnalyzerPort, opts ...AnalyzePDFOption) *AnalyzePDFUseCase {
+	uc := &AnalyzePDFUseCase{analyzer: analyzer}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
-// Execute applies validation at the DTO and domain levels.
+// Execute applies validation at the DTO and domain levels. Pages
+// selected by the OCR mode are recognized before the domain
+// validation, so scanned documents pass it once they have text.
 func (uc *AnalyzePDFUseCase) Execute(ctx context.Context, input dto.AnalyzePDFInputDTO) (dto.AnalyzePDFOutputDTO, error) {
 
 	// 1. DTO validation
@@ -24,21 +68,69 @@ func (uc *AnalyzePDFUseCase) Execute(ctx context.Context, input dto.AnalyzePDFIn
 	}
 
 	// 2. Port call → returns domain object
-	domainResult, err := uc.analyzer.AnalyzeFile(input.FilePath)
+	domainResult, err := uc.analyzer.AnalyzeFile(ctx, input.FilePath, port.AnalyzeOptions{Password: input.Password, Layout: string(input.Layout)})
 	if err != nil {
-		return dto.AnalyzePDFOutputDTO{}, err
+		return dto.AnalyzePDFOutputDTO{}, contextError(err)
 	}
 
-	// 3. Domain validation
+	// 3. OCR of image-only pages
+	domainResult, err = uc.recognize(ctx, input, domainResult)
+	if err != nil {
+		return dto.AnalyzePDFOutputDTO{}, contextError(err)
+	}
+
+	// 4. Personal data in the pages, annotations, forms and metadata
+	domainResult, err = uc.detectPII(ctx, domainResult)
+	if err != nil {
+		return dto.AnalyzePDFOutputDTO{}, contextError(err)
+	}
+
+	// 5. Domain validation
 	if err := domainResult.Validate(); err != nil {
 		return dto.AnalyzePDFOutputDTO{}, err
 	}
 
-	// 4. Map domain → DTO
+	// 6. Map domain → DTO
 	out := dto.AnalyzePDFOutputDTO{
-		Content:   domainResult.Content,
-		WordCount: domainResult.WordCount,
+		Content:        domainResult.Content,
+		WordCount:      domainResult.WordCount,
+		TokenCount:     domainResult.TokenCount,
+		SentenceCount:  domainResult.SentenceCount,
+		ParagraphCount: domainResult.ParagraphCount,
+		NeedsOCR:       domainResult.NeedsOCR(),
+		TextCoverage:   math.Round(domainResult.TextCoverage()*1000) / 1000,
+		Pages:          toPageDTOs(domainResult.Pages),
+		Metadata:       toMetadataDTO(domainResult.Metadata),
+		Encryption:     toEncryptionDTO(domainResult.Encryption),
+		Outline:        toOutlineDTO(domainResult.Outline),
+		Annotations:    toAnnotationDTOs(domainResult.Annotations),
+		Images:         toImageDTOs(domainResult.Images),
+		Fonts:          toFontDTOs(domainResult.Fonts),
+		Language:       toLanguageDTO(domainResult.Language),
+		Cached:         domainResult.FromCache,
 	}
 
+	if uc.pii != nil {
+		out.PII = toPIIReportDTO(domainResult.PII)
+	}
+
+	// 7. Text analyses of the content
+	out.Readability = toReadabilityDTO(readability.Analyze(domainResult.Content, domainResult.Language.Code))
+	out.Keywords = uc.keywords(ctx, input, domainResult.Content, domainResult.Language.Code)
+
 	return out, nil
 }
+
+// contextError translates context errors returned by the port into
+// domain errors, so callers can tell a timeout from a cancellation
+// without knowing about contexts.
+func contextError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %v", domain.ErrAnalysisTimeout, err)
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("%w: %v", domain.ErrAnalysisCancelled, err)
+	default:
+		return err
+	}
+}
@@ -3,8 +3,11 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/ocr"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
 	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
 	"github.com/jorgediasdsg/pdf-expert/internal/domain"
@@ -47,6 +50,11 @@ func TestAnalyzePDFUseCase_InvalidDTO(t *testing.T) {
 	if err == nil {
 		t.Fatalf("expected validation error, got nil")
 	}
+
+	_, err = uc.Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf", Layout: "columns"})
+	if !errors.Is(err, dto.ErrInvalidLayout) {
+		t.Fatalf("expected ErrInvalidLayout, got %v", err)
+	}
 }
 
 func TestAnalyzePDFUseCase_PortError(t *testing.T) {
@@ -84,3 +92,362 @@ func TestAnalyzePDFUseCase_DomainError(t *testing.T) {
 		t.Fatalf("expected domain validation error, got nil")
 	}
 }
+
+func TestAnalyzePDFUseCase_Pages(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello world",
+			WordCount: 2,
+			Pages: []domain.PageAnalysis{
+				{Number: 1, Text: "hello world", WordCount: 2, CharCount: 10, Width: 612, Height: 792},
+				{Number: 2, Width: 612, Height: 792, Rotation: 90},
+			},
+		},
+	}
+
+	uc := NewAnalyzePDFUseCase(mockPort)
+
+	output, err := uc.Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(output.Pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(output.Pages))
+	}
+	if output.Pages[0].Blank || !output.Pages[1].Blank {
+		t.Errorf("expected only the second page to be blank, got %+v", output.Pages)
+	}
+	if output.Pages[1].Rotation != 90 {
+		t.Errorf("expected rotation 90, got %d", output.Pages[1].Rotation)
+	}
+}
+
+func TestAnalyzePDFUseCase_InvalidPageNumbering(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages:     []domain.PageAnalysis{{Number: 2}},
+		},
+	}
+
+	uc := NewAnalyzePDFUseCase(mockPort)
+
+	_, err := uc.Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrInvalidPage) {
+		t.Fatalf("expected ErrInvalidPage, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_Outline(t *testing.T) {
+	outline := domain.Outline{Source: domain.OutlineBookmarks, Items: []domain.OutlineItem{
+		{Title: "Intro", Level: 1, Page: 1, Children: []domain.OutlineItem{{Title: "Scope", Level: 2}}},
+	}}
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages:     []domain.PageAnalysis{{Number: 1, WordCount: 1}},
+			Outline:   outline,
+		},
+	}
+
+	out, err := NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Outline.Source != "bookmarks" || len(out.Outline.Items) != 1 || out.Outline.Items[0].Children[0].Title != "Scope" {
+		t.Errorf("unexpected outline: %+v", out.Outline)
+	}
+
+	// Destinations beyond the last page violate the invariants.
+	outline.Items[0].Page = 2
+	mockPort.Result.Outline = outline
+	_, err = NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrInvalidOutline) {
+		t.Fatalf("expected ErrInvalidOutline, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_Annotations(t *testing.T) {
+	annotations := []domain.Annotation{
+		{Page: 1, Type: domain.AnnotLink, URI: "https://example.com"},
+		{Page: 1, Type: domain.AnnotNote, Contents: "Check", Author: "Jane", Modified: time.Date(2023, 4, 16, 9, 0, 0, 0, time.UTC)},
+	}
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:     "hello",
+			WordCount:   1,
+			Pages:       []domain.PageAnalysis{{Number: 1, WordCount: 1}},
+			Annotations: annotations,
+		},
+	}
+
+	out, err := NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Annotations) != 2 || out.Annotations[0].URI != "https://example.com" {
+		t.Fatalf("unexpected annotations: %+v", out.Annotations)
+	}
+	if out.Annotations[1].Modified != "2023-04-16T09:00:00Z" || out.Annotations[1].Author != "Jane" {
+		t.Errorf("unexpected note: %+v", out.Annotations[1])
+	}
+
+	// Links to pages beyond the last one violate the invariants.
+	annotations[0].DestinationPage = 2
+	_, err = NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrInvalidAnnotation) {
+		t.Fatalf("expected ErrInvalidAnnotation, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_Fonts(t *testing.T) {
+	fonts := []domain.Font{
+		{Name: "Helvetica", Subtype: domain.FontType1, Encoding: "WinAnsiEncoding", Pages: []int{1, 2}},
+		{Name: "NotoSansCJK", Subtype: domain.FontType0, Embedded: true, Encoding: "Identity-H", Pages: []int{2}},
+	}
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages:     []domain.PageAnalysis{{Number: 1}, {Number: 2}},
+			Fonts:     fonts,
+		},
+	}
+
+	out, err := NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Fonts) != 2 || out.Fonts[0].Embedded || !out.Fonts[0].TextExtractable {
+		t.Fatalf("unexpected fonts: %+v", out.Fonts)
+	}
+	// Identity-encoded CID fonts without a ToUnicode map yield glyph IDs.
+	if out.Fonts[1].TextExtractable {
+		t.Errorf("expected %s not to be extractable", out.Fonts[1].Name)
+	}
+
+	fonts[1].Pages = []int{3}
+	_, err = NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrInvalidFont) {
+		t.Fatalf("expected ErrInvalidFont, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_ScannedPages(t *testing.T) {
+	letter := func(n, chars int, coverage float64) domain.PageAnalysis {
+		return domain.PageAnalysis{Number: n, CharCount: chars, Width: 612, Height: 792, ImageCoverage: coverage}
+	}
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello",
+			WordCount: 1,
+			Pages: []domain.PageAnalysis{
+				letter(1, 2500, 0.1),
+				letter(2, 3, 1),    // scan with a page number
+				letter(3, 1800, 1), // scan with a text layer
+				letter(4, 0, 0),
+			},
+		},
+	}
+
+	out, err := NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var kinds []string
+	for _, p := range out.Pages {
+		kinds = append(kinds, p.Kind)
+	}
+	if strings.Join(kinds, ",") != "text,image,mixed,blank" {
+		t.Errorf("unexpected page kinds %v", kinds)
+	}
+	if !out.NeedsOCR || !out.Pages[1].NeedsOCR || out.Pages[2].NeedsOCR {
+		t.Errorf("expected only page 2 to need OCR: %+v", out.Pages)
+	}
+	if out.TextCoverage != 0.667 {
+		t.Errorf("expected text coverage 0.667, got %v", out.TextCoverage)
+	}
+
+	// Without any text, a scan is reported as such.
+	mockPort.Result.Content = ""
+	mockPort.Result.Pages = []domain.PageAnalysis{letter(1, 0, 1)}
+	_, err = NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrScannedDocument) {
+		t.Fatalf("expected ErrScannedDocument, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_OCR(t *testing.T) {
+	newPort := func() *mock.MockPDFAnalyzer {
+		return &mock.MockPDFAnalyzer{
+			Result: domain.AnalysisResult{
+				Pages: []domain.PageAnalysis{{Number: 1, Width: 612, Height: 792, ImageCoverage: 1}},
+				Images: []domain.Image{
+					{Page: 1, Name: "Logo", Width: 50, Height: 50},
+					{Page: 1, Name: "Scan", Width: 2550, Height: 3300},
+				},
+			},
+			Images: []domain.ImageData{
+				{ContentType: "image/png", Data: []byte("logo")},
+				{ContentType: "image/jpeg", Data: []byte("scan")},
+			},
+		}
+	}
+	engine := ocr.Fake{Text: "Scanned contract.\n", Confidence: 0.91234}
+	input := dto.AnalyzePDFInputDTO{FilePath: "/tmp/scan.pdf"}
+
+	out, err := NewAnalyzePDFUseCase(newPort(), WithOCR(engine)).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page := out.Pages[0]
+	if !page.OCR || page.OCRConfidence != 0.912 || page.NeedsOCR || page.Text != engine.Text {
+		t.Errorf("unexpected page: %+v", page)
+	}
+	if out.WordCount != 2 || out.NeedsOCR {
+		t.Errorf("expected the recognized text to be counted: %+v", out)
+	}
+
+	// The largest image is recognized, not the logo.
+	out, err = NewAnalyzePDFUseCase(newPort(), WithOCR(ocr.Fake{})).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.Pages[0].Text, "image/jpeg") {
+		t.Errorf("expected the scan to be recognized, got %q", out.Pages[0].Text)
+	}
+
+	t.Run("off", func(t *testing.T) {
+		input := input
+		input.OCR = dto.OCROff
+		_, err := NewAnalyzePDFUseCase(newPort(), WithOCR(engine)).Execute(context.Background(), input)
+		if !errors.Is(err, domain.ErrScannedDocument) {
+			t.Fatalf("expected ErrScannedDocument, got %v", err)
+		}
+	})
+
+	t.Run("force without engine", func(t *testing.T) {
+		input := input
+		input.OCR = dto.OCRForce
+		_, err := NewAnalyzePDFUseCase(newPort()).Execute(context.Background(), input)
+		if !errors.Is(err, domain.ErrOCRUnavailable) {
+			t.Fatalf("expected ErrOCRUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("engine failure", func(t *testing.T) {
+		_, err := NewAnalyzePDFUseCase(newPort(), WithOCR(ocr.Fake{Err: errors.New("boom")})).Execute(context.Background(), input)
+		if !errors.Is(err, domain.ErrScannedDocument) {
+			t.Fatalf("expected ErrScannedDocument, got %v", err)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		input := input
+		input.OCR = "always"
+		_, err := NewAnalyzePDFUseCase(newPort(), WithOCR(engine)).Execute(context.Background(), input)
+		if !errors.Is(err, dto.ErrInvalidOCRMode) {
+			t.Fatalf("expected ErrInvalidOCRMode, got %v", err)
+		}
+	})
+}
+
+func TestAnalyzePDFUseCase_Metadata(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "hello world",
+			WordCount: 2,
+			Metadata: domain.Metadata{
+				Title:        "Contract",
+				CreationDate: time.Date(2023, 4, 15, 10, 30, 0, 0, time.FixedZone("", 2*3600)),
+				PDFVersion:   "1.7",
+				PageCount:    1,
+			},
+		},
+	}
+
+	uc := NewAnalyzePDFUseCase(mockPort)
+
+	output, err := uc.Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Metadata.Title != "Contract" {
+		t.Errorf("expected title Contract, got %q", output.Metadata.Title)
+	}
+	if output.Metadata.CreationDate != "2023-04-15T10:30:00+02:00" {
+		t.Errorf("expected RFC 3339 creation date, got %q", output.Metadata.CreationDate)
+	}
+	if output.Metadata.ModificationDate != "" {
+		t.Errorf("expected empty modification date, got %q", output.Metadata.ModificationDate)
+	}
+}
+
+func TestAnalyzePDFUseCase_Timeout(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{Wait: make(chan struct{})}
+	defer close(mockPort.Wait)
+
+	uc := NewAnalyzePDFUseCase(mockPort)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := uc.Execute(ctx, dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrAnalysisTimeout) {
+		t.Fatalf("expected ErrAnalysisTimeout, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_Cancelled(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{Wait: make(chan struct{})}
+	defer close(mockPort.Wait)
+
+	uc := NewAnalyzePDFUseCase(mockPort)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := uc.Execute(ctx, dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrAnalysisCancelled) {
+		t.Fatalf("expected ErrAnalysisCancelled, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_Language(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{Content: "hello", WordCount: 1, Language: domain.Language{Code: "english", Confidence: 1}},
+	}
+	_, err := NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if !errors.Is(err, domain.ErrInvalidLanguage) {
+		t.Errorf("expected ErrInvalidLanguage, got %v", err)
+	}
+}
+
+func TestAnalyzePDFUseCase_Readability(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "The cat sat on the mat. The dog ran.",
+			WordCount: 9,
+			Language:  domain.Language{Code: "en", Confidence: 0.9},
+		},
+	}
+
+	out, err := NewAnalyzePDFUseCase(mockPort).Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := out.Readability
+	if r.Language != "en" || r.SentenceCount != 2 || r.AverageSentenceLength != 4.5 || r.SyllablesPerWord != 1 {
+		t.Errorf("unexpected readability: %+v", r)
+	}
+	if r.Scores["flesch_reading_ease"] != 117.67 || r.Scores["flesch_kincaid_grade"] != -2.03 || r.Scores["gunning_fog"] != 1.8 {
+		t.Errorf("scores = %v", r.Scores)
+	}
+	if r.ReadingTime != 2*time.Second || r.SpeakingTime != 4*time.Second {
+		t.Errorf("reading %v, speaking %v", r.ReadingTime, r.SpeakingTime)
+	}
+}
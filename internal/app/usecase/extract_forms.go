@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+)
+
+// ExtractFormsUseCase lists the interactive form fields of a PDF.
+type ExtractFormsUseCase struct {
+	analyzer port.PDFAnalyzerPort
+}
+
+func NewExtractFormsUseCase(analyzer port.PDFAnalyzerPort) *ExtractFormsUseCase {
+	return &ExtractFormsUseCase{analyzer: analyzer}
+}
+
+// Execute validates the input, extracts the fields and checks them
+// against the domain invariants.
+func (uc *ExtractFormsUseCase) Execute(ctx context.Context, input dto.ExtractFormsInputDTO) (dto.ExtractFormsOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return dto.ExtractFormsOutputDTO{}, err
+	}
+
+	fields, err := uc.analyzer.ExtractForms(ctx, input.FilePath, port.AnalyzeOptions{Password: input.Password})
+	if err != nil {
+		return dto.ExtractFormsOutputDTO{}, contextError(err)
+	}
+
+	for _, f := range fields {
+		if err := f.Validate(); err != nil {
+			return dto.ExtractFormsOutputDTO{}, err
+		}
+	}
+
+	return dto.ExtractFormsOutputDTO{Fields: toFormFieldDTOs(fields)}, nil
+}
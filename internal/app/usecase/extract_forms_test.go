@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func TestExtractFormsUseCase_Success(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Forms: []domain.FormField{
+			{Name: "applicant.name", Type: domain.FieldText, Value: "Jane", Required: true, Page: 1, Rect: domain.Rect{X1: 10, Y1: 10, X2: 100, Y2: 30}},
+			{Name: "plan", Type: domain.FieldRadio, Value: "Gold", Options: []domain.FormOption{{Value: "Gold", Label: "Gold"}}},
+		},
+	}
+
+	out, err := NewExtractFormsUseCase(mockPort).Execute(context.Background(), dto.ExtractFormsInputDTO{FilePath: "/tmp/form.pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(out.Fields))
+	}
+	if f := out.Fields[0]; f.Name != "applicant.name" || !f.Required || f.Rect.X2 != 100 {
+		t.Errorf("unexpected first field: %+v", f)
+	}
+	if f := out.Fields[1]; len(f.Options) != 1 || f.Options[0].Value != "Gold" {
+		t.Errorf("unexpected options: %+v", f.Options)
+	}
+}
+
+func TestExtractFormsUseCase_InvalidInput(t *testing.T) {
+	_, err := NewExtractFormsUseCase(&mock.MockPDFAnalyzer{}).Execute(context.Background(), dto.ExtractFormsInputDTO{})
+	if !errors.Is(err, dto.ErrInvalidPath) {
+		t.Fatalf("expected ErrInvalidPath, got %v", err)
+	}
+}
+
+func TestExtractFormsUseCase_InvalidField(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Forms: []domain.FormField{{Name: "x", Page: -1}},
+	}
+
+	_, err := NewExtractFormsUseCase(mockPort).Execute(context.Background(), dto.ExtractFormsInputDTO{FilePath: "/tmp/form.pdf"})
+	if !errors.Is(err, domain.ErrInvalidFormField) {
+		t.Fatalf("expected ErrInvalidFormField, got %v", err)
+	}
+}
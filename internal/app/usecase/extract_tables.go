@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+)
+
+// ExtractTablesUseCase finds the tables of a PDF.
+type ExtractTablesUseCase struct {
+	analyzer port.PDFAnalyzerPort
+}
+
+func NewExtractTablesUseCase(analyzer port.PDFAnalyzerPort) *ExtractTablesUseCase {
+	return &ExtractTablesUseCase{analyzer: analyzer}
+}
+
+// Execute validates the input, extracts the tables of the selected
+// pages and checks them against the domain invariants.
+func (uc *ExtractTablesUseCase) Execute(ctx context.Context, input dto.ExtractTablesInputDTO) (dto.ExtractTablesOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return dto.ExtractTablesOutputDTO{}, err
+	}
+
+	opts := port.AnalyzeOptions{Password: input.Password, Pages: toPageRanges(input.Pages)}
+	tables, err := uc.analyzer.ExtractTables(ctx, input.FilePath, opts)
+	if err != nil {
+		return dto.ExtractTablesOutputDTO{}, contextError(err)
+	}
+
+	for _, t := range tables {
+		if err := t.Validate(); err != nil {
+			return dto.ExtractTablesOutputDTO{}, err
+		}
+	}
+
+	return dto.ExtractTablesOutputDTO{Tables: toTableDTOs(tables)}, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func TestExtractTablesUseCase_Success(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Tables: []domain.Table{
+			{Page: 1, Method: domain.TableRuled, Rows: 2, Columns: 2, Cells: []domain.TableCell{
+				{Row: 0, Column: 0, RowSpan: 1, ColumnSpan: 2, Text: "Totals"},
+				{Row: 1, Column: 0, RowSpan: 1, ColumnSpan: 1, Text: "3"},
+				{Row: 1, Column: 1, RowSpan: 1, ColumnSpan: 1, Text: "4"},
+			}},
+			{Page: 3, Method: domain.TableAligned, Rows: 1, Columns: 1, Cells: []domain.TableCell{
+				{RowSpan: 1, ColumnSpan: 1, Text: "x"},
+			}},
+		},
+	}
+
+	input := dto.ExtractTablesInputDTO{FilePath: "/tmp/report.pdf", Pages: []dto.PageRangeDTO{{First: 1, Last: 2}}}
+	out, err := NewExtractTablesUseCase(mockPort).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Tables) != 1 {
+		t.Fatalf("expected the table of page 1 only, got %+v", out.Tables)
+	}
+	grid := out.Tables[0].Grid()
+	if len(grid) != 2 || grid[0][0] != "Totals" || grid[0][1] != "" || grid[1][1] != "4" {
+		t.Errorf("unexpected grid %q", grid)
+	}
+}
+
+func TestExtractTablesUseCase_InvalidInput(t *testing.T) {
+	uc := NewExtractTablesUseCase(&mock.MockPDFAnalyzer{})
+
+	if _, err := uc.Execute(context.Background(), dto.ExtractTablesInputDTO{}); !errors.Is(err, dto.ErrInvalidPath) {
+		t.Errorf("expected ErrInvalidPath, got %v", err)
+	}
+	input := dto.ExtractTablesInputDTO{FilePath: "/tmp/report.pdf", Pages: []dto.PageRangeDTO{{First: 4, Last: 2}}}
+	if _, err := uc.Execute(context.Background(), input); !errors.Is(err, dto.ErrInvalidPageRange) {
+		t.Errorf("expected ErrInvalidPageRange, got %v", err)
+	}
+}
+
+func TestExtractTablesUseCase_InvalidTable(t *testing.T) {
+	mockPort := &mock.MockPDFAnalyzer{
+		Tables: []domain.Table{{Page: 1, Rows: 1, Columns: 1, Cells: []domain.TableCell{{Row: 1, RowSpan: 1, ColumnSpan: 1}}}},
+	}
+
+	_, err := NewExtractTablesUseCase(mockPort).Execute(context.Background(), dto.ExtractTablesInputDTO{FilePath: "/tmp/report.pdf"})
+	if !errors.Is(err, domain.ErrInvalidTable) {
+		t.Fatalf("expected ErrInvalidTable, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/keywords"
+)
+
+// keywords returns the keywords and keyphrases of content when the
+// input asks for them, and records its terms in the term statistics
+// store, when there is one. Analyses without keywords cost nothing and
+// are not recorded: the frequencies cover the documents whose keywords
+// were requested. The stopwords are those of language, or of the
+// language guessed from them when it has no list. The store is best
+// effort: when it fails, the keywords are ranked by frequency only.
+func (uc *AnalyzePDFUseCase) keywords(ctx context.Context, input dto.AnalyzePDFInputDTO, content, language string) *dto.KeywordsDTO {
+	if !input.Wants(dto.AnalysisKeywords) {
+		return nil
+	}
+
+	doc := keywords.Parse(content, language)
+	var idf keywords.IDF
+	docs := 0
+	if uc.terms != nil {
+		terms := doc.Terms()
+		sum := sha256.Sum256([]byte(content))
+		if err := uc.terms.AddDocument(ctx, hex.EncodeToString(sum[:]), terms); err == nil {
+			if n, df, err := uc.terms.Frequencies(ctx, terms); err == nil {
+				docs = n
+				idf = func(term string) float64 { return keywords.SmoothIDF(n, df[term]) }
+			}
+		}
+	}
+
+	return &dto.KeywordsDTO{
+		Language:   doc.Language,
+		Documents:  docs,
+		Keywords:   toKeywordDTOs(doc.Keywords(input.KeywordsTop, idf)),
+		Keyphrases: toKeywordDTOs(doc.Keyphrases(input.KeywordsTop, idf)),
+	}
+}
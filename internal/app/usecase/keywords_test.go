@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/termstats"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+func TestAnalyzePDFUseCase_Keywords(t *testing.T) {
+	analyze := func(uc *AnalyzePDFUseCase, content string, input dto.AnalyzePDFInputDTO) dto.AnalyzePDFOutputDTO {
+		t.Helper()
+		uc.analyzer = &mock.MockPDFAnalyzer{Result: domain.AnalysisResult{Content: content, WordCount: 1}}
+		input.FilePath = "/tmp/test.pdf"
+		out, err := uc.Execute(context.Background(), input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return out
+	}
+	wants := dto.AnalyzePDFInputDTO{Analyses: []dto.Analysis{dto.AnalysisKeywords}, KeywordsTop: 2}
+
+	// Without a store, keywords are ranked by frequency.
+	out := analyze(NewAnalyzePDFUseCase(nil), "invoice total invoice tax", wants)
+	if out.Keywords == nil || out.Keywords.Documents != 0 || len(out.Keywords.Keywords) != 2 || out.Keywords.Keywords[0].Text != "invoice" {
+		t.Fatalf("keywords = %+v", out.Keywords)
+	}
+	if out.Keywords.Language != "en" {
+		t.Errorf("language = %q, want en", out.Keywords.Language)
+	}
+
+	// With a store, the analyses with keywords are recorded, and
+	// terms found in every document lose their rank. Analyses without
+	// keywords are not recorded.
+	terms := termstats.NewMemoryTermStats(0, 0)
+	uc := NewAnalyzePDFUseCase(nil, WithTermStats(terms))
+	if out := analyze(uc, "invoice total", dto.AnalyzePDFInputDTO{}); out.Keywords != nil {
+		t.Errorf("keywords must be nil when not requested, got %+v", out.Keywords)
+	}
+	if n, _, _ := terms.Frequencies(context.Background(), nil); n != 0 {
+		t.Errorf("recorded %d documents without keywords, want 0", n)
+	}
+	analyze(uc, "invoice total", wants)
+	analyze(uc, "invoice shipping", wants)
+	out = analyze(uc, "invoice refund", wants)
+	if out.Keywords.Documents != 3 {
+		t.Errorf("documents = %d, want 3", out.Keywords.Documents)
+	}
+	if top := out.Keywords.Keywords[0]; top.Text != "refund" || top.Score != 1 {
+		t.Errorf("top keyword = %+v, want refund", top)
+	}
+
+	_, err := uc.Execute(context.Background(), dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf", Analyses: []dto.Analysis{"summary"}})
+	if !errors.Is(err, dto.ErrInvalidAnalysis) {
+		t.Errorf("expected ErrInvalidAnalysis, got %v", err)
+	}
+}
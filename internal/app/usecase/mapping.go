@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+	"github.com/jorgediasdsg/pdf-expert/internal/keywords"
+	"github.com/jorgediasdsg/pdf-expert/internal/readability"
+)
+
+// Mapping helpers: domain → DTO.
+
+func toPageDTOs(pages []domain.PageAnalysis) []dto.PageDTO {
+	out := make([]dto.PageDTO, 0, len(pages))
+	for _, p := range pages {
+		out = append(out, dto.PageDTO{
+			Number:    p.Number,
+			Text:      p.Text,
+			WordCount: p.WordCount,
+			CharCount: p.CharCount,
+			Width:     p.Width,
+			Height:    p.Height,
+			Rotation:  p.Rotation,
+			Blank:     p.IsBlank(),
+
+			Kind:          p.Kind(),
+			ImageCoverage: p.ImageCoverage,
+			NeedsOCR:      p.NeedsOCR(),
+			OCR:           p.OCR,
+			OCRConfidence: math.Round(p.OCRConfidence*1000) / 1000,
+			Language:      toLanguageDTO(p.Language),
+		})
+	}
+	return out
+}
+
+func toLanguageDTO(l domain.Language) dto.LanguageDTO {
+	return dto.LanguageDTO{Code: l.Code, Confidence: l.Confidence}
+}
+
+func toMetadataDTO(md domain.Metadata) dto.MetadataDTO {
+	return dto.MetadataDTO{
+		Title:            md.Title,
+		Author:           md.Author,
+		Subject:          md.Subject,
+		Keywords:         md.Keywords,
+		Creator:          md.Creator,
+		Producer:         md.Producer,
+		CreationDate:     formatTime(md.CreationDate),
+		ModificationDate: formatTime(md.ModificationDate),
+		PDFVersion:       md.PDFVersion,
+		PageCount:        md.PageCount,
+		XMP:              md.XMP,
+	}
+}
+
+func toEncryptionDTO(e domain.Encryption) dto.EncryptionDTO {
+	return dto.EncryptionDTO{
+		Encrypted: e.Encrypted,
+		Filter:    e.Filter,
+		Version:   e.Version,
+		Revision:  e.Revision,
+		KeyLength: e.KeyLength,
+		Algorithm: e.Algorithm,
+		Permissions: dto.PermissionsDTO{
+			Print:            e.Permissions.Print,
+			Modify:           e.Permissions.Modify,
+			Copy:             e.Permissions.Copy,
+			Annotate:         e.Permissions.Annotate,
+			FillForms:        e.Permissions.FillForms,
+			ExtractForAccess: e.Permissions.ExtractForAccess,
+			Assemble:         e.Permissions.Assemble,
+			PrintHighQuality: e.Permissions.PrintHighQuality,
+		},
+	}
+}
+
+func toOutlineDTO(o domain.Outline) dto.OutlineDTO {
+	return dto.OutlineDTO{Source: o.Source, Items: toOutlineItemDTOs(o.Items)}
+}
+
+func toOutlineItemDTOs(items []domain.OutlineItem) []dto.OutlineItemDTO {
+	out := make([]dto.OutlineItemDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, dto.OutlineItemDTO{
+			Title:    it.Title,
+			Level:    it.Level,
+			Page:     it.Page,
+			Children: toOutlineItemDTOs(it.Children),
+		})
+	}
+	return out
+}
+
+func toAnnotationDTOs(annotations []domain.Annotation) []dto.AnnotationDTO {
+	out := make([]dto.AnnotationDTO, 0, len(annotations))
+	for _, a := range annotations {
+		out = append(out, dto.AnnotationDTO{
+			Page:            a.Page,
+			Type:            a.Type,
+			Rect:            dto.RectDTO(a.Rect),
+			Contents:        a.Contents,
+			Author:          a.Author,
+			Modified:        formatTime(a.Modified),
+			URI:             a.URI,
+			DestinationPage: a.DestinationPage,
+			MarkedText:      a.MarkedText,
+			Stamp:           a.Stamp,
+		})
+	}
+	return out
+}
+
+func toImageDTOs(images []domain.Image) []dto.ImageDTO {
+	out := make([]dto.ImageDTO, 0, len(images))
+	for _, img := range images {
+		out = append(out, dto.ImageDTO(img))
+	}
+	return out
+}
+
+func toFontDTOs(fonts []domain.Font) []dto.FontDTO {
+	out := make([]dto.FontDTO, 0, len(fonts))
+	for _, f := range fonts {
+		out = append(out, dto.FontDTO{
+			Name:            f.Name,
+			Subtype:         f.Subtype,
+			Embedded:        f.Embedded,
+			Subset:          f.Subset,
+			Encoding:        f.Encoding,
+			ToUnicode:       f.ToUnicode,
+			TextExtractable: f.TextExtractable(),
+			Pages:           f.Pages,
+		})
+	}
+	return out
+}
+
+func toReadabilityDTO(m readability.Metrics) dto.ReadabilityDTO {
+	scores := make(map[string]float64, len(m.Scores))
+	for name, v := range m.Scores {
+		scores[name] = math.Round(v*100) / 100
+	}
+	return dto.ReadabilityDTO{
+		Language:              m.Language,
+		SentenceCount:         m.Sentences,
+		AverageSentenceLength: math.Round(m.AverageSentenceLength*100) / 100,
+		SyllablesPerWord:      math.Round(m.SyllablesPerWord*100) / 100,
+		Scores:                scores,
+		ReadingTime:           m.ReadingTime,
+		SpeakingTime:          m.SpeakingTime,
+	}
+}
+
+func toKeywordDTOs(kws []keywords.Keyword) []dto.KeywordDTO {
+	out := make([]dto.KeywordDTO, 0, len(kws))
+	for _, k := range kws {
+		out = append(out, dto.KeywordDTO{Text: k.Text, Score: k.Score, Count: k.Count})
+	}
+	return out
+}
+
+func toPIIReportDTO(matches []domain.PIIMatch) *dto.PIIReportDTO {
+	report := &dto.PIIReportDTO{
+		Count:     len(matches),
+		ByType:    make(map[string]int),
+		Pages:     []int{},
+		Matches:   make([]dto.PIIMatchDTO, 0, min(len(matches), dto.MaxPIIMatches)),
+		Truncated: len(matches) > dto.MaxPIIMatches,
+	}
+	for i, m := range matches {
+		report.ByType[m.Type]++
+		if m.Page > 0 {
+			report.Pages = append(report.Pages, m.Page)
+		}
+		if i < dto.MaxPIIMatches {
+			report.Matches = append(report.Matches, dto.PIIMatchDTO{
+				Type:    m.Type,
+				Source:  m.Source,
+				Field:   m.Field,
+				Page:    m.Page,
+				Offset:  m.Offset,
+				Length:  m.Length,
+				Preview: m.Preview,
+			})
+		}
+	}
+	slices.Sort(report.Pages)
+	report.Pages = slices.Compact(report.Pages)
+	return report
+}
+
+func toTableDTOs(tables []domain.Table) []dto.TableDTO {
+	out := make([]dto.TableDTO, 0, len(tables))
+	for _, t := range tables {
+		table := dto.TableDTO{
+			Page:    t.Page,
+			Method:  t.Method,
+			Rect:    dto.RectDTO(t.Rect),
+			Rows:    t.Rows,
+			Columns: t.Columns,
+			Cells:   make([]dto.TableCellDTO, 0, len(t.Cells)),
+		}
+		for _, c := range t.Cells {
+			table.Cells = append(table.Cells, dto.TableCellDTO(c))
+		}
+		out = append(out, table)
+	}
+	return out
+}
+
+func toPageRanges(ranges []dto.PageRangeDTO) []domain.PageRange {
+	out := make([]domain.PageRange, 0, len(ranges))
+	for _, r := range ranges {
+		out = append(out, domain.PageRange(r))
+	}
+	return out
+}
+
+func toFormFieldDTOs(fields []domain.FormField) []dto.FormFieldDTO {
+	out := make([]dto.FormFieldDTO, 0, len(fields))
+	for _, f := range fields {
+		field := dto.FormFieldDTO{
+			Name:     f.Name,
+			Type:     f.Type,
+			Value:    f.Value,
+			Values:   f.Values,
+			Required: f.Required,
+			ReadOnly: f.ReadOnly,
+			Page:     f.Page,
+			Rect:     dto.RectDTO(f.Rect),
+		}
+		for _, o := range f.Options {
+			field.Options = append(field.Options, dto.FormOptionDTO(o))
+		}
+		out = append(out, field)
+	}
+	return out
+}
+
+// formatTime renders t as RFC 3339, or "" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// recognize replaces the text of the pages selected by the OCR mode
+// with the text recognized in their largest image, and has the
+// analyzer count the recognized pages and recount the document. Pages
+// whose image cannot be exported or recognized keep their text layer
+// and still report that they need OCR.
+func (uc *AnalyzePDFUseCase) recognize(ctx context.Context, input dto.AnalyzePDFInputDTO, result domain.AnalysisResult) (domain.AnalysisResult, error) {
+	mode := input.OCR
+	if mode == "" {
+		mode = dto.OCRAuto
+	}
+	if mode == dto.OCROff {
+		return result, nil
+	}
+	if uc.ocr == nil {
+		if mode == dto.OCRForce {
+			return result, domain.ErrOCRUnavailable
+		}
+		return result, nil
+	}
+
+	largest := largestImages(result.Images)
+	scans := make(map[int]int)
+	for _, p := range result.Pages {
+		selected := p.NeedsOCR() || mode == dto.OCRForce && p.Kind() == domain.PageMixed
+		if n, ok := largest[p.Number]; selected && ok {
+			scans[p.Number] = n
+		}
+	}
+	if len(scans) == 0 {
+		return result, nil
+	}
+
+	// The scans are exported in one pass over the file. An export
+	// failure leaves every page as it is, like a failed recognition.
+	opts := port.AnalyzeOptions{Password: input.Password}
+	images, err := uc.analyzer.ExtractScans(ctx, input.FilePath, opts, scans)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if err != nil {
+		return result, nil
+	}
+
+	// The pages may be shared with the result cache: copy them before
+	// writing.
+	pages := slices.Clone(result.Pages)
+	texts := make([]string, len(pages))
+	recognized := false
+	for i, p := range pages {
+		texts[i] = p.Text
+		img, ok := images[p.Number]
+		if !ok {
+			continue
+		}
+
+		text, err := uc.ocr.Recognize(ctx, img)
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err != nil {
+			continue
+		}
+
+		stats := uc.analyzer.CountContent([]string{text.Text})
+		p.Text = text.Text
+		p.WordCount = stats.WordCount
+		p.CharCount = stats.CharCount
+		p.Language = stats.Language
+		p.OCR = true
+		p.OCRConfidence = text.Confidence
+		pages[i] = p
+		texts[i] = p.Text
+		recognized = true
+	}
+	if !recognized {
+		return result, nil
+	}
+
+	stats := uc.analyzer.CountContent(texts)
+	result.Pages = pages
+	result.Content = stats.Content
+	result.WordCount = stats.WordCount
+	result.TokenCount = stats.TokenCount
+	result.SentenceCount = stats.SentenceCount
+	result.ParagraphCount = stats.ParagraphCount
+	result.Language = stats.Language
+	return result, nil
+}
+
+// largestImages returns, for each page, the 1-based inventory index
+// of its image with the most pixels: the scan on image-only pages.
+func largestImages(images []domain.Image) map[int]int {
+	largest := make(map[int]int)
+	for i, img := range images {
+		best, ok := largest[img.Page]
+		if !ok || img.Width*img.Height > images[best-1].Width*images[best-1].Height {
+			largest[img.Page] = i + 1
+		}
+	}
+	return largest
+}
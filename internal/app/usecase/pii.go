@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+// detectPII scans the document for personal data with the PII
+// detector, when there is one: the text of every page, after OCR, the
+// contents of the annotations, the values of the form fields and the
+// metadata. Every part comes from the analysis, so results served from
+// the cache are not parsed again. Unlike the term statistics, the scan is not best effort: a
+// document that could not be scanned in full must not be reported
+// free of personal data.
+func (uc *AnalyzePDFUseCase) detectPII(ctx context.Context, result domain.AnalysisResult) (domain.AnalysisResult, error) {
+	if uc.pii == nil {
+		return result, nil
+	}
+	var found []domain.PIIMatch
+	scan := func(text string, at domain.PIIMatch) error {
+		if text == "" {
+			return nil
+		}
+		matches, err := uc.pii.Detect(ctx, text)
+		if err != nil {
+			return err
+		}
+		for _, m := range matches {
+			m.Source, m.Field, m.Page = at.Source, at.Field, at.Page
+			found = append(found, m)
+		}
+		return nil
+	}
+
+	for _, p := range result.Pages {
+		if err := scan(p.Text, domain.PIIMatch{Source: domain.PIISourceText, Page: p.Number}); err != nil {
+			return result, err
+		}
+	}
+	for _, a := range result.Annotations {
+		if err := scan(a.Contents, domain.PIIMatch{Source: domain.PIISourceAnnotation, Page: a.Page}); err != nil {
+			return result, err
+		}
+	}
+	for _, f := range result.FormFields {
+		for _, value := range fieldValues(f) {
+			if err := scan(value, domain.PIIMatch{Source: domain.PIISourceFormField, Field: f.Name, Page: f.Page}); err != nil {
+				return result, err
+			}
+		}
+	}
+	for _, entry := range metadataEntries(result.Metadata) {
+		if err := scan(entry[1], domain.PIIMatch{Source: domain.PIISourceMetadata, Field: entry[0]}); err != nil {
+			return result, err
+		}
+	}
+	result.PII = found
+	return result, nil
+}
+
+// fieldValues returns the distinct values of f: the value of a
+// multi-select choice field is also one of its selected options.
+func fieldValues(f domain.FormField) []string {
+	values := []string{f.Value}
+	for _, v := range f.Values {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// metadataEntries returns the free-text entries of md as name and
+// value pairs, named as in the API: the information dictionary first,
+// then the XMP properties by name.
+func metadataEntries(md domain.Metadata) [][2]string {
+	entries := [][2]string{
+		{"title", md.Title},
+		{"author", md.Author},
+		{"subject", md.Subject},
+		{"keywords", md.Keywords},
+	}
+	for _, name := range slices.Sorted(maps.Keys(md.XMP)) {
+		entries = append(entries, [2]string{"xmp." + name, md.XMP[name]})
+	}
+	return entries
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/adapter/pii"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+	"github.com/jorgediasdsg/pdf-expert/internal/app/port/mock"
+	"github.com/jorgediasdsg/pdf-expert/internal/domain"
+)
+
+type failingDetector struct{ err error }
+
+func (d failingDetector) Detect(context.Context, string) ([]domain.PIIMatch, error) {
+	return nil, d.err
+}
+
+func TestAnalyzePDFUseCase_PII(t *testing.T) {
+	port := &mock.MockPDFAnalyzer{
+		Result: domain.AnalysisResult{
+			Content:   "Contact: ana@example.com\nNo personal data.\nCPF 529.982.247-25, ana@example.com\n",
+			WordCount: 9,
+			Pages: []domain.PageAnalysis{
+				{Number: 1, Text: "Contact: ana@example.com\n", Width: 612, Height: 792},
+				{Number: 2, Text: "No personal data.\n", Width: 612, Height: 792},
+				{Number: 3, Text: "CPF 529.982.247-25, ana@example.com\n", Width: 612, Height: 792},
+			},
+		},
+	}
+	input := dto.AnalyzePDFInputDTO{FilePath: "/tmp/test.pdf"}
+
+	out, err := NewAnalyzePDFUseCase(port).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.PII != nil {
+		t.Errorf("PII must be nil without a detector, got %+v", out.PII)
+	}
+
+	uc := NewAnalyzePDFUseCase(port, WithPIIDetector(pii.NewRuleDetector(pii.DefaultRules()...)))
+	out, err = uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report := out.PII
+	if report == nil || report.Count != 3 || report.Truncated {
+		t.Fatalf("PII = %+v", report)
+	}
+	if report.ByType[domain.PIIEmail] != 2 || report.ByType[domain.PIICPF] != 1 {
+		t.Errorf("by type = %v", report.ByType)
+	}
+	if len(report.Pages) != 2 || report.Pages[0] != 1 || report.Pages[1] != 3 {
+		t.Errorf("pages = %v, want [1 3]", report.Pages)
+	}
+	want := dto.PIIMatchDTO{Type: domain.PIICPF, Source: domain.PIISourceText, Page: 3, Offset: 4, Length: 14, Preview: "***.***.***-25"}
+	if got := report.Matches[1]; got != want {
+		t.Errorf("match = %+v, want %+v", got, want)
+	}
+
+	// Annotations, form fields and metadata are scanned after the
+	// pages; the pages of the report stay in order.
+	withExtras := *port
+	withExtras.Result.Annotations = []domain.Annotation{{Page: 2, Type: domain.AnnotNote, Contents: "Ask bob@example.com"}}
+	withExtras.Result.FormFields = []domain.FormField{
+		{Name: "applicant.cpf", Type: domain.FieldText, Value: "529.982.247-25", Page: 1},
+		{Name: "notes", Type: domain.FieldText, Value: "none"},
+		// The value of a multi-select field is also among its
+		// selected options; it is reported once.
+		{Name: "contacts", Type: domain.FieldChoice, Value: "ana@example.com", Values: []string{"ana@example.com", "bob"}, Page: 1},
+	}
+	withExtras.Result.Metadata = domain.Metadata{Author: "ana@example.com", XMP: map[string]string{"dc:rights": "CC BY"}}
+	out, err = NewAnalyzePDFUseCase(&withExtras, WithPIIDetector(pii.NewRuleDetector(pii.DefaultRules()...))).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(out.PII.Pages) != "[1 2 3]" || out.PII.Count != 7 {
+		t.Errorf("PII = %+v", out.PII)
+	}
+	extras := []dto.PIIMatchDTO{
+		{Type: domain.PIIEmail, Source: domain.PIISourceAnnotation, Page: 2, Offset: 4, Length: 15, Preview: "b**@example.com"},
+		{Type: domain.PIICPF, Source: domain.PIISourceFormField, Field: "applicant.cpf", Page: 1, Length: 14, Preview: "***.***.***-25"},
+		{Type: domain.PIIEmail, Source: domain.PIISourceFormField, Field: "contacts", Page: 1, Length: 15, Preview: "a**@example.com"},
+		{Type: domain.PIIEmail, Source: domain.PIISourceMetadata, Field: "author", Length: 15, Preview: "a**@example.com"},
+	}
+	if got := out.PII.Matches[3:]; fmt.Sprint(got) != fmt.Sprint(extras) {
+		t.Errorf("matches = %+v, want %+v", got, extras)
+	}
+
+	// A clean document has an empty report, not a missing one.
+	port.Result.Pages = port.Result.Pages[1:2]
+	port.Result.Pages[0].Number = 1
+	out, err = uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.PII == nil || out.PII.Count != 0 || len(out.PII.Matches) != 0 {
+		t.Errorf("PII = %+v, want an empty report", out.PII)
+	}
+
+	// The analysis fails rather than report a partial scan.
+	uc = NewAnalyzePDFUseCase(port, WithPIIDetector(failingDetector{err: context.DeadlineExceeded}))
+	if _, err := uc.Execute(context.Background(), input); !errors.Is(err, domain.ErrAnalysisTimeout) {
+		t.Errorf("expected ErrAnalysisTimeout, got %v", err)
+	}
+}
@@ -1,13 +1,55 @@
 package config
 
 import (
+	"context"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
 	Env        string
 	HTTPPort   string
 	TempFolder string
+
+	// Upload and parsing limits (0 disables a limit)
+	MaxUploadBytes int64
+	MaxPages       int
+	MaxStreamBytes int64
+
+	// Maximum duration of a single analysis (0 disables the timeout)
+	AnalysisTimeout time.Duration
+
+	// Asynchronous analysis jobs
+	JobWorkers   int
+	JobQueueSize int
+	JobRetention time.Duration
+
+	// Batch analysis: files per request, total upload and decompressed
+	// ZIP size, and analyses running at once across all batches
+	BatchMaxFiles    int
+	BatchMaxBytes    int64
+	BatchConcurrency int
+
+	// Result cache
+	CacheBackend    string // "memory", "disk" or "none"
+	CacheMaxEntries int    // memory backend
+	CacheMaxBytes   int64  // disk backend
+	CacheTTL        time.Duration
+
+	// OCR of image-only pages
+	OCREngine     string // "tesseract", "fake" or "none"
+	OCRLanguages  string // tesseract languages, e.g. "eng+por"
+	TesseractPath string
+
+	// Document frequencies of the documents analyzed with keywords,
+	// weighing the keywords of each analysis
+	TermStatsBackend      string // "memory" or "none"
+	TermStatsMaxDocuments int
+	TermStatsMaxTerms     int
+
+	// Personal data detection in the page texts
+	PIIDetector string // "rules" or "none"
 }
 
 func Load() Config {
@@ -15,6 +57,35 @@ func Load() Config {
 		Env:        get("APP_ENV", "dev"),
 		HTTPPort:   get("HTTP_PORT", "8080"),
 		TempFolder: get("TEMP_FOLDER", "./tmp"),
+
+		MaxUploadBytes: getInt64("MAX_UPLOAD_BYTES", 50<<20),
+		MaxPages:       getInt("MAX_PAGES", 2000),
+		MaxStreamBytes: getInt64("MAX_STREAM_BYTES", 64<<20),
+
+		AnalysisTimeout: getDuration("ANALYSIS_TIMEOUT", time.Minute),
+
+		JobWorkers:   getInt("JOB_WORKERS", 4),
+		JobQueueSize: getInt("JOB_QUEUE_SIZE", 100),
+		JobRetention: getDuration("JOB_RETENTION", time.Hour),
+
+		BatchMaxFiles:    getInt("BATCH_MAX_FILES", 50),
+		BatchMaxBytes:    getInt64("BATCH_MAX_BYTES", 200<<20),
+		BatchConcurrency: getInt("BATCH_CONCURRENCY", 4),
+
+		CacheBackend:    get("CACHE_BACKEND", "memory"),
+		CacheMaxEntries: getInt("CACHE_MAX_ENTRIES", 1000),
+		CacheMaxBytes:   getInt64("CACHE_MAX_BYTES", 256<<20),
+		CacheTTL:        getDuration("CACHE_TTL", 24*time.Hour),
+
+		OCREngine:     get("OCR_ENGINE", "tesseract"),
+		OCRLanguages:  get("OCR_LANGUAGES", "eng"),
+		TesseractPath: get("TESSERACT_PATH", "tesseract"),
+
+		TermStatsBackend:      get("TERM_STATS_BACKEND", "memory"),
+		TermStatsMaxDocuments: getInt("TERM_STATS_MAX_DOCUMENTS", 100000),
+		TermStatsMaxTerms:     getInt("TERM_STATS_MAX_TERMS", 500000),
+
+		PIIDetector: get("PII_DETECTOR", "rules"),
 	}
 
 	return cfg
@@ -26,3 +97,48 @@ func get(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func getInt64(key string, fallback int64) int64 {
+	if v, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+// CacheEnabled reports whether a result cache backend is configured.
+func (c Config) CacheEnabled() bool {
+	return c.CacheBackend == "memory" || c.CacheBackend == "disk"
+}
+
+// UploadRetention returns how long the upload of a job is kept for
+// image downloads: JOB_RETENTION, or one hour when finished jobs are
+// kept forever, so that uploads are always released.
+func (c Config) UploadRetention() time.Duration {
+	if c.JobRetention > 0 {
+		return c.JobRetention
+	}
+	return time.Hour
+}
+
+// AnalysisContext derives the context an analysis runs with,
+// applying AnalysisTimeout when it is set.
+func (c Config) AnalysisContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if c.AnalysisTimeout > 0 {
+		return context.WithTimeout(parent, c.AnalysisTimeout)
+	}
+	return context.WithCancel(parent)
+}
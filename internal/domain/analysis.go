@@ -2,17 +2,123 @@ package domain
 
 // AnalysisResult represents the pure domain entity.
 type AnalysisResult struct {
-	Content   string
-	WordCount int
+	Content        string
+	WordCount      int
+	TokenCount     int
+	SentenceCount  int
+	ParagraphCount int
+	Pages          []PageAnalysis
+	Metadata       Metadata
+	Encryption     Encryption
+	Outline        Outline
+	Annotations    []Annotation
+	Images         []Image
+	Fonts          []Font
+	FormFields     []FormField
+	Language       Language // of Content as a whole
+
+	// PII lists the personal data found in the document: in the page
+	// texts, in page order, then in the annotations, the form fields
+	// and the metadata. It is filled by the use case, not by
+	// analyzers.
+	PII []PIIMatch
+
+	// FromCache reports whether the result was served from the
+	// result cache instead of being parsed from the file.
+	FromCache bool
+}
+
+// ContentStats is the text of a document or page with its counts and
+// language, as analyzers count text layers.
+type ContentStats struct {
+	Content        string
+	WordCount      int
+	TokenCount     int
+	SentenceCount  int
+	ParagraphCount int
+	CharCount      int // non-whitespace characters
+	Language       Language
+}
+
+// NeedsOCR reports whether some page has no text layer and must be
+// recognized to be read.
+func (a AnalysisResult) NeedsOCR() bool {
+	for _, p := range a.Pages {
+		if p.NeedsOCR() {
+			return true
+		}
+	}
+	return false
+}
+
+// TextCoverage returns the fraction of non-blank pages that carry a
+// text layer, from 0 to 1. Documents with only blank pages have a
+// coverage of 1: nothing is missing.
+func (a AnalysisResult) TextCoverage() float64 {
+	var withText, nonBlank int
+	for _, p := range a.Pages {
+		if p.IsBlank() {
+			continue
+		}
+		nonBlank++
+		if p.HasText() {
+			withText++
+		}
+	}
+	if nonBlank == 0 {
+		return 1
+	}
+	return float64(withText) / float64(nonBlank)
 }
 
-// Validate enforces domain invariants.
+// Validate enforces domain invariants. A document without text fails
+// with ErrScannedDocument when its pages are images, and with
+// ErrEmptyContent otherwise.
 func (a AnalysisResult) Validate() error {
 	if a.Content == "" {
+		if a.NeedsOCR() {
+			return ErrScannedDocument
+		}
 		return ErrEmptyContent
 	}
-	if a.WordCount < 0 {
+	if a.WordCount < 0 || a.TokenCount < 0 || a.SentenceCount < 0 || a.ParagraphCount < 0 {
 		return ErrInvalidWordCount
 	}
-	return nil
+	if err := a.Language.Validate(); err != nil {
+		return err
+	}
+	for i, p := range a.Pages {
+		if p.Number != i+1 {
+			return ErrInvalidPage
+		}
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, an := range a.Annotations {
+		if err := an.Validate(len(a.Pages)); err != nil {
+			return err
+		}
+	}
+	for _, img := range a.Images {
+		if err := img.Validate(len(a.Pages)); err != nil {
+			return err
+		}
+	}
+	for _, f := range a.Fonts {
+		if err := f.Validate(len(a.Pages)); err != nil {
+			return err
+		}
+	}
+	for _, f := range a.FormFields {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, m := range a.PII {
+		if err := m.Validate(len(a.Pages)); err != nil {
+			return err
+		}
+	}
+	return a.Outline.Validate(len(a.Pages))
 }
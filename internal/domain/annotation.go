@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Annotation types.
+const (
+	AnnotLink      = "link"
+	AnnotNote      = "note"
+	AnnotFreeText  = "free_text"
+	AnnotHighlight = "highlight"
+	AnnotUnderline = "underline"
+	AnnotStrikeOut = "strike_out"
+	AnnotSquiggly  = "squiggly"
+	AnnotStamp     = "stamp"
+)
+
+// Annotation is a link, comment or text markup placed on a page.
+type Annotation struct {
+	Page     int
+	Type     string // one of the Annot* constants
+	Rect     Rect
+	Contents string
+	Author   string
+	Modified time.Time // zero when unknown
+
+	URI             string // external link target
+	DestinationPage int    // internal link target, 0 when none
+	MarkedText      string // text covered by text markup
+	Stamp           string // stamp name
+}
+
+// Validate checks that the annotation and its link target lie within
+// the document.
+func (a Annotation) Validate(pageCount int) error {
+	if a.Page < 1 || a.Page > pageCount || a.DestinationPage < 0 || a.DestinationPage > pageCount {
+		return ErrInvalidAnnotation
+	}
+	if a.Rect.X1 > a.Rect.X2 || a.Rect.Y1 > a.Rect.Y2 {
+		return ErrInvalidAnnotation
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+// Encryption describes how a document is protected. It is the zero
+// value for documents that are not encrypted.
+type Encryption struct {
+	Encrypted   bool
+	Filter      string // security handler
+	Version     int
+	Revision    int
+	KeyLength   int // bits
+	Algorithm   string
+	Permissions Permissions
+}
+
+// Permissions are the operations the document author allows to
+// users who opened it with the user password.
+type Permissions struct {
+	Print            bool
+	Modify           bool
+	Copy             bool
+	Annotate         bool
+	FillForms        bool
+	ExtractForAccess bool
+	Assemble         bool
+	PrintHighQuality bool
+}
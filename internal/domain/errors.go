@@ -1,11 +1,39 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Domain-level errors, not tied to HTTP or infra.
 // These errors describe violations of business invariants.
 
 var (
-	ErrEmptyContent     = errors.New("analysis content cannot be empty")
-	ErrInvalidWordCount = errors.New("invalid word count")
+	ErrEmptyContent      = errors.New("analysis content cannot be empty")
+	ErrScannedDocument   = errors.New("document has no text layer: its pages are images that need OCR")
+	ErrInvalidWordCount  = errors.New("invalid word count")
+	ErrInvalidPage       = errors.New("invalid page analysis")
+	ErrInvalidOutline    = errors.New("invalid document outline")
+	ErrInvalidFormField  = errors.New("invalid form field")
+	ErrInvalidAnnotation = errors.New("invalid annotation")
+	ErrInvalidImage      = errors.New("invalid image")
+	ErrImageNotFound     = errors.New("image not found")
+	ErrInvalidFont       = errors.New("invalid font")
+	ErrInvalidTable      = errors.New("invalid table")
+	ErrInvalidLanguage   = errors.New("invalid language")
+	ErrInvalidPII        = errors.New("invalid personal data match")
+	ErrDocumentTooLarge  = errors.New("document exceeds processing limits")
+	ErrAnalysisTimeout   = errors.New("analysis timed out")
+	ErrAnalysisCancelled = errors.New("analysis was cancelled")
+	ErrOCRUnavailable    = errors.New("OCR is not available")
+
+	// The file is a PDF the analyzer cannot read.
+	ErrEncryptedDocument   = errors.New("document is encrypted")
+	ErrCorruptDocument     = errors.New("document is corrupt")
+	ErrUnsupportedDocument = errors.New("document uses unsupported PDF features")
+
+	// Encrypted documents that need a password to be opened. Both
+	// wrap ErrEncryptedDocument.
+	ErrPasswordRequired  = fmt.Errorf("%w: password required", ErrEncryptedDocument)
+	ErrIncorrectPassword = fmt.Errorf("%w: incorrect password", ErrEncryptedDocument)
 )
@@ -0,0 +1,55 @@
+package domain
+
+import "strings"
+
+// Font subtypes.
+const (
+	FontType1    = "Type1"
+	FontMMType1  = "MMType1"
+	FontTrueType = "TrueType"
+	FontType0    = "Type0" // composite (CID-keyed) font
+	FontType3    = "Type3"
+)
+
+// Font is a font used by the document.
+type Font struct {
+	Name      string // without the subset prefix
+	Subtype   string
+	Embedded  bool
+	Subset    bool
+	Encoding  string // "" for the font's built-in encoding
+	ToUnicode bool
+	Pages     []int
+}
+
+// TextExtractable reports whether the text drawn with the font can be
+// mapped back to Unicode. Without a ToUnicode map, composite fonts
+// with an Identity encoding only yield glyph IDs, and Type3 glyph
+// names are arbitrary: their text comes out as garbage.
+func (f Font) TextExtractable() bool {
+	if f.ToUnicode {
+		return true
+	}
+	switch f.Subtype {
+	case FontType3:
+		return false
+	case FontType0:
+		return !strings.HasPrefix(f.Encoding, "Identity-")
+	default:
+		return true
+	}
+}
+
+// Validate checks that the font is used on pages of the document, in
+// ascending order.
+func (f Font) Validate(pageCount int) error {
+	if f.Name == "" || len(f.Pages) == 0 {
+		return ErrInvalidFont
+	}
+	for i, p := range f.Pages {
+		if p < 1 || p > pageCount || i > 0 && p <= f.Pages[i-1] {
+			return ErrInvalidFont
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+// Form field types.
+const (
+	FieldText      = "text"
+	FieldCheckbox  = "checkbox"
+	FieldRadio     = "radio"
+	FieldButton    = "button"
+	FieldChoice    = "choice"
+	FieldSignature = "signature"
+)
+
+// FormField is a terminal field of an interactive (AcroForm) form.
+type FormField struct {
+	Name     string   // fully qualified, dot-separated name
+	Type     string   // one of the Field* constants, "" when unknown
+	Value    string   // current value; the state name for check boxes and radios
+	Values   []string // every selected option of multi-select choice fields
+	Options  []FormOption
+	Required bool
+	ReadOnly bool
+	Page     int // page of the first widget, 0 when unknown
+	Rect     Rect
+}
+
+// FormOption is a selectable value of a choice, check box or radio
+// field.
+type FormOption struct {
+	Value string
+	Label string
+}
+
+// Rect is a rectangle in PDF user space, in points, with X1 <= X2
+// and Y1 <= Y2.
+type Rect struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// Validate enforces form field invariants.
+func (f FormField) Validate() error {
+	if f.Page < 0 || f.Rect.X1 > f.Rect.X2 || f.Rect.Y1 > f.Rect.Y2 {
+		return ErrInvalidFormField
+	}
+	return nil
+}
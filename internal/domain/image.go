@@ -0,0 +1,32 @@
+package domain
+
+// Image is an image placed on a page.
+type Image struct {
+	Page             int
+	Name             string // resource name
+	Width, Height    int    // in pixels
+	BitsPerComponent int
+	ColorSpace       string
+	Filter           string // compression filter, "" when uncompressed
+	ByteSize         int64
+	DPIX, DPIY       float64 // effective resolution, 0 when not drawn
+}
+
+// ImageData is an image exported from a document.
+type ImageData struct {
+	ContentType string
+	Extension   string // file name extension, with the dot
+	Data        []byte
+}
+
+// Validate checks that the image lies within the document and has
+// non-negative dimensions.
+func (i Image) Validate(pageCount int) error {
+	if i.Page < 1 || i.Page > pageCount {
+		return ErrInvalidImage
+	}
+	if i.Width < 0 || i.Height < 0 || i.BitsPerComponent < 0 || i.ByteSize < 0 || i.DPIX < 0 || i.DPIY < 0 {
+		return ErrInvalidImage
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package domain
+
+// Language is the language a text is written in.
+type Language struct {
+	Code       string  // ISO 639-1 code, empty when undetermined
+	Confidence float64 // from 0 to 1, 0 when undetermined
+}
+
+// Validate enforces language invariants: a two-letter lowercase code
+// with a confidence, or neither.
+func (l Language) Validate() error {
+	if l.Code == "" {
+		if l.Confidence != 0 {
+			return ErrInvalidLanguage
+		}
+		return nil
+	}
+	if len(l.Code) != 2 || l.Code[0] < 'a' || l.Code[0] > 'z' || l.Code[1] < 'a' || l.Code[1] > 'z' {
+		return ErrInvalidLanguage
+	}
+	if l.Confidence < 0 || l.Confidence > 1 {
+		return ErrInvalidLanguage
+	}
+	return nil
+}
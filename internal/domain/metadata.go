@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Metadata describes a document as declared by its producer:
+// the information dictionary, version and XMP properties.
+type Metadata struct {
+	Title            string
+	Author           string
+	Subject          string
+	Keywords         string
+	Creator          string
+	Producer         string
+	CreationDate     time.Time
+	ModificationDate time.Time
+	PDFVersion       string
+	PageCount        int
+	XMP              map[string]string
+}
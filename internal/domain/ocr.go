@@ -0,0 +1,7 @@
+package domain
+
+// OCRResult is the text recognized in an image.
+type OCRResult struct {
+	Text       string
+	Confidence float64 // mean word confidence, from 0 to 1
+}
@@ -0,0 +1,40 @@
+package domain
+
+// Outline sources.
+const (
+	OutlineBookmarks = "bookmarks"
+	OutlineHeadings  = "headings"
+)
+
+// Outline is the navigation tree of a document: its bookmarks or,
+// when it has none, the headings found in its text.
+type Outline struct {
+	Source string // OutlineBookmarks, OutlineHeadings, or "" when empty
+	Items  []OutlineItem
+}
+
+// OutlineItem is one entry of the outline tree.
+type OutlineItem struct {
+	Title    string
+	Level    int // nesting depth, 1 for top-level entries
+	Page     int // destination page, 0 when unknown
+	Children []OutlineItem
+}
+
+// Validate checks that levels follow the nesting and that pages fall
+// within the document.
+func (o Outline) Validate(pageCount int) error {
+	return validateOutlineItems(o.Items, 1, pageCount)
+}
+
+func validateOutlineItems(items []OutlineItem, level, pageCount int) error {
+	for _, it := range items {
+		if it.Level != level || it.Page < 0 || it.Page > pageCount {
+			return ErrInvalidOutline
+		}
+		if err := validateOutlineItems(it.Children, level+1, pageCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
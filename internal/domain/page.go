@@ -0,0 +1,94 @@
+package domain
+
+// Page kinds, from the text a page carries and the area its images
+// cover.
+const (
+	PageText  = "text"  // a text layer, with little or no imagery
+	PageImage = "image" // images without a text layer, such as scans
+	PageMixed = "mixed" // a text layer over large images
+	PageBlank = "blank" // neither text nor images
+)
+
+// Thresholds of the page classification.
+const (
+	// MinTextDensity is the number of characters per square inch from
+	// which a page has a text layer. A full page of text carries about
+	// 30; page numbers and stamps on a scan stay well below 0.5.
+	MinTextDensity = 0.5
+	// MinScanCoverage is the fraction of the page images must cover
+	// for a page without a text layer to read as a scan.
+	MinScanCoverage = 0.5
+	// minTextChars replaces the density when the page size is unknown.
+	minTextChars = 50
+)
+
+// PageAnalysis represents the analysis of a single page
+// of a document.
+type PageAnalysis struct {
+	Number        int
+	Text          string
+	WordCount     int
+	CharCount     int
+	Width         float64
+	Height        float64
+	Rotation      int
+	ImageCoverage float64 // fraction of the page covered by images
+
+	// OCR reports that Text was recognized in the page image rather
+	// than read from a text layer, with OCRConfidence from 0 to 1.
+	OCR           bool
+	OCRConfidence float64
+
+	Language Language // of Text
+}
+
+// HasText reports whether the page carries a text layer: enough
+// characters for its size to be more than page numbers or stamps.
+func (p PageAnalysis) HasText() bool {
+	if p.Width <= 0 || p.Height <= 0 {
+		return p.CharCount >= minTextChars
+	}
+	squareInches := p.Width * p.Height / (72 * 72)
+	return float64(p.CharCount)/squareInches >= MinTextDensity
+}
+
+// Kind classifies the page as one of the Page* constants.
+func (p PageAnalysis) Kind() string {
+	switch {
+	case p.CharCount == 0 && p.ImageCoverage == 0:
+		return PageBlank
+	case p.HasText() && p.ImageCoverage >= MinScanCoverage:
+		return PageMixed
+	case p.HasText():
+		return PageText
+	case p.ImageCoverage >= MinScanCoverage || p.CharCount == 0:
+		return PageImage
+	default:
+		return PageText
+	}
+}
+
+// NeedsOCR reports whether the text of the page can only be recovered
+// by optical character recognition, and has not been yet.
+func (p PageAnalysis) NeedsOCR() bool {
+	return !p.OCR && p.Kind() == PageImage
+}
+
+// IsBlank reports whether the page carries neither text nor images.
+func (p PageAnalysis) IsBlank() bool {
+	return p.Kind() == PageBlank
+}
+
+// Validate enforces page-level invariants.
+func (p PageAnalysis) Validate() error {
+	if p.Number < 1 || p.WordCount < 0 || p.CharCount < 0 {
+		return ErrInvalidPage
+	}
+	if p.Width < 0 || p.Height < 0 || p.Rotation%90 != 0 {
+		return ErrInvalidPage
+	}
+	if p.ImageCoverage < 0 || p.ImageCoverage > 1 || p.OCRConfidence < 0 || p.OCRConfidence > 1 {
+		return ErrInvalidPage
+	}
+	return p.Language.Validate()
+}
@@ -0,0 +1,59 @@
+package domain
+
+// Kinds of personal data reported by the built-in PII rules. Detectors
+// may report other kinds.
+const (
+	PIIEmail      = "email"
+	PIIPhone      = "phone"
+	PIIIBAN       = "iban"
+	PIICreditCard = "credit_card"
+	PIICPF        = "cpf"  // Brazilian individual taxpayer number
+	PIICNPJ       = "cnpj" // Brazilian company taxpayer number
+	PIISSN        = "ssn"  // US Social Security number
+)
+
+// Places of a document scanned for personal data, as reported in
+// PIIMatch.Source.
+const (
+	PIISourceText       = "text"       // the text of a page
+	PIISourceAnnotation = "annotation" // the contents of an annotation
+	PIISourceFormField  = "form_field" // the value of a form field
+	PIISourceMetadata   = "metadata"   // an information dictionary or XMP entry
+)
+
+// PIIMatch is personal data found in a document. The value is never
+// kept: Preview masks all but a few of its characters.
+type PIIMatch struct {
+	Type   string
+	Source string // one of the PIISource* constants
+	// Field names the form field or metadata entry holding the match.
+	Field string
+	// Page is 1-based, 0 for metadata and for form fields without a
+	// known page.
+	Page    int
+	Offset  int // in characters (code points) from the start of the scanned text
+	Length  int // in characters
+	Preview string
+}
+
+// Validate enforces match invariants against the page count of the
+// document.
+func (m PIIMatch) Validate(pageCount int) error {
+	if m.Type == "" || m.Page < 0 || m.Page > pageCount || m.Offset < 0 || m.Length < 1 {
+		return ErrInvalidPII
+	}
+	switch m.Source {
+	case PIISourceText, PIISourceAnnotation:
+		if m.Page < 1 {
+			return ErrInvalidPII
+		}
+	case PIISourceFormField:
+	case PIISourceMetadata:
+		if m.Page != 0 {
+			return ErrInvalidPII
+		}
+	default:
+		return ErrInvalidPII
+	}
+	return nil
+}
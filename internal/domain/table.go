@@ -0,0 +1,51 @@
+package domain
+
+// Table detection methods.
+const (
+	TableRuled   = "ruled"   // cells bounded by drawn lines
+	TableAligned = "aligned" // columns found from the word positions
+)
+
+// Table is a table found on a page: a grid of Rows × Columns
+// positions covered by Cells.
+type Table struct {
+	Page    int
+	Method  string // one of the Table* constants
+	Rect    Rect
+	Rows    int
+	Columns int
+	Cells   []TableCell // in row-major order
+}
+
+// TableCell is a cell of a table, at a 0-based row and column. A cell
+// spanning several positions is listed once, at its top-left one.
+type TableCell struct {
+	Row, Column         int
+	RowSpan, ColumnSpan int
+	Text                string // lines separated by "\n"
+}
+
+// PageRange is an inclusive range of 1-based page numbers. A Last of
+// zero runs to the end of the document.
+type PageRange struct {
+	First, Last int
+}
+
+// Contains reports whether page n is in r.
+func (r PageRange) Contains(n int) bool {
+	return n >= r.First && (r.Last == 0 || n <= r.Last)
+}
+
+// Validate enforces table invariants: every cell lies inside the grid.
+func (t Table) Validate() error {
+	if t.Page < 1 || t.Rows < 1 || t.Columns < 1 || t.Rect.X1 > t.Rect.X2 || t.Rect.Y1 > t.Rect.Y2 {
+		return ErrInvalidTable
+	}
+	for _, c := range t.Cells {
+		if c.Row < 0 || c.Column < 0 || c.RowSpan < 1 || c.ColumnSpan < 1 ||
+			c.Row+c.RowSpan > t.Rows || c.Column+c.ColumnSpan > t.Columns {
+			return ErrInvalidTable
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+// Package keywords finds what a text is about: its most significant
+// terms, scored by TF-IDF, and its keyphrases, scored by RAKE (Rose et
+// al., "Automatic keyword extraction from individual documents",
+// 2010). Stopwords are removed with a list for the language of the
+// text.
+package keywords
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/tokenizer"
+)
+
+// Keyword is a term or keyphrase with its score, from 0 to 1 relative
+// to the best one, and its number of occurrences.
+type Keyword struct {
+	Text  string
+	Score float64
+	Count int
+}
+
+// IDF returns the inverse document frequency weight of a term.
+type IDF func(term string) float64
+
+// SmoothIDF is the smoothed inverse document frequency of a term found
+// in df of docs documents: ln((1+docs)/(1+df)) + 1. It is 1 when every
+// document has the term, and never zero, so terms seen everywhere are
+// kept but not favored.
+func SmoothIDF(docs, df int) float64 {
+	return math.Log(float64(1+docs)/float64(1+df)) + 1
+}
+
+// Limits of the extraction.
+const (
+	// DefaultTop is the number of keywords and keyphrases returned
+	// when none is asked for.
+	DefaultTop = 10
+	// MaxTop bounds the number of keywords and keyphrases returned.
+	MaxTop = 100
+	// maxPhraseWords is the longest keyphrase reported.
+	maxPhraseWords = 4
+)
+
+// Document is a text split into candidate words: the runs of words
+// between stopwords, punctuation and paragraph breaks.
+type Document struct {
+	Language string
+	runs     [][]string
+}
+
+// paragraphBreak matches a blank line.
+var paragraphBreak = regexp.MustCompile(`\n[ \t\r\f\v]*\n`)
+
+// Parse splits text into candidate runs, removing the stopwords of
+// language, an ISO 639-1 code. An empty language, or one without a
+// list, is guessed from the stopwords found among Languages.
+func Parse(text, language string) *Document {
+	var words [][]string // per paragraph, lowercased, nil for breaks
+	var all []string
+	for _, para := range paragraphBreak.Split(tokenizer.JoinHyphenated(text), -1) {
+		var ws []string
+		for _, tok := range tokenizer.Tokenize(para) {
+			if tok.Kind != tokenizer.Word {
+				ws = append(ws, "")
+				continue
+			}
+			w := normalize(tok.Text)
+			ws = append(ws, w)
+			all = append(all, w)
+		}
+		words = append(words, ws)
+	}
+
+	if _, ok := stopwords[language]; !ok {
+		language = guessLanguage(all)
+	}
+	stop := stopwords[language]
+
+	d := &Document{Language: language}
+	for _, ws := range words {
+		var run []string
+		for _, w := range ws {
+			if !candidate(w) || stop[w] {
+				if len(run) > 0 {
+					d.runs = append(d.runs, run)
+				}
+				run = nil
+				continue
+			}
+			run = append(run, w)
+		}
+		if len(run) > 0 {
+			d.runs = append(d.runs, run)
+		}
+	}
+	return d
+}
+
+// normalize lowercases a word, unifies apostrophes and drops the
+// English possessive.
+func normalize(w string) string {
+	w = strings.ToLower(strings.ReplaceAll(w, "’", "'"))
+	return strings.TrimSuffix(w, "'s")
+}
+
+// candidate reports whether w may be a keyword: two letters or more,
+// so numbers, initials and stray glyphs are left out.
+func candidate(w string) bool {
+	letters := 0
+	for _, r := range w {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+// Terms returns the distinct candidate words of the document, sorted,
+// as recorded by document frequency stores.
+func (d *Document) Terms() []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, run := range d.runs {
+		for _, w := range run {
+			if !seen[w] {
+				seen[w] = true
+				terms = append(terms, w)
+			}
+		}
+	}
+	sort.Strings(terms)
+	return terms
+}
+
+// Keywords returns the top terms by TF-IDF. A nil idf weighs every
+// term 1, which ranks terms by frequency.
+func (d *Document) Keywords(top int, idf IDF) []Keyword {
+	counts := make(map[string]int)
+	total := 0
+	for _, run := range d.runs {
+		for _, w := range run {
+			counts[w]++
+			total++
+		}
+	}
+
+	out := make([]Keyword, 0, len(counts))
+	for w, n := range counts {
+		out = append(out, Keyword{Text: w, Score: float64(n) / float64(total) * weight(idf, w), Count: n})
+	}
+	return rank(out, top)
+}
+
+// Keyphrases returns the top phrases of two to maxPhraseWords words
+// found inside runs. A phrase scores the sum of the RAKE scores of its
+// words, their degree (the words they co-occur with in runs,
+// themselves included) divided by their frequency, times its
+// occurrences and the mean IDF of its words. Phrases seen once are
+// dropped when others repeat, and so are phrases only seen inside a
+// longer one.
+func (d *Document) Keyphrases(top int, idf IDF) []Keyword {
+	freq := make(map[string]int)
+	degree := make(map[string]int)
+	counts := make(map[string]int)
+	for _, run := range d.runs {
+		for _, w := range run {
+			freq[w]++
+			degree[w] += len(run)
+		}
+		for n := 2; n <= maxPhraseWords; n++ {
+			for i := 0; i+n <= len(run); i++ {
+				counts[strings.Join(run[i:i+n], " ")]++
+			}
+		}
+	}
+
+	// A phrase seen as often as a phrase one word longer that starts
+	// or ends with it only occurs as part of that phrase.
+	repeated := false
+	inside := make(map[string]bool)
+	for text, n := range counts {
+		repeated = repeated || n > 1
+		if words := strings.Fields(text); len(words) > 2 {
+			for _, part := range []string{strings.Join(words[:len(words)-1], " "), strings.Join(words[1:], " ")} {
+				if counts[part] == n {
+					inside[part] = true
+				}
+			}
+		}
+	}
+
+	out := make([]Keyword, 0, len(counts))
+	for text, n := range counts {
+		if repeated && n < 2 || inside[text] {
+			continue
+		}
+		words := strings.Fields(text)
+		var rake, weights float64
+		for _, w := range words {
+			rake += float64(degree[w]) / float64(freq[w])
+			weights += weight(idf, w)
+		}
+		score := rake * float64(n) * weights / float64(len(words))
+		out = append(out, Keyword{Text: text, Score: score, Count: n})
+	}
+	return rank(out, top)
+}
+
+func weight(idf IDF, term string) float64 {
+	if idf == nil {
+		return 1
+	}
+	return idf(term)
+}
+
+// rank sorts keywords by score, then alphabetically, keeps the first
+// top (DefaultTop when top < 1, at most MaxTop) and scales the scores
+// so the best is 1.
+func rank(out []Keyword, top int) []Keyword {
+	if top < 1 {
+		top = DefaultTop
+	}
+	top = min(top, MaxTop)
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Text < out[j].Text
+	})
+	if len(out) > top {
+		out = out[:top]
+	}
+	if len(out) > 0 && out[0].Score > 0 {
+		best := out[0].Score
+		for i := range out {
+			out[i].Score = math.Round(out[i].Score/best*10000) / 10000
+		}
+	}
+	return out
+}
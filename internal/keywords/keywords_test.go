@@ -0,0 +1,89 @@
+package keywords
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const englishText = `Solar panels convert sunlight into electricity. The efficiency of solar panels
+depends on the angle of the panels and on the temperature.
+
+Battery storage keeps the electricity for the night. Battery storage is still
+expensive, but solar panels are cheaper every year.`
+
+func texts(kws []Keyword) string {
+	var s []string
+	for _, k := range kws {
+		s = append(s, fmt.Sprintf("%s=%g/%d", k.Text, k.Score, k.Count))
+	}
+	return strings.Join(s, " ")
+}
+
+func TestParse_GuessesLanguage(t *testing.T) {
+	if d := Parse(englishText, ""); d.Language != English {
+		t.Errorf("language = %q, want en", d.Language)
+	}
+	pt := "O contrato de prestação de serviços é válido por um ano e pode ser renovado pelas partes."
+	if d := Parse(pt, ""); d.Language != Portuguese {
+		t.Errorf("language = %q, want pt", d.Language)
+	}
+	es := "El contrato de prestación de servicios es válido por un año y puede ser renovado por las partes."
+	if d := Parse(es, ""); d.Language != Spanish {
+		t.Errorf("language = %q, want es", d.Language)
+	}
+	if d := Parse(pt, English); d.Language != English {
+		t.Errorf("an explicit language must be kept, got %q", d.Language)
+	}
+}
+
+func TestDocument_Keywords(t *testing.T) {
+	d := Parse(englishText, English)
+
+	got := texts(d.Keywords(3, nil))
+	want := "panels=1/4 solar=0.75/3 battery=0.5/2"
+	if got != want {
+		t.Errorf("keywords:\n got %s\nwant %s", got, want)
+	}
+
+	// Terms found in every document of the corpus lose their rank.
+	idf := func(term string) float64 {
+		if term == "panels" || term == "solar" {
+			return SmoothIDF(10, 10)
+		}
+		return SmoothIDF(10, 1)
+	}
+	if top := d.Keywords(1, idf); len(top) != 1 || top[0].Text != "battery" {
+		t.Errorf("with IDF, top keyword = %v, want battery", top)
+	}
+}
+
+func TestDocument_Keyphrases(t *testing.T) {
+	d := Parse(englishText, English)
+
+	got := texts(d.Keyphrases(2, nil))
+	want := "solar panels=1/3 battery storage=0.6061/2"
+	if got != want {
+		t.Errorf("keyphrases:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestDocument_Terms(t *testing.T) {
+	// Numbers and words of a single letter are not terms.
+	d := Parse("The 2024 report: Q3 revenue, the report’s revenue.", English)
+	if got := strings.Join(d.Terms(), " "); got != "report revenue" {
+		t.Errorf("terms = %q", got)
+	}
+}
+
+func TestDocument_Portuguese(t *testing.T) {
+	text := "A nota fiscal eletrônica substitui a nota fiscal em papel. Cada nota fiscal eletrônica tem uma chave de acesso."
+	d := Parse(text, Portuguese)
+
+	if top := d.Keyphrases(1, nil); len(top) != 1 || top[0].Text != "nota fiscal eletrônica" {
+		t.Errorf("keyphrases = %v, want nota fiscal eletrônica first", top)
+	}
+	if kws := texts(d.Keywords(2, nil)); kws != "fiscal=1/3 nota=1/3" {
+		t.Errorf("keywords = %s", kws)
+	}
+}
@@ -0,0 +1,84 @@
+package keywords
+
+import "strings"
+
+// Languages with a stopword list, as ISO 639-1 codes.
+const (
+	English    = "en"
+	Portuguese = "pt"
+	Spanish    = "es"
+)
+
+// stopwords holds, per language, the function words and the most
+// common verbs and adverbs, which carry no topic on their own.
+var stopwords = map[string]map[string]bool{
+	English: set(`
+		a about above after again against all almost also although always am among an and another any
+		anyone anything are around as at be became because become been before being below between both
+		but by can cannot could did do does doing done down during each either else enough etc even ever
+		every few first for from further get gets got had has have having he her here hers herself him
+		himself his how however i if in into is it its itself just last least less like made make many
+		may me might more most much must my myself neither never new next no nor not now of off often on
+		once one only or other others our ours ourselves out over own per perhaps rather same see seen
+		several shall she should since so some such than that the their theirs them themselves then there
+		these they this those though through thus to too two under until up upon us use used using very
+		via was way we well were what when where whether which while who whom whose why will with within
+		without would yet you your yours yourself yourselves
+		can't don't doesn't didn't isn't aren't wasn't weren't won't wouldn't shouldn't couldn't it's
+		i'm i've i'd i'll you're you've we're we've they're they've that's there's let's
+		`),
+	Portuguese: set(`
+		a à às ao aos aquela aquelas aquele aqueles aquilo as até com como contra cada da das de dela
+		delas dele deles depois desde dessa dessas desse desses desta destas deste destes do dos e é ela
+		elas ele eles em entre era eram essa essas esse esses esta está estão estas estava estavam este
+		estes eu foi foram há isso isto já lhe lhes mais mas me mesma mesmas mesmo mesmos meu meus minha
+		minhas muito muitos na nas não nem no nos nós nossa nossas nosso nossos num numa o os ou onde
+		para pela pelas pelo pelos per perante pois por porque quais qual quando que quem se seja sejam
+		sem sendo ser será serão seu seus si sido sob sobre sua suas também tal tão te tem têm tendo
+		tenho ter teu teus tinha tinham toda todas todo todos tu tua tuas um uma umas uns vai vão você
+		vocês vos ainda além apenas assim bem cá deve devem disso disto dito fazer feito faz fez havia
+		lá logo menos num numas nuns outra outras outro outros pode podem qualquer quanto sempre só
+		tanto tanta toda vez vezes
+		`),
+	Spanish: set(`
+		a al algo algunas algunos ante antes aquel aquella aquellas aquellos aquí así aun aunque bajo
+		bien cada casi como con contra cual cuales cualquier cuando cuanto de del desde donde dos el él
+		ella ellas ello ellos en entre era eran es esa esas ese eso esos esta está están estas este esto
+		estos fue fueron ha había han hasta hay la las le les lo los más me mi mis mismo mucho muy nada
+		ni no nos nosotros o os otra otras otro otros para pero poco por porque pues que qué quien
+		quienes se sea ser será si sí sido siempre sin sobre su sus tal también tan tanto te tiene tienen
+		todo todos tras tu tus un una unas uno unos usted ustedes ya yo además debe deben hace hacer
+		puede pueden sólo solo según vez veces
+		`),
+}
+
+func set(words string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		m[w] = true
+	}
+	return m
+}
+
+// Languages lists the languages with a stopword list.
+func Languages() []string {
+	return []string{English, Portuguese, Spanish}
+}
+
+// guessLanguage picks the language whose stopwords occur most often in
+// words, English when none does.
+func guessLanguage(words []string) string {
+	best, hits := English, 0
+	for _, lang := range Languages() {
+		n := 0
+		for _, w := range words {
+			if stopwords[lang][w] {
+				n++
+			}
+		}
+		if n > hits {
+			best, hits = lang, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,226 @@
+// Package langid identifies the language of a text from its character
+// n-grams, without network calls or external models. Each language has
+// a profile, the frequencies of the one to three letter sequences of a
+// sample text embedded in the binary, and a text is attributed to the
+// profile most likely to produce its own sequences (naive Bayes). A
+// text that fits that profile poorly, with few of its words or many of
+// its sequences missing from the sample, is in another language and is
+// not identified.
+package langid
+
+import (
+	"embed"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Languages with a profile, as ISO 639-1 codes.
+const (
+	English    = "en"
+	Portuguese = "pt"
+	Spanish    = "es"
+)
+
+// Languages lists the languages with a profile.
+func Languages() []string {
+	return []string{English, Portuguese, Spanish}
+}
+
+// Result is the language of a text and the confidence of the
+// identification, from 0 to 1. Code is empty when the text is too
+// short, not written in the Latin script, or in a language without a
+// profile.
+type Result struct {
+	Code       string
+	Confidence float64
+}
+
+// Limits of the identification.
+const (
+	// MinLetters is the number of letters below which a text is not
+	// identified: page numbers, headers and stray words.
+	MinLetters = 20
+	// maxN is the length of the longest n-gram.
+	maxN = 3
+	// maxWords bounds the words read from a long text; they are
+	// sampled evenly across it.
+	maxWords = 5000
+	// evidence is the number of n-grams whose mean weight sets the
+	// confidence. Longer texts are not more certain: without the cap,
+	// any paragraph would reach 1 even between close languages.
+	evidence = 10
+	// alpha smooths the frequencies of n-grams a sample lacks.
+	alpha = 0.5
+	// minKnownWords is the share of the words of a text that must
+	// appear in the sample of its language. Function words make up a
+	// large part of any text and all of them are in the samples, so
+	// texts in other languages fall well below it.
+	minKnownWords = 0.2
+	// maxUnseen is the share of the three letter sequences of a text
+	// that may be missing from the sample of its language.
+	maxUnseen = 0.35
+)
+
+//go:embed profiles/*.txt
+var samples embed.FS
+
+// profile holds the n-gram log-probabilities of a language.
+type profile struct {
+	code    string
+	logProb map[string]float64
+	unseen  float64         // log-probability of an n-gram absent from the sample
+	words   map[string]bool // the words of the sample
+}
+
+var profiles = sync.OnceValue(func() []profile {
+	counts := make(map[string]map[string]int)
+	sampleWords := make(map[string]map[string]bool)
+	vocabulary := make(map[string]bool)
+	for _, code := range Languages() {
+		sample, err := samples.ReadFile("profiles/" + code + ".txt")
+		if err != nil {
+			panic("langid: missing profile " + code)
+		}
+		counts[code] = make(map[string]int)
+		sampleWords[code] = make(map[string]bool)
+		for _, w := range words(string(sample), 0) {
+			sampleWords[code][w] = true
+			for _, g := range ngrams(w) {
+				counts[code][g]++
+				vocabulary[g] = true
+			}
+		}
+	}
+
+	out := make([]profile, 0, len(counts))
+	for _, code := range Languages() {
+		total := 0
+		for _, n := range counts[code] {
+			total += n
+		}
+		denominator := float64(total) + alpha*float64(len(vocabulary)+1)
+		p := profile{code: code, logProb: make(map[string]float64, len(counts[code])), unseen: math.Log(alpha / denominator), words: sampleWords[code]}
+		for g, n := range counts[code] {
+			p.logProb[g] = math.Log((float64(n) + alpha) / denominator)
+		}
+		out = append(out, p)
+	}
+	return out
+})
+
+// Detect identifies the language of text among Languages.
+func Detect(text string) Result {
+	ws := words(text, maxWords)
+	letters := 0
+	for _, w := range ws {
+		letters += len([]rune(w))
+	}
+	if letters < MinLetters {
+		return Result{}
+	}
+
+	ps := profiles()
+	scores := make([]float64, len(ps))
+	known := 0
+	for _, w := range ws {
+		for _, g := range ngrams(w) {
+			seen := false
+			for _, p := range ps {
+				_, ok := p.logProb[g]
+				seen = seen || ok
+			}
+			if !seen {
+				continue // no evidence for any language
+			}
+			known++
+			for i, p := range ps {
+				if lp, ok := p.logProb[g]; ok {
+					scores[i] += lp
+				} else {
+					scores[i] += p.unseen
+				}
+			}
+		}
+	}
+	if known == 0 {
+		return Result{}
+	}
+
+	// Posterior probabilities, with the evidence capped.
+	scale := min(1, evidence/float64(known))
+	best := 0
+	for i := range scores {
+		scores[i] *= scale
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+	if !fits(ps[best], ws) {
+		return Result{}
+	}
+
+	sum := 0.0
+	for _, s := range scores {
+		sum += math.Exp(s - scores[best])
+	}
+	return Result{Code: ps[best].code, Confidence: math.Round(1/sum*1000) / 1000}
+}
+
+// fits reports whether the words ws read like the sample of p: enough
+// of them are in the sample, and few of their three letter sequences
+// are missing from it.
+func fits(p profile, ws []string) bool {
+	known := 0
+	trigrams, unseen := 0, 0
+	for _, w := range ws {
+		if p.words[w] {
+			known++
+		}
+		for _, g := range ngrams(w) {
+			if utf8.RuneCountInString(g) != maxN {
+				continue
+			}
+			trigrams++
+			if _, ok := p.logProb[g]; !ok {
+				unseen++
+			}
+		}
+	}
+	return float64(known) >= minKnownWords*float64(len(ws)) && float64(unseen) <= maxUnseen*float64(trigrams)
+}
+
+// words returns the lowercased runs of Latin letters of text. A limit
+// above zero keeps at most limit words, sampled evenly.
+func words(text string, limit int) []string {
+	ws := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.Is(unicode.Latin, r)
+	})
+	if limit <= 0 || len(ws) <= limit {
+		return ws
+	}
+	sampled := make([]string, 0, limit)
+	for i := range limit {
+		sampled = append(sampled, ws[i*len(ws)/limit])
+	}
+	return sampled
+}
+
+// ngrams returns the sequences of one to maxN characters of a word
+// padded with spaces, so n-grams at its edges are told apart: " th",
+// "he ". The single spaces are left out.
+func ngrams(word string) []string {
+	rs := []rune(" " + word + " ")
+	var out []string
+	for n := 1; n <= maxN; n++ {
+		for i := 0; i+n <= len(rs); i++ {
+			if n == 1 && rs[i] == ' ' {
+				continue
+			}
+			out = append(out, string(rs[i:i+n]))
+		}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package langid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"The invoice must be paid within thirty days of delivery, otherwise interest will be charged.", English},
+		{"A fatura deve ser paga em até trinta dias após a entrega; caso contrário, serão cobrados juros.", Portuguese},
+		{"La factura debe pagarse en un plazo de treinta días desde la entrega; de lo contrario, se cobrarán intereses.", Spanish},
+		{"Os funcionários não podem trabalhar mais de quarenta horas por semana.", Portuguese},
+		{"Los empleados no pueden trabajar más de cuarenta horas por semana.", Spanish},
+		{"Employees may not work more than forty hours a week.", English},
+		{"O governo anunciou novas medidas para a educação", Portuguese},
+		{"El gobierno anunció nuevas medidas para la educación", Spanish},
+		{"Page 3", ""},
+		{"第一章 总则 本合同由双方签订，自签字之日起生效。", ""},
+		// Languages without a profile.
+		{"Sehr geehrte Damen und Herren, vielen Dank für Ihre Bestellung. Wir werden die Ware so schnell wie möglich versenden.", ""},
+		{"Die Mitarbeiter dürfen nicht mehr als vierzig Stunden pro Woche arbeiten.", ""},
+		{"Le conseil municipal s'est réuni mardi soir pour discuter du nouveau budget des écoles, des routes et de la santé publique.", ""},
+		{"Les employés ne peuvent pas travailler plus de quarante heures par semaine.", ""},
+		{"De werknemers mogen niet meer dan veertig uur per week werken.", ""},
+		// Jargon still reads as the language of its function words.
+		{"Deploy the service with Helm and configure the ingress controller to terminate TLS.", English},
+		{"Implante o serviço com Helm e configure o controlador de entrada para encerrar o TLS.", Portuguese},
+		{"Despliegue el servicio con Helm y configure el controlador de entrada para terminar TLS.", Spanish},
+	}
+	for _, tc := range tests {
+		got := Detect(tc.text)
+		if got.Code != tc.want {
+			t.Errorf("Detect(%q) = %+v; want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestDetect_Confidence(t *testing.T) {
+	clear := Detect("Los empleados no pueden trabajar más de cuarenta horas por semana.")
+	jargon := Detect("Quarterly EBITDA increased, reflecting lower procurement costs.")
+	names := Detect("Maria Silva Santos Rodrigues Pereira")
+	if clear.Confidence < 0.9 || clear.Confidence > 1 {
+		t.Errorf("confidence of a full sentence = %g; want at least 0.9", clear.Confidence)
+	}
+	if jargon.Code != English || jargon.Confidence >= clear.Confidence {
+		t.Errorf("jargon (%+v) must be less certain than a sentence (%g)", jargon, clear.Confidence)
+	}
+	if names != (Result{}) {
+		t.Errorf("a list of names has no language, got %+v", names)
+	}
+}
+
+func TestWords_SamplesLongTexts(t *testing.T) {
+	text := "one two three four five six seven eight"
+	if got := words(text, 4); strings.Join(got, " ") != "one three five seven" {
+		t.Errorf("words = %q", got)
+	}
+	if got := words("São Paulo, 2024: ação!", 0); strings.Join(got, " ") != "são paulo ação" {
+		t.Errorf("words = %q", got)
+	}
+}
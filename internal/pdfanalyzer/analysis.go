@@ -2,6 +2,35 @@ package pdfanalyzer
 
 // AnalysisResult represents the outcome of analyzing a PDF file.
 type AnalysisResult struct {
-	Content   string // raw extracted text (Phase 2: still basic)
-	WordCount int    // naive word count
+	Content        string         // raw extracted text (Phase 2: still basic)
+	WordCount      int            // UAX #29 word count, see package tokenizer
+	TokenCount     int            // words plus punctuation tokens
+	SentenceCount  int            // sentences containing at least one word
+	ParagraphCount int            // paragraphs containing at least one word
+	Pages          []PageAnalysis // per-page breakdown, in document order
+	Metadata       Metadata       // document information and XMP metadata
+	Encryption     Encryption     // security handler, zero when not encrypted
+	Outline        Outline        // bookmarks, or headings when there are none
+	Annotations    []Annotation   // links, comments and markup, in page order
+	Images         []Image        // image XObjects, in page order
+	Fonts          []Font         // fonts in the page resources, by first use
+	FormFields     []FormField    // AcroForm fields, in field-tree order
+	Language       Language       // language of Content
+}
+
+// PageAnalysis represents the text and geometry of a single page.
+type PageAnalysis struct {
+	Number    int     // 1-based page number
+	Text      string  // text extracted from this page only
+	WordCount int     // word count of Text
+	CharCount int     // non-whitespace characters in Text
+	Width     float64 // MediaBox width, in points
+	Height    float64 // MediaBox height, in points
+	Rotation  int     // clockwise rotation in degrees: 0, 90, 180 or 270
+
+	// ImageCoverage is the fraction of the page covered by images,
+	// from 0 to 1.
+	ImageCoverage float64
+
+	Language Language // language of Text
 }
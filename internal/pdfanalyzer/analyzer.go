@@ -1,43 +1,160 @@
 package pdfanalyzer
 
 import (
-	"bytes"
-	"io"
+	"context"
+	"os"
 
 	"github.com/ledongthuc/pdf"
 )
 
 // PDFAnalyzer processes PDF files and extracts text and metadata.
-type PDFAnalyzer struct{}
+type PDFAnalyzer struct {
+	maxPages       int
+	maxStreamBytes int64
+}
 
 // Constructor
-func NewPDFAnalyzer() *PDFAnalyzer {
-	return &PDFAnalyzer{}
+func NewPDFAnalyzer(opts ...Option) *PDFAnalyzer {
+	a := &PDFAnalyzer{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // AnalyzeFile extracts text from the PDF at the given path and returns an AnalysisResult.
-func (a *PDFAnalyzer) AnalyzeFile(filePath string) (AnalysisResult, error) {
-	file, content, err := pdf.Open(filePath)
+// The context is checked between pages; once it is done, ctx.Err() is returned.
+// Library failures, including panics, are reported as ErrEncrypted, ErrCorrupt or
+// ErrUnsupported; encrypted documents fail with ErrPasswordRequired or
+// ErrIncorrectPassword unless they open with an empty user password.
+func (a *PDFAnalyzer) AnalyzeFile(ctx context.Context, filePath string, opts ...FileOption) (result AnalysisResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = AnalysisResult{}, recoverError(r)
+		}
+	}()
+
+	var o fileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	file, err := os.Open(filePath)
 	if err != nil {
 		return AnalysisResult{}, err
 	}
 	defer file.Close()
 
-	var buf bytes.Buffer
-	reader, err := content.GetPlainText()
+	content, err := openReader(file, o.password)
 	if err != nil {
 		return AnalysisResult{}, err
 	}
 
-	if _, err := io.Copy(&buf, reader); err != nil {
+	header := make([]byte, 1024)
+	n, _ := file.ReadAt(header, 0)
+	metadata := readMetadata(content, header[:n])
+
+	numPages := content.NumPage()
+	if err := a.checkPageCount(numPages); err != nil {
+		return AnalysisResult{}, err
+	}
+
+	pages := make([]PageAnalysis, 0, numPages)
+	fonts := make(map[string]*pdf.Font)
+
+	// Bookmark destinations point to page objects; headings are only
+	// derived when the document has no bookmarks.
+	pageIDs := make(map[objectID]int, numPages)
+	deriveHeadings := !hasBookmarks(content)
+	var lines []textLine
+	var annotations annotationCollector
+	var images []Image
+	var usedFonts fontCollector
+	layout := o.layout == LayoutReading || o.layout == LayoutPhysical
+
+	texts := make([]string, 0, numPages)
+	for i := 1; i <= numPages; i++ {
+		if err := ctx.Err(); err != nil {
+			return AnalysisResult{}, err
+		}
+
+		p := content.Page(i)
+		if err := a.checkStreamSizes(p, i); err != nil {
+			return AnalysisResult{}, err
+		}
+
+		page, err := analyzePage(p, i, fonts)
+		if err != nil {
+			return AnalysisResult{}, classifyError(err)
+		}
+
+		placed, coverage := pageImages(p, i, maxImages-len(images))
+		for _, img := range placed {
+			images = append(images, img.Image)
+		}
+		page.ImageCoverage = coverage
+		usedFonts.collect(p, i)
+
+		// The positioned glyphs are only needed for headings,
+		// highlighted text and the layout modes; they cost a second
+		// pass over the page.
+		annotated, markup := hasAnnotations(p)
+		var glyphs []pdf.Text
+		if deriveHeadings || markup || layout {
+			glyphs = pageGlyphs(p)
+		}
+		if text := layoutText(glyphs, o.layout); text != "" {
+			page.Text = text
+			page.WordCount = countWords(text)
+			page.CharCount = countChars(text)
+		}
+
+		page.Language = detectLanguage(page.Text)
+
+		texts = append(texts, page.Text)
+		pages = append(pages, page)
+
+		if id, ok := objectIDOf(p.V); ok {
+			pageIDs[id] = i
+		}
+
+		if deriveHeadings {
+			lines = append(lines, pageLines(glyphs, i)...)
+		}
+		if annotated {
+			annotations.collect(p, i, glyphs)
+		}
+	}
+
+	dests := destinations{root: content.Trailer().Key("Root"), pages: pageIDs}
+	var outline Outline
+	if deriveHeadings {
+		outline = headingOutline(lines, numPages)
+	} else {
+		outline = readBookmarks(content, dests)
+	}
+
+	formFields, err := readFormFields(ctx, content, numPages)
+	if err != nil {
 		return AnalysisResult{}, err
 	}
 
-	text := buf.String()
-	wordCount := countWords(text)
+	stats := CountContent(texts)
 
 	return AnalysisResult{
-		Content:   text,
-		WordCount: wordCount,
+		Content:        stats.Content,
+		WordCount:      stats.WordCount,
+		TokenCount:     stats.TokenCount,
+		SentenceCount:  stats.SentenceCount,
+		ParagraphCount: stats.ParagraphCount,
+		Pages:          pages,
+		Metadata:       metadata,
+		Encryption:     readEncryption(content),
+		Outline:        outline,
+		Annotations:    annotations.annotations(dests),
+		Images:         images,
+		Fonts:          usedFonts.items,
+		FormFields:     formFields,
+		Language:       stats.Language,
 	}, nil
 }
@@ -1,6 +1,8 @@
 package pdfanalyzer
 
 import (
+	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 )
@@ -10,7 +12,7 @@ func TestAnalyzeFile_SimplePDF(t *testing.T) {
 
 	analyzer := NewPDFAnalyzer()
 
-	result, err := analyzer.AnalyzeFile(pdfPath)
+	result, err := analyzer.AnalyzeFile(context.Background(), pdfPath)
 	if err != nil {
 		t.Fatalf("AnalyzeFile returned error: %v", err)
 	}
@@ -23,3 +25,52 @@ func TestAnalyzeFile_SimplePDF(t *testing.T) {
 		t.Errorf("expected content to be non-empty")
 	}
 }
+
+func TestAnalyzeFile_Pages(t *testing.T) {
+	pdfPath := filepath.Join("testdata", "simple.pdf")
+
+	result, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), pdfPath)
+	if err != nil {
+		t.Fatalf("AnalyzeFile returned error: %v", err)
+	}
+
+	if len(result.Pages) != 1 {
+		t.Fatalf("expected 1 page, got %d", len(result.Pages))
+	}
+
+	page := result.Pages[0]
+	if page.Number != 1 {
+		t.Errorf("expected page number 1, got %d", page.Number)
+	}
+	if page.Width != 596 || page.Height != 842 {
+		t.Errorf("expected 596x842 page, got %vx%v", page.Width, page.Height)
+	}
+	if page.Rotation != 0 {
+		t.Errorf("expected rotation 0, got %d", page.Rotation)
+	}
+	if page.Text != result.Content {
+		t.Errorf("expected single page text to match document content")
+	}
+	if page.WordCount != result.WordCount {
+		t.Errorf("expected page word count %d, got %d", result.WordCount, page.WordCount)
+	}
+	if page.CharCount == 0 {
+		t.Errorf("expected > 0 characters on page")
+	}
+}
+
+func TestCountChars(t *testing.T) {
+	if got := countChars(" a b\n\tç "); got != 3 {
+		t.Errorf("countChars = %d; want 3", got)
+	}
+}
+
+func TestAnalyzeFile_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewPDFAnalyzer().AnalyzeFile(ctx, filepath.Join("testdata", "simple.pdf"))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
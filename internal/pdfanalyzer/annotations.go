@@ -0,0 +1,179 @@
+package pdfanalyzer
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Annotation types. Other annotation subtypes (widgets, popups,
+// drawings) are not reported.
+const (
+	AnnotLink      = "link"
+	AnnotNote      = "note" // sticky note (/Text)
+	AnnotFreeText  = "free_text"
+	AnnotHighlight = "highlight"
+	AnnotUnderline = "underline"
+	AnnotStrikeOut = "strike_out"
+	AnnotSquiggly  = "squiggly"
+	AnnotStamp     = "stamp"
+)
+
+// annotationTypes maps PDF annotation subtypes to reported types.
+var annotationTypes = map[string]string{
+	"Link":      AnnotLink,
+	"Text":      AnnotNote,
+	"FreeText":  AnnotFreeText,
+	"Highlight": AnnotHighlight,
+	"Underline": AnnotUnderline,
+	"StrikeOut": AnnotStrikeOut,
+	"Squiggly":  AnnotSquiggly,
+	"Stamp":     AnnotStamp,
+}
+
+// Annotation is a link, comment or markup on a page.
+type Annotation struct {
+	Page     int    // 1-based page the annotation is on
+	Type     string // one of the Annot* constants
+	Rect     Rect
+	Contents string    // comment text
+	Author   string    // /T, the author of a comment
+	Modified time.Time // zero when absent or unparseable
+
+	URI             string // external link target
+	DestinationPage int    // internal link target, 0 when none
+	MarkedText      string // text under a highlight, underline, strike-out or squiggly
+	Stamp           string // stamp name, e.g. "Approved"
+}
+
+// maxAnnotations bounds the annotations reported for a document.
+const maxAnnotations = 10000
+
+// annotationCollector gathers annotations page by page. Link
+// destinations are resolved once every page object is known.
+type annotationCollector struct {
+	items []Annotation
+	dests []pdf.Value // per item, internal link destination
+}
+
+// hasAnnotations reports whether p carries annotations worth reading
+// and whether any of them needs the page text.
+func hasAnnotations(p pdf.Page) (found, needsText bool) {
+	list := p.V.Key("Annots")
+	for i := 0; i < list.Len(); i++ {
+		typ, ok := annotationTypes[list.Index(i).Key("Subtype").Name()]
+		if !ok {
+			continue
+		}
+		found = true
+		if isMarkup(typ) {
+			return true, true
+		}
+	}
+	return found, false
+}
+
+func isMarkup(typ string) bool {
+	switch typ {
+	case AnnotHighlight, AnnotUnderline, AnnotStrikeOut, AnnotSquiggly:
+		return true
+	}
+	return false
+}
+
+// collect reads the annotations of page number. glyphs is the page
+// text, used to recover the text under markup annotations.
+func (c *annotationCollector) collect(p pdf.Page, number int, glyphs []pdf.Text) {
+	list := p.V.Key("Annots")
+	for i := 0; i < list.Len() && len(c.items) < maxAnnotations; i++ {
+		v := list.Index(i)
+		typ, ok := annotationTypes[v.Key("Subtype").Name()]
+		if !ok {
+			continue
+		}
+
+		a := Annotation{
+			Page:     number,
+			Type:     typ,
+			Rect:     readRect(v.Key("Rect")),
+			Contents: strings.TrimSpace(v.Key("Contents").Text()),
+			Author:   v.Key("T").Text(),
+			Modified: parsePDFDate(v.Key("M").Text()),
+		}
+		var dest pdf.Value
+
+		switch typ {
+		case AnnotLink:
+			a.Author = "" // /T is not defined for links
+			if action := v.Key("A"); action.Key("S").Name() == "URI" {
+				a.URI = action.Key("URI").RawString()
+			}
+			dest = destinationOf(v)
+		case AnnotStamp:
+			a.Stamp = v.Key("Name").Name()
+		case AnnotHighlight, AnnotUnderline, AnnotStrikeOut, AnnotSquiggly:
+			a.MarkedText = markedText(glyphs, markedAreas(v))
+		}
+
+		c.items = append(c.items, a)
+		c.dests = append(c.dests, dest)
+	}
+}
+
+// annotations returns the collected annotations with their link
+// destinations resolved.
+func (c *annotationCollector) annotations(d destinations) []Annotation {
+	for i, dest := range c.dests {
+		if !dest.IsNull() {
+			c.items[i].DestinationPage = d.page(dest)
+		}
+	}
+	return c.items
+}
+
+// markedAreas returns the areas covered by a markup annotation: the
+// bounding boxes of its /QuadPoints or, without them, its /Rect.
+func markedAreas(v pdf.Value) []Rect {
+	quads := v.Key("QuadPoints")
+	var areas []Rect
+	for i := 0; i+8 <= quads.Len(); i += 8 {
+		r := Rect{X1: math.Inf(1), Y1: math.Inf(1), X2: math.Inf(-1), Y2: math.Inf(-1)}
+		for j := 0; j < 8; j += 2 {
+			x, y := quads.Index(i+j).Float64(), quads.Index(i+j+1).Float64()
+			r.X1, r.X2 = math.Min(r.X1, x), math.Max(r.X2, x)
+			r.Y1, r.Y2 = math.Min(r.Y1, y), math.Max(r.Y2, y)
+		}
+		areas = append(areas, r)
+	}
+	if len(areas) == 0 {
+		areas = append(areas, readRect(v.Key("Rect")))
+	}
+	return areas
+}
+
+// markedText returns the glyphs whose center lies in one of areas, in
+// content order. Each area usually covers one line; a space separates
+// text from different areas.
+func markedText(glyphs []pdf.Text, areas []Rect) string {
+	var sb strings.Builder
+	last := -1
+	for _, g := range glyphs {
+		// The glyph box spans roughly from the baseline to the cap
+		// height; its middle is a robust point to test.
+		x := g.X + g.W/2
+		y := g.Y + g.FontSize*0.35
+		for i, r := range areas {
+			if x >= r.X1 && x <= r.X2 && y >= r.Y1 && y <= r.Y2 {
+				if last >= 0 && i != last {
+					sb.WriteByte(' ')
+				}
+				sb.WriteString(g.S)
+				last = i
+				break
+			}
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -0,0 +1,70 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestAnalyzeFile_Annotations(t *testing.T) {
+	// Objects 1-5 are the catalog, page tree, font and page; the
+	// annotations start at 6.
+	b := newTextPDF("", "/Annots [6 0 R 7 0 R 8 0 R 9 0 R 10 0 R 11 0 R]",
+		"BT /F1 12 Tf 72 720 Td (Please) Tj ET\n"+
+			"BT /F1 12 Tf 200 720 Td (review this) Tj ET\n"+
+			"BT /F1 12 Tf 72 700 Td (clause carefully) Tj ET")
+	b.add("<< /Type /Annot /Subtype /Link /Rect [72 600 144 612] /A << /S /URI /URI (https://example.com/terms) >> >>")
+	b.add(fmt.Sprintf("<< /Type /Annot /Subtype /Link /Rect [72 580 144 592] /Dest [%d 0 R /Fit] >>", pageObject(1)))
+	b.add("<< /Type /Annot /Subtype /Text /Rect [500 700 520 720] /Contents ( Check the dates. ) /T (Ana) /M (D:20240102030405Z) >>")
+	b.add("<< /Type /Annot /Subtype /Highlight /Rect [190 718 260 732] /QuadPoints [190 732 260 732 190 718 260 718] /T (Ana) /Contents (Why?) >>")
+	b.add("<< /Type /Annot /Subtype /Stamp /Rect [400 100 500 150] /Name /Approved /T (Bruno) >>")
+	b.add("<< /Type /Annot /Subtype /Popup /Rect [0 0 1 1] >>")
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Annotation{
+		{Page: 1, Type: AnnotLink, Rect: Rect{72, 600, 144, 612}, URI: "https://example.com/terms"},
+		{Page: 1, Type: AnnotLink, Rect: Rect{72, 580, 144, 592}, DestinationPage: 1},
+		{Page: 1, Type: AnnotNote, Rect: Rect{500, 700, 520, 720}, Contents: "Check the dates.", Author: "Ana",
+			Modified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Page: 1, Type: AnnotHighlight, Rect: Rect{190, 718, 260, 732}, Contents: "Why?", Author: "Ana", MarkedText: "review this"},
+		{Page: 1, Type: AnnotStamp, Rect: Rect{400, 100, 500, 150}, Author: "Bruno", Stamp: "Approved"},
+	}
+	if len(res.Annotations) != len(want) {
+		t.Fatalf("expected %d annotations, got %d: %+v", len(want), len(res.Annotations), res.Annotations)
+	}
+	for i := range want {
+		got := res.Annotations[i]
+		if !got.Modified.Equal(want[i].Modified) {
+			t.Errorf("annotation %d: modified %v, want %v", i, got.Modified, want[i].Modified)
+		}
+		got.Modified = want[i].Modified
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("annotation %d:\n got %+v\nwant %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestMarkedText_SeveralLines(t *testing.T) {
+	b := newTextPDF("", "", textContent("first line", "second line", "third line"))
+	path := b.write(t)
+
+	// textContent starts at y=720 with a 14pt leading.
+	areas := []Rect{{X1: 70, Y1: 700, X2: 300, Y2: 712}, {X1: 70, Y1: 686, X2: 300, Y2: 698}}
+
+	f, r, err := pdf.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	if got := markedText(pageGlyphs(r.Page(1)), areas); got != "second line third line" {
+		t.Errorf("markedText = %q", got)
+	}
+}
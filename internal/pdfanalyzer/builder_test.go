@@ -0,0 +1,115 @@
+package pdfanalyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testPDF assembles small, uncompressed PDF files for tests that need
+// structures the fixture in testdata does not have (bookmarks,
+// annotations, forms, images, ...). Objects are numbered from 1 in
+// the order they are added.
+type testPDF struct {
+	objects []string
+	trailer string // extra trailer entries, e.g. "/Info 3 0 R"
+}
+
+// add appends an object body and returns its object number.
+func (b *testPDF) add(body string) int {
+	b.objects = append(b.objects, body)
+	return len(b.objects)
+}
+
+// set replaces the body of an already reserved object.
+func (b *testPDF) set(num int, body string) {
+	b.objects[num-1] = body
+}
+
+// stream formats a stream object with the given dictionary entries.
+func stream(dict, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+// bytes renders the document with a valid cross-reference table.
+// The catalog must be object 1.
+func (b *testPDF) bytes() []byte {
+	var sb strings.Builder
+	sb.WriteString("%PDF-1.7\n")
+
+	offsets := make([]int, len(b.objects))
+	for i, body := range b.objects {
+		offsets[i] = sb.Len()
+		fmt.Fprintf(&sb, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+
+	xref := sb.Len()
+	fmt.Fprintf(&sb, "xref\n0 %d\n0000000000 65535 f \n", len(b.objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&sb, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&sb, "trailer\n<< /Size %d /Root 1 0 R %s >>\nstartxref\n%d\n%%%%EOF\n",
+		len(b.objects)+1, b.trailer, xref)
+
+	return []byte(sb.String())
+}
+
+// write stores the document in a temporary directory and returns its path.
+func (b *testPDF) write(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.pdf")
+	if err := os.WriteFile(path, b.bytes(), 0o600); err != nil {
+		t.Fatalf("write test pdf: %v", err)
+	}
+	return path
+}
+
+// newTextPDF returns a builder holding a catalog, a page tree and one
+// page per entry in contents, using Helvetica as font /F1 and, inline
+// in the resources, Helvetica-Bold as /F2. Object
+// numbers: 1 catalog, 2 pages, 3 font, then each page followed by its
+// content stream. extraCatalog and extraPage are spliced into the
+// catalog and every page dictionary.
+func newTextPDF(extraCatalog, extraPage string, contents ...string) *testPDF {
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R " + extraCatalog + " >>")
+	b.add("") // page tree, filled once the kids are known
+	b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	var kids []string
+	for _, content := range contents {
+		page := b.add("")
+		body := b.add(stream("", content))
+		b.set(page, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R /F2 %s >> >> /Contents %d 0 R %s >>",
+			boldFont, body, extraPage))
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+	b.set(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	return b
+}
+
+// boldFont is the inline dictionary of font /F2.
+const boldFont = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"
+
+// pageObject returns the object number of the n-th (1-based) page
+// created by newTextPDF.
+func pageObject(n int) int {
+	return 4 + (n-1)*2
+}
+
+// textContent returns a content stream that shows each line with
+// Helvetica 12pt, one line below the other.
+func textContent(lines ...string) string {
+	var sb strings.Builder
+	sb.WriteString("BT /F1 12 Tf 72 720 Td 14 TL\n")
+	for _, line := range lines {
+		fmt.Fprintf(&sb, "(%s) Tj T*\n", line)
+	}
+	sb.WriteString("ET")
+	return sb.String()
+}
@@ -0,0 +1,238 @@
+package pdfanalyzer
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rc4"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Encryption describes the security handler of an encrypted document.
+// It is the zero value for documents that are not encrypted.
+type Encryption struct {
+	Encrypted   bool
+	Filter      string // security handler, e.g. "Standard"
+	Version     int    // V: algorithm version
+	Revision    int    // R: standard security handler revision
+	KeyLength   int    // key length in bits
+	Algorithm   string // "RC4" or "AESV2"
+	Permissions Permissions
+}
+
+// Permissions are the user access flags of the standard security
+// handler (PDF 32000-1:2008, table 22). They are advisory: the
+// analyzer reads permission-restricted documents regardless.
+type Permissions struct {
+	Print            bool // bit 3
+	Modify           bool // bit 4
+	Copy             bool // bit 5: copy or extract text and graphics
+	Annotate         bool // bit 6
+	FillForms        bool // bit 9
+	ExtractForAccess bool // bit 10: extraction for accessibility
+	Assemble         bool // bit 11
+	PrintHighQuality bool // bit 12
+}
+
+// openReader opens f with the PDF library. Documents with an empty
+// user password (owner-locked files) open without a password. For
+// the others, password is tried as the user password and then as the
+// owner password, from which the user password is recovered.
+func openReader(f *os.File, password string) (*pdf.Reader, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+
+	r, err := pdf.NewReaderEncrypted(f, size, passwords(password))
+	if !errors.Is(err, pdf.ErrInvalidPassword) {
+		return r, classifyError(err)
+	}
+	if password == "" {
+		return nil, ErrPasswordRequired
+	}
+
+	if user, ok := userPasswordFromOwner(f, size, password); ok {
+		if r, err := pdf.NewReaderEncrypted(f, size, passwords(user)); err == nil {
+			return r, nil
+		}
+	}
+	return nil, ErrIncorrectPassword
+}
+
+// passwords returns a password callback for pdf.NewReaderEncrypted
+// that offers pw once.
+func passwords(pw string) func() string {
+	return func() string {
+		next := pw
+		pw = ""
+		return next
+	}
+}
+
+// readEncryption reports the security handler of an opened document.
+func readEncryption(r *pdf.Reader) Encryption {
+	enc := r.Trailer().Key("Encrypt")
+	if enc.Kind() != pdf.Dict {
+		return Encryption{}
+	}
+
+	e := Encryption{
+		Encrypted: true,
+		Filter:    enc.Key("Filter").Name(),
+		Version:   int(enc.Key("V").Int64()),
+		Revision:  int(enc.Key("R").Int64()),
+		KeyLength: int(enc.Key("Length").Int64()),
+		Algorithm: "RC4",
+	}
+	if e.KeyLength == 0 {
+		e.KeyLength = 40
+	}
+	if e.Version == 4 && enc.Key("CF").Key(enc.Key("StmF").Name()).Key("CFM").Name() == "AESV2" {
+		e.Algorithm = "AESV2"
+	}
+	e.Permissions = parsePermissions(uint32(enc.Key("P").Int64()), e.Revision)
+
+	return e
+}
+
+// parsePermissions decodes the P entry. Revision 2 has no bits 9-12;
+// their operations follow the corresponding older bits.
+func parsePermissions(p uint32, revision int) Permissions {
+	bit := func(n uint) bool { return p&(1<<(n-1)) != 0 }
+
+	perms := Permissions{
+		Print:    bit(3),
+		Modify:   bit(4),
+		Copy:     bit(5),
+		Annotate: bit(6),
+	}
+	if revision < 3 {
+		perms.FillForms = perms.Annotate
+		perms.ExtractForAccess = perms.Copy
+		perms.Assemble = perms.Modify
+		perms.PrintHighQuality = perms.Print
+		return perms
+	}
+	perms.FillForms = bit(9)
+	perms.ExtractForAccess = bit(10)
+	perms.Assemble = bit(11)
+	perms.PrintHighQuality = bit(12)
+	return perms
+}
+
+// passwordPad pads passwords to 32 bytes (PDF 32000-1:2008, 7.6.3.3).
+var passwordPad = []byte{
+	0x28, 0xBF, 0x4E, 0x5E, 0x4E, 0x75, 0x8A, 0x41, 0x64, 0x00, 0x4E, 0x56, 0xFF, 0xFA, 0x01, 0x08,
+	0x2E, 0x2E, 0x00, 0xB6, 0xD0, 0x68, 0x3E, 0x80, 0x2F, 0x0C, 0xA9, 0xFE, 0x64, 0x53, 0x69, 0x7A,
+}
+
+// userPasswordFromOwner recovers the padded user password from the
+// owner password (algorithm 7 of PDF 32000-1:2008), which the PDF
+// library does not implement. It returns false when the document
+// uses a handler other than the standard RC4/AES-128 one.
+func userPasswordFromOwner(f io.ReaderAt, size int64, owner string) (string, bool) {
+	r, err := pdf.NewReader(unencryptedView{f}, size)
+	if err != nil {
+		return "", false
+	}
+	enc := r.Trailer().Key(maskedEncrypt)
+	if enc.Key("Filter").Name() != "Standard" {
+		return "", false
+	}
+
+	revision := enc.Key("R").Int64()
+	n := enc.Key("Length").Int64()
+	if n == 0 {
+		n = 40
+	}
+	o := []byte(enc.Key("O").RawString())
+	if revision < 2 || revision > 4 || n%8 != 0 || n < 40 || n > 128 || len(o) != 32 {
+		return "", false
+	}
+
+	// Steps a-d of algorithm 3: hash the padded owner password.
+	h := md5.New()
+	h.Write(padPassword(owner))
+	key := h.Sum(nil)
+	keyLen := int(n / 8)
+	if revision >= 3 {
+		for i := 0; i < 50; i++ {
+			sum := md5.Sum(key[:keyLen])
+			key = sum[:]
+		}
+	} else {
+		keyLen = 5
+	}
+	key = key[:keyLen]
+
+	user := make([]byte, len(o))
+	copy(user, o)
+	if revision == 2 {
+		c, _ := rc4.NewCipher(key)
+		c.XORKeyStream(user, user)
+		return string(user), true
+	}
+	for i := 19; i >= 0; i-- {
+		k := make([]byte, len(key))
+		for j := range key {
+			k[j] = key[j] ^ byte(i)
+		}
+		c, _ := rc4.NewCipher(k)
+		c.XORKeyStream(user, user)
+	}
+	return string(user), true
+}
+
+// padPassword truncates or pads pw to 32 bytes.
+func padPassword(pw string) []byte {
+	out := make([]byte, 0, 32)
+	out = append(out, pw...)
+	if len(out) >= 32 {
+		return out[:32]
+	}
+	return append(out, passwordPad[:32-len(out)]...)
+}
+
+// maskedEncrypt replaces the /Encrypt key in unencryptedView. It has
+// the same length, so offsets in the cross-reference table hold.
+const maskedEncrypt = "EncrypX"
+
+// unencryptedView hides the /Encrypt trailer entry, so the PDF
+// library opens an encrypted file without decrypting it. Strings are
+// then returned raw, which is what owner password recovery needs:
+// the Encrypt dictionary itself is never encrypted, but the library
+// would otherwise run it through the decryption of its object.
+type unencryptedView struct {
+	r io.ReaderAt
+}
+
+func (v unencryptedView) ReadAt(p []byte, off int64) (int, error) {
+	// Read a little around p so a key split across calls is masked too.
+	overlap := int64(len("/Encrypt") - 1)
+	start := off - overlap
+	if start < 0 {
+		start = 0
+	}
+	buf := make([]byte, off-start+int64(len(p))+overlap)
+	n, err := v.r.ReadAt(buf, start)
+	buf = buf[:n]
+	masked := bytes.ReplaceAll(buf, []byte("/Encrypt"), []byte("/"+maskedEncrypt))
+
+	lead := int(off - start)
+	if lead >= len(masked) {
+		return 0, io.EOF
+	}
+	copied := copy(p, masked[lead:])
+	if copied < len(p) {
+		if err == nil {
+			err = io.EOF
+		}
+		return copied, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,171 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rc4"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// rc4Encrypt applies RC4 with key to data, returning a new slice.
+func rc4Encrypt(key, data []byte) []byte {
+	out := make([]byte, len(data))
+	c, _ := rc4.NewCipher(key)
+	c.XORKeyStream(out, data)
+	return out
+}
+
+// rc4Rounds runs the 19 extra RC4 passes of revision 3 (key XOR i).
+func rc4Rounds(key, data []byte) []byte {
+	for i := 1; i <= 19; i++ {
+		k := make([]byte, len(key))
+		for j := range key {
+			k[j] = key[j] ^ byte(i)
+		}
+		data = rc4Encrypt(k, data)
+	}
+	return data
+}
+
+// newEncryptedPDF builds a one-page document protected by the
+// standard security handler, revision 3, 128-bit RC4, following
+// algorithms 2, 3 and 5 of PDF 32000-1:2008.
+func newEncryptedPDF(t *testing.T, user, owner string, perms int32, text string) string {
+	t.Helper()
+
+	const keyLen = 16
+	id := []byte("0123456789abcdef")
+
+	// Algorithm 3: owner entry.
+	ownerKey := md5.Sum(padPassword(owner))
+	for i := 0; i < 50; i++ {
+		ownerKey = md5.Sum(ownerKey[:keyLen])
+	}
+	o := rc4Rounds(ownerKey[:keyLen], rc4Encrypt(ownerKey[:keyLen], padPassword(user)))
+
+	// Algorithm 2: file key.
+	p := uint32(perms)
+	h := md5.New()
+	h.Write(padPassword(user))
+	h.Write(o)
+	h.Write([]byte{byte(p), byte(p >> 8), byte(p >> 16), byte(p >> 24)})
+	h.Write(id)
+	key := h.Sum(nil)
+	for i := 0; i < 50; i++ {
+		sum := md5.Sum(key[:keyLen])
+		key = sum[:]
+	}
+	key = key[:keyLen]
+
+	// Algorithm 5: user entry.
+	h = md5.New()
+	h.Write(passwordPad)
+	h.Write(id)
+	u := rc4Rounds(key, rc4Encrypt(key, h.Sum(nil)))
+	u = append(u, make([]byte, 16)...)
+
+	b := newTextPDF("", "", textContent(text))
+
+	// Algorithm 1: the content stream (object 5) is encrypted with a
+	// key derived from its object number.
+	objKey := md5.Sum(append(append([]byte{}, key...), 5, 0, 0, 0, 0))
+	b.set(5, stream("", string(rc4Encrypt(objKey[:], []byte(textContent(text))))))
+
+	enc := b.add(fmt.Sprintf("<< /Filter /Standard /V 2 /R 3 /Length 128 /P %d /O <%s> /U <%s> >>",
+		perms, hex.EncodeToString(o), hex.EncodeToString(u)))
+	b.trailer = fmt.Sprintf("/Encrypt %d 0 R /ID [<%s> <%s>]", enc, hex.EncodeToString(id), hex.EncodeToString(id))
+
+	return b.write(t)
+}
+
+func TestAnalyzeFile_Encrypted(t *testing.T) {
+	// Print and copy allowed, modify denied.
+	const perms = -4 &^ (1 << 3)
+	path := newEncryptedPDF(t, "user-secret", "owner-secret", perms, "confidential terms")
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{"no password", "", ErrPasswordRequired},
+		{"wrong password", "guess", ErrIncorrectPassword},
+		{"user password", "user-secret", nil},
+		{"owner password", "owner-secret", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path, WithPassword(tc.password))
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) || !errors.Is(err, ErrEncrypted) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(res.Content, "confidential terms") {
+				t.Errorf("expected decrypted text, got %q", res.Content)
+			}
+
+			want := Encryption{
+				Encrypted: true,
+				Filter:    "Standard",
+				Version:   2,
+				Revision:  3,
+				KeyLength: 128,
+				Algorithm: "RC4",
+				Permissions: Permissions{
+					Print: true, Copy: true, Annotate: true, FillForms: true,
+					ExtractForAccess: true, Assemble: true, PrintHighQuality: true,
+				},
+			}
+			if res.Encryption != want {
+				t.Errorf("got %+v; want %+v", res.Encryption, want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFile_OwnerLocked(t *testing.T) {
+	// Empty user password: readable by anyone, copying denied.
+	path := newEncryptedPDF(t, "", "owner-secret", -4&^(1<<4), "locked but readable")
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(res.Content, "locked but readable") {
+		t.Errorf("unexpected text %q", res.Content)
+	}
+	if !res.Encryption.Encrypted || res.Encryption.Permissions.Copy || !res.Encryption.Permissions.Print {
+		t.Errorf("unexpected encryption info %+v", res.Encryption)
+	}
+}
+
+func TestAnalyzeFile_NotEncrypted(t *testing.T) {
+	path := newTextPDF("", "", textContent("plain")).write(t)
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path, WithPassword("ignored"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Encryption != (Encryption{}) {
+		t.Errorf("expected zero Encryption, got %+v", res.Encryption)
+	}
+}
+
+func TestParsePermissions_Revision2(t *testing.T) {
+	// Bits 9-12 do not exist in revision 2 and follow bits 3-6.
+	got := parsePermissions(uint32(0xFFFFFFC0|1<<2|1<<4), 2)
+	want := Permissions{Print: true, Copy: true, ExtractForAccess: true, PrintHighQuality: true}
+	if got != want {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
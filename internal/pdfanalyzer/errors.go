@@ -0,0 +1,65 @@
+package pdfanalyzer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Failures of the underlying PDF library, classified so callers can
+// tell a bad file from a bug.
+var (
+	ErrEncrypted   = errors.New("cannot decrypt file")
+	ErrCorrupt     = errors.New("cannot parse file")
+	ErrUnsupported = errors.New("unsupported feature")
+
+	// Encrypted documents that cannot be opened without a password.
+	ErrPasswordRequired  = fmt.Errorf("%w: password required", ErrEncrypted)
+	ErrIncorrectPassword = fmt.Errorf("%w: incorrect password", ErrEncrypted)
+)
+
+// classifyError wraps an error returned by the PDF library into one
+// of the errors above. The library only exports ErrInvalidPassword;
+// everything else is a formatted string, so the remaining cases are
+// recognised by their message. Unknown errors are returned as is.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	switch {
+	case errors.Is(err, pdf.ErrInvalidPassword):
+		return fmt.Errorf("%w: %v", ErrEncrypted, err)
+	case strings.HasPrefix(msg, "unsupported"), strings.HasPrefix(msg, "unknown filter"):
+		return fmt.Errorf("%w: %v", ErrUnsupported, err)
+	case strings.HasPrefix(msg, "malformed"),
+		strings.HasPrefix(msg, "not a PDF file"),
+		strings.Contains(msg, "xref"),
+		strings.HasPrefix(msg, "invalid"),
+		strings.HasPrefix(msg, "loading"),
+		strings.HasPrefix(msg, "stream not present"),
+		errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	default:
+		return err
+	}
+}
+
+// recoverError turns a panic raised while reading the document into
+// an error. The library panics instead of returning errors on most
+// structural problems, so a panic means a corrupt file unless the
+// message says otherwise.
+func recoverError(r any) error {
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	if classified := classifyError(err); classified != err {
+		return classified
+	}
+	return fmt.Errorf("%w: %v", ErrCorrupt, err)
+}
@@ -0,0 +1,63 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// encryptedPDF returns a document whose Encrypt dictionary is extra.
+// The O and U entries do not match the empty user password.
+func encryptedPDF(t *testing.T, extra string) string {
+	t.Helper()
+
+	b := newTextPDF("", "", textContent("secret"))
+	enc := b.add(fmt.Sprintf("<< /Filter /Standard /O <%s> /U <%s> /P -4 %s >>",
+		strings.Repeat("00", 32), strings.Repeat("ff", 32), extra))
+	b.trailer = fmt.Sprintf("/Encrypt %d 0 R /ID [<00112233445566778899aabbccddeeff> <00112233445566778899aabbccddeeff>]", enc)
+	return b.write(t)
+}
+
+func TestAnalyzeFile_LibraryFailures(t *testing.T) {
+	truncated := filepath.Join(t.TempDir(), "truncated.pdf")
+	full := newTextPDF("", "", textContent("hello")).bytes()
+	if err := os.WriteFile(truncated, full[:len(full)/2], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	unsupportedFilter := newTextPDF("", "", "")
+	unsupportedFilter.set(5, stream("/Filter /JBIG2Decode", "data"))
+
+	tests := []struct {
+		name string
+		path string
+		want error
+	}{
+		{"password required", encryptedPDF(t, "/V 1 /R 2"), ErrEncrypted},
+		{"unsupported encryption", encryptedPDF(t, "/V 5 /R 6"), ErrUnsupported},
+		{"truncated file", truncated, ErrCorrupt},
+		{"unsupported filter", unsupportedFilter.write(t), ErrUnsupported},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), tc.path)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestRecoverError(t *testing.T) {
+	if err := recoverError("not a stream"); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("expected ErrCorrupt for unknown panic, got %v", err)
+	}
+	if err := recoverError(errors.New("unsupported filter /JPXDecode")); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
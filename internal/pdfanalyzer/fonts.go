@@ -0,0 +1,169 @@
+package pdfanalyzer
+
+import (
+	"regexp"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Font subtypes, as reported in Font.Subtype.
+const (
+	FontType1    = "Type1"
+	FontMMType1  = "MMType1"
+	FontTrueType = "TrueType"
+	FontType0    = "Type0" // composite (CID-keyed) font
+	FontType3    = "Type3" // glyphs drawn by content streams
+)
+
+// Font is a font used by the document. A font shared by several pages
+// is listed once, with every page it is used on; a font written inline
+// in the resources of a page is listed for that page alone.
+type Font struct {
+	Name     string // BaseFont without the subset prefix, e.g. "Helvetica"
+	Subtype  string // one of the Font* constants
+	Embedded bool   // the font program is in the file (always for Type3)
+	Subset   bool   // only the glyphs used are embedded, e.g. "ABCDEF+Arial"
+	// Encoding is the encoding name ("WinAnsiEncoding", "Identity-H"),
+	// the base encoding of a custom encoding, "Custom" for a custom
+	// encoding without a base, or "" for the font's built-in encoding.
+	Encoding  string
+	ToUnicode bool  // a ToUnicode CMap maps glyphs back to text
+	Pages     []int // 1-based, ascending
+}
+
+// maxFonts bounds the number of distinct fonts per document.
+const maxFonts = 10000
+
+// subsetPrefix is the tag of a subset font: six uppercase letters
+// and a plus sign (PDF 32000-1:2008, §9.6.4).
+var subsetPrefix = regexp.MustCompile(`^[A-Z]{6}\+`)
+
+// fontCollector gathers the fonts of the pages it is shown, in order
+// of first use.
+type fontCollector struct {
+	items []Font
+	index map[any]int // font dictionary key to position in items
+}
+
+// collect records the fonts in the resources of page number,
+// including the resources of the form XObjects it contains.
+func (c *fontCollector) collect(p pdf.Page, number int) {
+	if c.index == nil {
+		c.index = make(map[any]int)
+	}
+	fonts := p.Resources().Key("Font")
+	for _, name := range fonts.Keys() {
+		c.add(fonts, name, number)
+	}
+	c.collectForms(p.Resources(), number, 0)
+}
+
+func (c *fontCollector) collectForms(resources pdf.Value, number, depth int) {
+	if depth >= maxFormNested {
+		return
+	}
+	xobjects := resources.Key("XObject")
+	for _, name := range xobjects.Keys() {
+		form := xobjects.Key(name)
+		if form.Key("Subtype").Name() != "Form" {
+			continue
+		}
+		res := form.Key("Resources")
+		fonts := res.Key("Font")
+		for _, fontName := range fonts.Keys() {
+			c.add(fonts, fontName, number)
+		}
+		c.collectForms(res, number, depth+1)
+	}
+}
+
+// add records the font name of the font resource dictionary fonts.
+func (c *fontCollector) add(fonts pdf.Value, name string, page int) {
+	v := fonts.Key(name)
+	if v.Kind() != pdf.Dict {
+		return
+	}
+
+	// A direct font dictionary is read from the object that holds the
+	// resources, so its object cannot tell it apart from its
+	// neighbours or across pages: it is keyed by page and resource
+	// name. Only a font in an object of its own is keyed by object.
+	var key any = [2]any{page, name}
+	if id, ok := objectIDOf(v); ok {
+		if parent, _ := objectIDOf(fonts); id != parent {
+			key = id
+		}
+	}
+
+	i, seen := c.index[key]
+	if !seen {
+		if len(c.items) >= maxFonts {
+			return
+		}
+		i = len(c.items)
+		c.index[key] = i
+		c.items = append(c.items, describeFont(v, name))
+	}
+	if pages := c.items[i].Pages; len(pages) == 0 || pages[len(pages)-1] != page {
+		c.items[i].Pages = append(pages, page)
+	}
+}
+
+// describeFont reads a font dictionary. Type3 fonts without a
+// BaseFont are named after their resource.
+func describeFont(v pdf.Value, resource string) Font {
+	base := v.Key("BaseFont").Name()
+	f := Font{
+		Name:      subsetPrefix.ReplaceAllString(base, ""),
+		Subtype:   v.Key("Subtype").Name(),
+		Subset:    subsetPrefix.MatchString(base),
+		Encoding:  fontEncoding(v.Key("Encoding")),
+		ToUnicode: v.Key("ToUnicode").Kind() == pdf.Stream,
+	}
+	if f.Name == "" {
+		f.Name = resource
+	}
+
+	switch f.Subtype {
+	case FontType3:
+		f.Embedded = true
+	case FontType0:
+		// The font program belongs to the descendant CIDFont.
+		f.Embedded = hasFontFile(v.Key("DescendantFonts").Index(0).Key("FontDescriptor"))
+	default:
+		f.Embedded = hasFontFile(v.Key("FontDescriptor"))
+	}
+	return f
+}
+
+// hasFontFile reports whether a font descriptor carries a font
+// program: Type 1, TrueType, or CFF and OpenType respectively.
+func hasFontFile(descriptor pdf.Value) bool {
+	for _, key := range []string{"FontFile", "FontFile2", "FontFile3"} {
+		if descriptor.Key(key).Kind() == pdf.Stream {
+			return true
+		}
+	}
+	return false
+}
+
+// fontEncoding names the encoding of a font, see Font.Encoding.
+func fontEncoding(v pdf.Value) string {
+	switch v.Kind() {
+	case pdf.Name:
+		return v.Name()
+	case pdf.Dict:
+		if base := v.Key("BaseEncoding").Name(); base != "" {
+			return base
+		}
+		return "Custom"
+	case pdf.Stream:
+		// An embedded CMap of a composite font.
+		if name := v.Key("CMapName").Name(); name != "" {
+			return name
+		}
+		return "Custom"
+	default:
+		return ""
+	}
+}
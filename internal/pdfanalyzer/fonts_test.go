@@ -0,0 +1,67 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestAnalyzeFile_Fonts(t *testing.T) {
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 6 0 R /F2 7 0 R >> >> /Contents 5 0 R >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 6 0 R /T3 << /Type /Font /Subtype /Type3 /Encoding << /Differences [65 /square] >> >> >> /XObject << /Fm1 10 0 R >> >> /Contents 5 0 R >>")
+	b.add(stream("", "BT /F1 12 Tf 72 700 Td (Hello) Tj ET"))
+	b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	b.add("<< /Type /Font /Subtype /TrueType /BaseFont /ABCDEF+Arial /Encoding << /BaseEncoding /MacRomanEncoding >> /FontDescriptor 8 0 R /ToUnicode 9 0 R >>")
+	b.add("<< /Type /FontDescriptor /FontName /ABCDEF+Arial /FontFile2 11 0 R >>")
+	b.add(stream("", "/CIDInit /ProcSet findresource begin end"))
+	b.add(stream("/Type /XObject /Subtype /Form /BBox [0 0 100 100] /Resources << /Font << /C0 12 0 R >> >>", ""))
+	b.add(stream("", "glyphs"))
+	b.add("<< /Type /Font /Subtype /Type0 /BaseFont /NotoSansCJK /Encoding /Identity-H /DescendantFonts [<< /Type /Font /Subtype /CIDFontType0 /BaseFont /NotoSansCJK /FontDescriptor << /Type /FontDescriptor /FontFile3 11 0 R >> >>] >>")
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+
+	want := []Font{
+		{Name: "Helvetica", Subtype: FontType1, Encoding: "WinAnsiEncoding", Pages: []int{1, 2}},
+		{Name: "Arial", Subtype: FontTrueType, Embedded: true, Subset: true, Encoding: "MacRomanEncoding", ToUnicode: true, Pages: []int{1}},
+		{Name: "T3", Subtype: FontType3, Embedded: true, Encoding: "Custom", Pages: []int{2}},
+		{Name: "NotoSansCJK", Subtype: FontType0, Embedded: true, Encoding: "Identity-H", Pages: []int{2}},
+	}
+	if fmt.Sprint(res.Fonts) != fmt.Sprint(want) {
+		t.Errorf("fonts:\n got %+v\nwant %+v", res.Fonts, want)
+	}
+}
+
+func TestAnalyzeFile_InlineFonts(t *testing.T) {
+	// Two inline fonts on one page are two fonts; the same inline font
+	// on two pages is one entry per page, as they cannot be told
+	// apart.
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> /F2 << /Type /Font /Subtype /Type1 /BaseFont /Times-Roman >> >> >> /Contents 5 0 R >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources 6 0 R /Contents 5 0 R >>")
+	b.add(stream("", "BT /F1 12 Tf 72 700 Td (Hello) Tj ET"))
+	b.add("<< /Font << /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> /F2 7 0 R >> >>")
+	b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+
+	want := []Font{
+		{Name: "Helvetica", Subtype: FontType1, Pages: []int{1}},
+		{Name: "Times-Roman", Subtype: FontType1, Pages: []int{1}},
+		{Name: "Helvetica", Subtype: FontType1, Pages: []int{2}},
+		{Name: "Courier", Subtype: FontType1, Pages: []int{2}},
+	}
+	if fmt.Sprint(res.Fonts) != fmt.Sprint(want) {
+		t.Errorf("fonts:\n got %+v\nwant %+v", res.Fonts, want)
+	}
+}
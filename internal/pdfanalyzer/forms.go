@@ -0,0 +1,332 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Form field types.
+const (
+	FieldText      = "text"
+	FieldCheckbox  = "checkbox"
+	FieldRadio     = "radio"
+	FieldButton    = "button" // push button, holds no value
+	FieldChoice    = "choice"
+	FieldSignature = "signature"
+)
+
+// FormField is a terminal field of an interactive (AcroForm) form.
+type FormField struct {
+	Name     string   // fully qualified name, e.g. "applicant.address.city"
+	Type     string   // one of the Field* constants
+	Value    string   // current value; the state name for check boxes and radios
+	Values   []string // every selected option of multi-select choice fields
+	Options  []FormOption
+	Required bool
+	ReadOnly bool
+	Page     int  // 1-based page of the first widget, 0 when unknown
+	Rect     Rect // position of the first widget on its page
+}
+
+// FormOption is a choice of a choice field, or an on state of a check
+// box or radio group.
+type FormOption struct {
+	Value string // export value
+	Label string // displayed text, equal to Value when not set
+}
+
+// Rect is a rectangle in default user space, in points.
+type Rect struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// Field flags (PDF 32000-1:2008, tables 221, 226 and 228).
+const (
+	flagReadOnly    = 1 << 0
+	flagRequired    = 1 << 1
+	flagRadio       = 1 << 15
+	flagPushButton  = 1 << 16
+	flagMultiSelect = 1 << 21
+)
+
+// maxFormFields bounds the field walk, like maxOutlineItems for
+// bookmarks.
+const maxFormFields = 10000
+
+// ExtractForms lists the terminal fields of the document's AcroForm
+// in field-tree order. Documents without a form yield no fields. Errors
+// are reported as in AnalyzeFile.
+func (a *PDFAnalyzer) ExtractForms(ctx context.Context, filePath string, opts ...FileOption) (fields []FormField, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fields, err = nil, recoverError(r)
+		}
+	}()
+
+	var o fileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := openReader(file, o.password)
+	if err != nil {
+		return nil, err
+	}
+
+	numPages := content.NumPage()
+	if err := a.checkPageCount(numPages); err != nil {
+		return nil, err
+	}
+	return readFormFields(ctx, content, numPages)
+}
+
+// readFormFields walks the AcroForm field tree of content. It is shared
+// by ExtractForms and AnalyzeFile.
+func readFormFields(ctx context.Context, content *pdf.Reader, numPages int) ([]FormField, error) {
+	roots := content.Trailer().Key("Root").Key("AcroForm").Key("Fields")
+	if roots.Len() == 0 {
+		return nil, nil
+	}
+
+	// Widgets name their page with /P, which is optional; the page
+	// annotation arrays are the fallback.
+	pages := make(map[objectID]int, numPages)
+	annots := make(map[objectID]int)
+	for i := 1; i <= numPages; i++ {
+		p := content.Page(i)
+		if id, ok := objectIDOf(p.V); ok {
+			pages[id] = i
+		}
+		list := p.V.Key("Annots")
+		for j := 0; j < list.Len(); j++ {
+			if id, ok := objectIDOf(list.Index(j)); ok {
+				annots[id] = i
+			}
+		}
+	}
+
+	w := formWalker{
+		ctx:    ctx,
+		pages:  pages,
+		annots: annots,
+		seen:   make(map[objectID]bool),
+	}
+	for i := 0; i < roots.Len(); i++ {
+		if err := w.walk(roots.Index(i), "", inheritedField{}, 1); err != nil {
+			return nil, err
+		}
+	}
+	return w.fields, nil
+}
+
+// inheritedField holds the inheritable field attributes (PDF
+// 32000-1:2008, table 220).
+type inheritedField struct {
+	ft    string
+	flags int64
+	value pdf.Value
+	opt   pdf.Value
+}
+
+type formWalker struct {
+	ctx    context.Context
+	pages  map[objectID]int
+	annots map[objectID]int
+	seen   map[objectID]bool
+	fields []FormField
+}
+
+func (w *formWalker) walk(node pdf.Value, parent string, inh inheritedField, depth int) error {
+	if node.Kind() != pdf.Dict || depth > maxOutlineDepth || len(w.fields) >= maxFormFields {
+		return nil
+	}
+	if id, ok := objectIDOf(node); ok {
+		if w.seen[id] {
+			return nil
+		}
+		w.seen[id] = true
+	}
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+
+	name := parent
+	if t := node.Key("T").Text(); t != "" {
+		if name != "" {
+			name += "."
+		}
+		name += t
+	}
+	if ft := node.Key("FT").Name(); ft != "" {
+		inh.ft = ft
+	}
+	if ff := node.Key("Ff"); !ff.IsNull() {
+		inh.flags = ff.Int64()
+	}
+	if v := node.Key("V"); !v.IsNull() {
+		inh.value = v
+	}
+	if opt := node.Key("Opt"); !opt.IsNull() {
+		inh.opt = opt
+	}
+
+	// Kids with a /T are fields; kids without one are the widgets
+	// of this field.
+	kids := node.Key("Kids")
+	var widgets []pdf.Value
+	hasFieldKids := false
+	for i := 0; i < kids.Len(); i++ {
+		kid := kids.Index(i)
+		if kid.Key("T").IsNull() {
+			widgets = append(widgets, kid)
+			continue
+		}
+		hasFieldKids = true
+		if err := w.walk(kid, name, inh, depth+1); err != nil {
+			return err
+		}
+	}
+	if hasFieldKids {
+		return nil
+	}
+	if kids.Len() == 0 {
+		widgets = []pdf.Value{node} // field and widget merged
+	}
+
+	w.fields = append(w.fields, w.field(name, inh, widgets))
+	return nil
+}
+
+// field builds the description of a terminal field.
+func (w *formWalker) field(name string, inh inheritedField, widgets []pdf.Value) FormField {
+	f := FormField{
+		Name:     name,
+		Type:     fieldType(inh.ft, inh.flags),
+		ReadOnly: inh.flags&flagReadOnly != 0,
+		Required: inh.flags&flagRequired != 0,
+	}
+
+	switch f.Type {
+	case FieldText:
+		f.Value = inh.value.Text()
+	case FieldCheckbox, FieldRadio:
+		f.Value = inh.value.Name()
+		f.Options = onStates(widgets)
+	case FieldChoice:
+		f.Options = choiceOptions(inh.opt)
+		if inh.value.Kind() == pdf.Array {
+			for i := 0; i < inh.value.Len(); i++ {
+				f.Values = append(f.Values, inh.value.Index(i).Text())
+			}
+		} else if v := inh.value.Text(); v != "" {
+			f.Values = []string{v}
+		}
+		if len(f.Values) > 0 {
+			f.Value = f.Values[0]
+		}
+		if inh.flags&flagMultiSelect == 0 {
+			f.Values = nil
+		}
+	}
+
+	if len(widgets) > 0 {
+		f.Page, f.Rect = w.placement(widgets[0])
+	}
+	return f
+}
+
+func fieldType(ft string, flags int64) string {
+	switch ft {
+	case "Tx":
+		return FieldText
+	case "Btn":
+		switch {
+		case flags&flagPushButton != 0:
+			return FieldButton
+		case flags&flagRadio != 0:
+			return FieldRadio
+		default:
+			return FieldCheckbox
+		}
+	case "Ch":
+		return FieldChoice
+	case "Sig":
+		return FieldSignature
+	default:
+		return ""
+	}
+}
+
+// placement returns the page and rectangle of a widget annotation.
+func (w *formWalker) placement(widget pdf.Value) (int, Rect) {
+	page := 0
+	if id, ok := objectIDOf(widget.Key("P")); ok {
+		page = w.pages[id]
+	}
+	if page == 0 {
+		if id, ok := objectIDOf(widget); ok {
+			page = w.annots[id]
+		}
+	}
+	return page, readRect(widget.Key("Rect"))
+}
+
+// readRect normalizes a rectangle array so that X1 <= X2 and Y1 <= Y2.
+func readRect(v pdf.Value) Rect {
+	if v.Len() != 4 {
+		return Rect{}
+	}
+	x1, y1, x2, y2 := v.Index(0).Float64(), v.Index(1).Float64(), v.Index(2).Float64(), v.Index(3).Float64()
+	return Rect{
+		X1: math.Min(x1, x2), Y1: math.Min(y1, y2),
+		X2: math.Max(x1, x2), Y2: math.Max(y1, y2),
+	}
+}
+
+// onStates lists the appearance states other than "Off" of the
+// widgets of a button field: the values it can be switched to.
+func onStates(widgets []pdf.Value) []FormOption {
+	seen := make(map[string]bool)
+	var out []FormOption
+	for _, wdg := range widgets {
+		states := wdg.Key("AP").Key("N").Keys()
+		sort.Strings(states)
+		for _, s := range states {
+			if s != "Off" && !seen[s] {
+				seen[s] = true
+				out = append(out, FormOption{Value: s, Label: s})
+			}
+		}
+	}
+	return out
+}
+
+// choiceOptions reads /Opt, whose entries are either text strings or
+// [export value, displayed text] pairs.
+func choiceOptions(opt pdf.Value) []FormOption {
+	out := make([]FormOption, 0, opt.Len())
+	for i := 0; i < opt.Len(); i++ {
+		o := opt.Index(i)
+		if o.Kind() == pdf.Array && o.Len() == 2 {
+			out = append(out, FormOption{Value: o.Index(0).Text(), Label: o.Index(1).Text()})
+			continue
+		}
+		text := strings.TrimSpace(o.Text())
+		out = append(out, FormOption{Value: text, Label: text})
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
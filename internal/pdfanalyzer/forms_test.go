@@ -0,0 +1,90 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestExtractForms(t *testing.T) {
+	// Objects 1-5 are the catalog, page tree, font and one page; the
+	// form starts at 6.
+	b := newTextPDF("/AcroForm << /Fields [6 0 R 9 0 R 10 0 R 13 0 R 14 0 R] >>",
+		"/Annots [8 0 R 9 0 R 11 0 R 12 0 R]",
+		textContent("Application form"))
+	page := pageObject(1)
+
+	// 6: "applicant" with a nested text field split from its widget (8).
+	b.add("<< /T (applicant) /Kids [7 0 R] /FT /Tx >>")
+	b.add("<< /T (name) /Parent 6 0 R /V (Jane Doe) /Ff 2 /Kids [8 0 R] >>")
+	b.add(fmt.Sprintf("<< /Type /Annot /Subtype /Widget /Parent 7 0 R /P %d 0 R /Rect [300 700 100 680] >>", page))
+	// 9: check box, field and widget merged, without /P.
+	b.add("<< /T (agree) /FT /Btn /V /Yes /Ff 1 /Subtype /Widget /Rect [100 650 112 662] /AP << /N << /Yes 15 0 R /Off 15 0 R >> >> >>")
+	// 10: radio group with two widgets.
+	b.add("<< /T (plan) /FT /Btn /Ff 49152 /V /Gold /Kids [11 0 R 12 0 R] >>")
+	b.add(fmt.Sprintf("<< /Subtype /Widget /Parent 10 0 R /P %d 0 R /Rect [100 600 110 610] /AP << /N << /Silver 15 0 R /Off 15 0 R >> >> >>", page))
+	b.add(fmt.Sprintf("<< /Subtype /Widget /Parent 10 0 R /P %d 0 R /Rect [150 600 160 610] /AP << /N << /Gold 15 0 R /Off 15 0 R >> >> >>", page))
+	// 13: multi-select list box; 14: signature without widget.
+	b.add("<< /T (langs) /FT /Ch /Ff 2097152 /Opt [[(en) (English)] (pt)] /V [(en) (pt)] >>")
+	b.add("<< /T (signature) /FT /Sig >>")
+	b.add(stream("/Type /XObject /Subtype /Form /BBox [0 0 10 10]", ""))
+
+	path := b.write(t)
+	fields, err := NewPDFAnalyzer().ExtractForms(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The analysis reads the same fields in its single pass.
+	result, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+	if !reflect.DeepEqual(result.FormFields, fields) {
+		t.Errorf("analysis fields = %+v, want %+v", result.FormFields, fields)
+	}
+
+	want := []FormField{
+		{Name: "applicant.name", Type: FieldText, Value: "Jane Doe", Required: true, Page: 1, Rect: Rect{100, 680, 300, 700}},
+		{Name: "agree", Type: FieldCheckbox, Value: "Yes", ReadOnly: true, Page: 1, Rect: Rect{100, 650, 112, 662},
+			Options: []FormOption{{"Yes", "Yes"}}},
+		{Name: "plan", Type: FieldRadio, Value: "Gold", Page: 1, Rect: Rect{100, 600, 110, 610},
+			Options: []FormOption{{"Silver", "Silver"}, {"Gold", "Gold"}}},
+		{Name: "langs", Type: FieldChoice, Value: "en", Values: []string{"en", "pt"},
+			Options: []FormOption{{"en", "English"}, {"pt", "pt"}}},
+		{Name: "signature", Type: FieldSignature},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("expected %d fields, got %d: %+v", len(want), len(fields), fields)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(fields[i], want[i]) {
+			t.Errorf("field %d:\n got %+v\nwant %+v", i, fields[i], want[i])
+		}
+	}
+}
+
+func TestExtractForms_NoForm(t *testing.T) {
+	fields, err := NewPDFAnalyzer().ExtractForms(context.Background(), newTextPDF("", "", textContent("text")).write(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fields) != 0 {
+		t.Errorf("expected no fields, got %+v", fields)
+	}
+}
+
+func TestExtractForms_Cycle(t *testing.T) {
+	b := newTextPDF("/AcroForm << /Fields [6 0 R] >>", "", textContent("text"))
+	b.add("<< /T (loop) /FT /Tx /Kids [7 0 R] >>")
+	b.add("<< /T (child) /Kids [6 0 R] >>")
+
+	fields, err := NewPDFAnalyzer().ExtractForms(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fields) != 0 {
+		t.Errorf("expected the cycle to yield no terminal field, got %+v", fields)
+	}
+}
@@ -0,0 +1,266 @@
+package pdfanalyzer
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Heading heuristics, applied to documents without bookmarks.
+const (
+	// headingSizeRatio is how much larger than the body text a line
+	// must be to count as a heading without being bold.
+	headingSizeRatio = 1.15
+	// maxHeadingRunes rejects long lines, which are emphasized
+	// paragraphs rather than headings.
+	maxHeadingRunes = 120
+	// maxHeadingLevels bounds the depth of the derived tree; smaller
+	// styles are folded into the last level.
+	maxHeadingLevels = 6
+	// minRepeatedPages is the page count from which a line repeated
+	// on most pages is taken as a running header and ignored.
+	minRepeatedPages = 3
+)
+
+// textLine is a run of glyphs sharing a baseline.
+type textLine struct {
+	page int
+	text string
+	size float64 // font size of most glyphs, in points
+	bold bool    // every non-space glyph uses a bold face
+	y    float64
+}
+
+// pageGlyphs returns the positioned glyphs of p. It is best effort:
+// the content stream is interpreted a second time, and a failure there
+// only costs the layout-based features (headings, highlighted text)
+// of this page.
+func pageGlyphs(p pdf.Page) (glyphs []pdf.Text) {
+	defer func() {
+		if recover() != nil {
+			glyphs = nil
+		}
+	}()
+	return p.Content().Text
+}
+
+// pageLines groups glyphs, in content order, into lines.
+func pageLines(glyphs []pdf.Text, number int) []textLine {
+	var (
+		lines   []textLine
+		cur     strings.Builder
+		line    textLine
+		sizes   map[float64]int
+		bold    bool
+		started bool
+		prev    pdf.Text
+	)
+	flush := func() {
+		if !started {
+			return
+		}
+		line.text = strings.Join(strings.Fields(cur.String()), " ")
+		line.size = dominantSize(sizes)
+		line.bold = bold
+		if line.text != "" {
+			lines = append(lines, line)
+		}
+		cur.Reset()
+		started = false
+	}
+
+	for _, g := range glyphs {
+		if started && math.Abs(g.Y-prev.Y) > 0.3*math.Max(prev.FontSize, 1) {
+			flush()
+		}
+		if !started {
+			line = textLine{page: number, y: g.Y}
+			sizes = make(map[float64]int)
+			bold = true
+			started = true
+		} else if g.X > prev.X+prev.W+0.15*g.FontSize {
+			cur.WriteByte(' ')
+		}
+
+		cur.WriteString(g.S)
+		if strings.TrimSpace(g.S) != "" {
+			sizes[math.Round(g.FontSize*2)/2]++
+			bold = bold && isBoldFont(g.Font)
+		}
+		prev = g
+	}
+	flush()
+
+	return lines
+}
+
+// dominantSize returns the size used by most glyphs.
+func dominantSize(sizes map[float64]int) float64 {
+	var best float64
+	for size, n := range sizes {
+		if n > sizes[best] || n == sizes[best] && size > best {
+			best = size
+		}
+	}
+	return best
+}
+
+// isBoldFont guesses the weight from the font name, e.g.
+// "Helvetica-Bold" or "ABCDEF+Inter-SemiBold".
+func isBoldFont(name string) bool {
+	name = strings.ToLower(name)
+	for _, w := range []string{"bold", "black", "heavy", "demi"} {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// headingStyle is the typographic signature of a heading level.
+type headingStyle struct {
+	size float64
+	bold bool
+}
+
+// headingOutline derives an outline from the lines of all pages:
+// lines noticeably larger than the body text, or bold at body size,
+// become headings, and their styles rank into levels.
+func headingOutline(lines []textLine, numPages int) Outline {
+	body := bodySize(lines)
+	if body == 0 {
+		return Outline{}
+	}
+	running := runningHeaders(lines, numPages)
+
+	var headings []textLine
+	for _, l := range lines {
+		if isHeading(l, body) && !running[l.text] {
+			headings = appendHeading(headings, l)
+		}
+	}
+	// When most lines qualify, the text has no distinct body style
+	// (slides, forms) and the headings would be noise.
+	if len(headings) == 0 || len(headings) > len(lines)/2 {
+		return Outline{}
+	}
+	if len(headings) > maxOutlineItems {
+		headings = headings[:maxOutlineItems]
+	}
+
+	levels := headingLevels(headings)
+	items := make([]OutlineItem, len(headings))
+	depth := make([]int, len(headings))
+	for i, h := range headings {
+		items[i] = OutlineItem{Title: h.text, Page: h.page}
+		depth[i] = levels[headingStyle{h.size, h.bold}]
+	}
+	return Outline{Source: OutlineHeadings, Items: nestOutline(items, depth)}
+}
+
+// bodySize returns the font size of most of the text.
+func bodySize(lines []textLine) float64 {
+	runes := make(map[float64]int)
+	for _, l := range lines {
+		runes[l.size] += utf8.RuneCountInString(l.text)
+	}
+	return dominantSize(runes)
+}
+
+// runningHeaders returns the texts repeated on most pages, such as
+// document titles in page headers.
+func runningHeaders(lines []textLine, numPages int) map[string]bool {
+	if numPages < minRepeatedPages {
+		return nil
+	}
+
+	pages := make(map[string]map[int]bool)
+	for _, l := range lines {
+		if pages[l.text] == nil {
+			pages[l.text] = make(map[int]bool)
+		}
+		pages[l.text][l.page] = true
+	}
+
+	running := make(map[string]bool)
+	for text, on := range pages {
+		if len(on) > numPages/2 {
+			running[text] = true
+		}
+	}
+	return running
+}
+
+func isHeading(l textLine, body float64) bool {
+	n := utf8.RuneCountInString(l.text)
+	if n < 2 || n > maxHeadingRunes || !strings.ContainsFunc(l.text, unicode.IsLetter) {
+		return false
+	}
+	return l.size >= body*headingSizeRatio || l.bold && l.size >= body
+}
+
+// appendHeading adds l to headings, joining it to the previous heading
+// when it continues it on the next line.
+func appendHeading(headings []textLine, l textLine) []textLine {
+	if n := len(headings); n > 0 {
+		last := &headings[n-1]
+		if last.page == l.page && last.size == l.size && last.bold == l.bold &&
+			last.y > l.y && last.y-l.y <= 1.6*l.size {
+			last.text += " " + l.text
+			last.y = l.y
+			return headings
+		}
+	}
+	return append(headings, l)
+}
+
+// headingLevels ranks the styles of headings: larger first and, at
+// equal size, bold first.
+func headingLevels(headings []textLine) map[headingStyle]int {
+	seen := make(map[headingStyle]bool)
+	var styles []headingStyle
+	for _, h := range headings {
+		s := headingStyle{h.size, h.bold}
+		if !seen[s] {
+			seen[s] = true
+			styles = append(styles, s)
+		}
+	}
+	sort.Slice(styles, func(i, j int) bool {
+		if styles[i].size != styles[j].size {
+			return styles[i].size > styles[j].size
+		}
+		return styles[i].bold && !styles[j].bold
+	})
+
+	levels := make(map[headingStyle]int, len(styles))
+	for i, s := range styles {
+		levels[s] = min(i+1, maxHeadingLevels)
+	}
+	return levels
+}
+
+// nestOutline builds a tree from items in document order, where
+// depth[i] is the style level of items[i]. An item becomes a child of
+// the closest preceding item with a lower level. Levels are then
+// renumbered as nesting depths.
+func nestOutline(items []OutlineItem, depth []int) []OutlineItem {
+	var build func(i, parentLevel, level int) ([]OutlineItem, int)
+	build = func(i, parentLevel, level int) ([]OutlineItem, int) {
+		var out []OutlineItem
+		for i < len(items) && depth[i] > parentLevel {
+			item := items[i]
+			item.Level = level
+			item.Children, i = build(i+1, depth[i], level+1)
+			out = append(out, item)
+		}
+		return out, i
+	}
+
+	tree, _ := build(0, 0, 1)
+	return tree
+}
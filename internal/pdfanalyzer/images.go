@@ -0,0 +1,493 @@
+package pdfanalyzer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Image compression filters, as reported in Image.Filter.
+const (
+	FilterDCT       = "DCT" // JPEG
+	FilterJPX       = "JPX" // JPEG 2000
+	FilterJBIG2     = "JBIG2"
+	FilterCCITT     = "CCITTFax"
+	FilterFlate     = "Flate"
+	FilterLZW       = "LZW"
+	FilterRunLength = "RunLength"
+)
+
+// filterNames maps PDF filter names to reported filters. ASCII
+// filters only wrap the data and are not reported.
+var filterNames = map[string]string{
+	"DCTDecode":       FilterDCT,
+	"JPXDecode":       FilterJPX,
+	"JBIG2Decode":     FilterJBIG2,
+	"CCITTFaxDecode":  FilterCCITT,
+	"FlateDecode":     FilterFlate,
+	"LZWDecode":       FilterLZW,
+	"RunLengthDecode": FilterRunLength,
+}
+
+// Image is an image XObject drawn on a page. Images shared by several
+// pages are listed once per page.
+type Image struct {
+	Page             int    // 1-based page the image is on
+	Name             string // resource name, e.g. "Im1"
+	Width, Height    int    // in pixels
+	BitsPerComponent int
+	ColorSpace       string // e.g. "DeviceRGB", "ICCBased", "Indexed"
+	Filter           string // one of the Filter* constants, "" when uncompressed
+	ByteSize         int64  // size of the encoded stream
+	// Effective resolution of the largest placement on the page, in
+	// pixels per inch. Zero when the image is not drawn or the
+	// content stream cannot be read.
+	DPIX, DPIY float64
+}
+
+// ImageData is an image exported from a document.
+type ImageData struct {
+	ContentType string // e.g. "image/jpeg"
+	Extension   string // e.g. ".jpg"
+	Data        []byte
+}
+
+// ErrImageNotFound reports an image index beyond the inventory.
+var ErrImageNotFound = errors.New("image not found")
+
+// Bounds on the image walk: images per document, and form XObjects
+// nested in one another.
+const (
+	maxImages     = 10000
+	maxFormNested = 8
+)
+
+// placedImage is an inventory entry with the stream it describes.
+type placedImage struct {
+	Image
+	stream pdf.Value
+}
+
+// pageImages lists the images of page number: the images drawn by its
+// content, in drawing order, then the image resources it never draws.
+// It also returns the fraction of the page covered by images, from 0
+// to 1. Reading the content is best effort; a failure only loses the
+// placements.
+func pageImages(p pdf.Page, number int, limit int) ([]placedImage, float64) {
+	w := imageWalker{
+		page:  number,
+		limit: limit,
+		index: make(map[any]int),
+		box:   readRect(inheritedKey(p, "MediaBox")),
+	}
+	func() {
+		defer func() { _ = recover() }()
+		w.walk(p.V.Key("Contents"), p.Resources(), identity, 0)
+	}()
+
+	// Resources never drawn, e.g. left behind by an editor.
+	xobjects := p.Resources().Key("XObject")
+	names := xobjects.Keys()
+	sort.Strings(names)
+	for _, name := range names {
+		w.add(name, xobjects.Key(name), nil)
+	}
+
+	area := (w.box.X2 - w.box.X1) * (w.box.Y2 - w.box.Y1)
+	if area <= 0 {
+		return w.images, 0
+	}
+	return w.images, math.Round(min(w.covered/area, 1)*1000) / 1000
+}
+
+// matrix is an affine transformation [a b c d e f] (PDF 32000-1:2008,
+// §8.3.4).
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m × n, that is m applied first.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func readMatrix(v pdf.Value) matrix {
+	if v.Len() != 6 {
+		return identity
+	}
+	var m matrix
+	for i := range m {
+		m[i] = v.Index(i).Float64()
+	}
+	return m
+}
+
+type imageWalker struct {
+	page   int
+	limit  int
+	images []placedImage
+	index  map[any]int // image stream key to position in images
+
+	box     Rect    // page media box
+	covered float64 // area covered by images, in square points
+}
+
+// walk interprets a content stream, following the current
+// transformation matrix, and records the images it draws.
+func (w *imageWalker) walk(content, resources pdf.Value, ctm matrix, depth int) {
+	var stack []matrix
+	pdf.Interpret(content, func(stk *pdf.Stack, op string) {
+		n := stk.Len()
+		args := make([]pdf.Value, n)
+		for i := n - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			if len(args) == 6 {
+				var m matrix
+				for i := range m {
+					m[i] = args[i].Float64()
+				}
+				ctm = m.mul(ctm)
+			}
+		case "Do":
+			if len(args) != 1 {
+				return
+			}
+			name := args[0].Name()
+			xobj := resources.Key("XObject").Key(name)
+			switch xobj.Key("Subtype").Name() {
+			case "Image":
+				placement := ctm
+				w.add(name, xobj, &placement)
+			case "Form":
+				if depth >= maxFormNested {
+					return
+				}
+				res := xobj.Key("Resources")
+				if res.IsNull() {
+					res = resources
+				}
+				w.walk(xobj, res, readMatrix(xobj.Key("Matrix")).mul(ctm), depth+1)
+			}
+		}
+	})
+}
+
+// add records an image drawn with the given placement, or listed in
+// the resources when placement is nil. An image drawn several times
+// keeps its lowest resolution.
+func (w *imageWalker) add(name string, xobj pdf.Value, placement *matrix) {
+	if xobj.Kind() != pdf.Stream || xobj.Key("Subtype").Name() != "Image" {
+		return
+	}
+	if placement != nil {
+		w.covered += w.placedArea(*placement)
+	}
+
+	key := streamKey(xobj, name)
+	i, seen := w.index[key]
+	if !seen {
+		if len(w.images) >= w.limit {
+			return
+		}
+		i = len(w.images)
+		w.index[key] = i
+		w.images = append(w.images, placedImage{Image: describeImage(xobj, w.page, name), stream: xobj})
+	}
+	if placement == nil {
+		return
+	}
+
+	img := &w.images[i].Image
+	// The image occupies the unit square, so the lengths of the
+	// transformed unit vectors are its size on the page, in points.
+	width := math.Hypot(placement[0], placement[1]) / 72
+	height := math.Hypot(placement[2], placement[3]) / 72
+	if width == 0 || height == 0 {
+		return
+	}
+	dpiX := math.Round(float64(img.Width) / width)
+	dpiY := math.Round(float64(img.Height) / height)
+	if img.DPIX == 0 || dpiX < img.DPIX {
+		img.DPIX = dpiX
+	}
+	if img.DPIY == 0 || dpiY < img.DPIY {
+		img.DPIY = dpiY
+	}
+}
+
+// placedArea returns the area of the page box covered by the unit
+// square mapped by m. Rotated and skewed images are approximated by
+// their bounding box. Overlapping images are counted twice, which the
+// caller bounds by the page area.
+func (w *imageWalker) placedArea(m matrix) float64 {
+	r := Rect{X1: math.Inf(1), Y1: math.Inf(1), X2: math.Inf(-1), Y2: math.Inf(-1)}
+	for _, corner := range [][2]float64{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		x := corner[0]*m[0] + corner[1]*m[2] + m[4]
+		y := corner[0]*m[1] + corner[1]*m[3] + m[5]
+		r.X1, r.X2 = math.Min(r.X1, x), math.Max(r.X2, x)
+		r.Y1, r.Y2 = math.Min(r.Y1, y), math.Max(r.Y2, y)
+	}
+	width := math.Min(r.X2, w.box.X2) - math.Max(r.X1, w.box.X1)
+	height := math.Min(r.Y2, w.box.Y2) - math.Max(r.Y1, w.box.Y1)
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	return width * height
+}
+
+// streamKey identifies an image stream: by object when it is an
+// indirect object, by resource name otherwise.
+func streamKey(v pdf.Value, name string) any {
+	if id, ok := objectIDOf(v); ok {
+		return id
+	}
+	return name
+}
+
+func describeImage(v pdf.Value, page int, name string) Image {
+	img := Image{
+		Page:             page,
+		Name:             name,
+		Width:            int(v.Key("Width").Int64()),
+		Height:           int(v.Key("Height").Int64()),
+		BitsPerComponent: int(v.Key("BitsPerComponent").Int64()),
+		ColorSpace:       colorSpaceName(v.Key("ColorSpace")),
+		Filter:           imageFilter(v.Key("Filter")),
+		ByteSize:         v.Key("Length").Int64(),
+	}
+	// Stencil masks and JPX images may omit the depth.
+	if v.Key("ImageMask").Bool() {
+		img.BitsPerComponent = 1
+	}
+	return img
+}
+
+// colorSpaceName returns the family of a color space: its name, or the
+// first element of a parameterized color space such as [/ICCBased 5 0 R].
+func colorSpaceName(v pdf.Value) string {
+	if v.Kind() == pdf.Array {
+		return v.Index(0).Name()
+	}
+	return v.Name()
+}
+
+// imageFilter returns the last (innermost) compression filter of a
+// stream.
+func imageFilter(v pdf.Value) string {
+	names := []string{v.Name()}
+	if v.Kind() == pdf.Array {
+		names = names[:0]
+		for i := 0; i < v.Len(); i++ {
+			names = append(names, v.Index(i).Name())
+		}
+	}
+	filter := ""
+	for _, n := range names {
+		if f, ok := filterNames[n]; ok {
+			filter = f
+		}
+	}
+	return filter
+}
+
+// ExtractImage exports the n-th (1-based) image of the inventory
+// returned by AnalyzeFile. JPEG and JPEG 2000 images are returned as
+// stored; 8-bit gray and RGB images with Flate or no compression are
+// converted to PNG. Other images are returned as their raw encoded
+// stream. ErrImageNotFound is returned when n is out of range, and
+// ErrUnsupported when an encoded stream cannot be read, as in
+// encrypted documents. Other errors are reported as in AnalyzeFile.
+func (a *PDFAnalyzer) ExtractImage(ctx context.Context, filePath string, n int, opts ...FileOption) (data ImageData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			data, err = ImageData{}, recoverError(r)
+		}
+	}()
+
+	var o fileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ImageData{}, err
+	}
+	defer file.Close()
+
+	content, err := openReader(file, o.password)
+	if err != nil {
+		return ImageData{}, err
+	}
+
+	numPages := content.NumPage()
+	if err := a.checkPageCount(numPages); err != nil {
+		return ImageData{}, err
+	}
+
+	seen := 0
+	for i := 1; i <= numPages && n > 0; i++ {
+		if err := ctx.Err(); err != nil {
+			return ImageData{}, err
+		}
+		images, _ := pageImages(content.Page(i), i, maxImages-seen)
+		if n <= seen+len(images) {
+			encrypted := !content.Trailer().Key("Encrypt").IsNull()
+			return a.exportImage(file, images[n-seen-1], encrypted)
+		}
+		seen += len(images)
+	}
+	return ImageData{}, fmt.Errorf("%w: %d", ErrImageNotFound, n)
+}
+
+// exportImage encodes img for download.
+func (a *PDFAnalyzer) exportImage(file *os.File, img placedImage, encrypted bool) (ImageData, error) {
+	if a.maxStreamBytes > 0 && img.ByteSize > a.maxStreamBytes {
+		return ImageData{}, fmt.Errorf("%w: image of %d bytes, limit is %d", ErrStreamTooLarge, img.ByteSize, a.maxStreamBytes)
+	}
+
+	switch img.Filter {
+	case FilterFlate, "":
+		if data, ok := a.encodePNG(img); ok {
+			return data, nil
+		}
+	}
+
+	// The library cannot decode the remaining filters, and it only
+	// decrypts data it decodes.
+	if encrypted {
+		return ImageData{}, fmt.Errorf("%w: cannot export %s image of an encrypted document", ErrUnsupported, img.Filter)
+	}
+	raw, err := rawStream(file, img.stream)
+	if err != nil {
+		return ImageData{}, err
+	}
+
+	data := ImageData{ContentType: "application/octet-stream", Extension: ".bin", Data: raw}
+	if img.stream.Key("Filter").Kind() != pdf.Name {
+		return data, nil // chained filters: not a plain image file
+	}
+	switch img.Filter {
+	case FilterDCT:
+		data.ContentType, data.Extension = "image/jpeg", ".jpg"
+	case FilterJPX:
+		data.ContentType, data.Extension = "image/jp2", ".jp2"
+	case FilterJBIG2:
+		data.Extension = ".jbig2"
+	}
+	return data, nil
+}
+
+// encodePNG decodes an 8-bit gray or RGB image and encodes it as PNG.
+// ok is false when the image has another layout or cannot be decoded.
+func (a *PDFAnalyzer) encodePNG(img placedImage) (data ImageData, ok bool) {
+	defer func() {
+		if recover() != nil {
+			data, ok = ImageData{}, false
+		}
+	}()
+
+	var components int
+	switch img.ColorSpace {
+	case "DeviceGray", "CalGray":
+		components = 1
+	case "DeviceRGB", "CalRGB":
+		components = 3
+	default:
+		return ImageData{}, false
+	}
+	if img.BitsPerComponent != 8 || img.Width <= 0 || img.Height <= 0 {
+		return ImageData{}, false
+	}
+
+	size := int64(img.Width) * int64(img.Height) * int64(components)
+	if a.maxStreamBytes > 0 && size > a.maxStreamBytes {
+		return ImageData{}, false
+	}
+	rd := img.stream.Reader()
+	defer rd.Close()
+	samples := make([]byte, size)
+	if _, err := io.ReadFull(rd, samples); err != nil {
+		return ImageData{}, false
+	}
+
+	var out image.Image
+	rect := image.Rect(0, 0, img.Width, img.Height)
+	if components == 1 {
+		out = &image.Gray{Pix: samples, Stride: img.Width, Rect: rect}
+	} else {
+		rgba := image.NewNRGBA(rect)
+		for i := 0; i < img.Width*img.Height; i++ {
+			rgba.Pix[i*4], rgba.Pix[i*4+1], rgba.Pix[i*4+2] = samples[i*3], samples[i*3+1], samples[i*3+2]
+			rgba.Pix[i*4+3] = 0xff
+		}
+		out = rgba
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, out); err != nil {
+		return ImageData{}, false
+	}
+	return ImageData{ContentType: "image/png", Extension: ".png", Data: buf.Bytes()}, true
+}
+
+// rawStream reads the encoded bytes of stream v. The PDF library only
+// exposes decoded data, so the stream offset is read from an
+// unexported field, like objectIDOf does. The length comes from the
+// file: it is checked against the file size before allocating.
+func rawStream(file *os.File, v pdf.Value) ([]byte, error) {
+	data := reflect.ValueOf(v).FieldByName("data")
+	if data.Kind() == reflect.Interface {
+		data = data.Elem()
+	}
+	if data.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: stream not present", ErrCorrupt)
+	}
+	offset := data.FieldByName("offset")
+	if !offset.IsValid() || offset.Kind() != reflect.Int64 {
+		return nil, fmt.Errorf("%w: cannot locate image stream", ErrUnsupported)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	start, length := offset.Int(), v.Key("Length").Int64()
+	if start < 0 || start > info.Size() || length < 0 || length > info.Size()-start {
+		return nil, fmt.Errorf("%w: image stream of %d bytes at offset %d exceeds the file", ErrCorrupt, length, start)
+	}
+
+	raw := make([]byte, length)
+	if _, err := file.ReadAt(raw, start); err != nil {
+		return nil, fmt.Errorf("%w: read image stream: %v", ErrCorrupt, err)
+	}
+	return raw, nil
+}
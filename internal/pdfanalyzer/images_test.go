@@ -0,0 +1,167 @@
+package pdfanalyzer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+)
+
+// imagePDF builds a one-page document with an RGB image drawn
+// directly, a JPEG drawn through a scaled form XObject and a JBIG2
+// image that is never drawn. It returns the path and the JPEG bytes.
+func imagePDF(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, image.NewGray(image.Rect(0, 0, 16, 16)), nil); err != nil {
+		t.Fatal(err)
+	}
+	rgb := strings.Repeat("\xff\x00\x00", 20*10)
+
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /XObject << /Im1 5 0 R /Fm1 6 0 R /Im3 8 0 R >> >> /Contents 4 0 R >>")
+	// Im1: 20x10 pixels on 10x5 points, 144 dpi. Fm1 halves a
+	// 144-point square, so the 16-pixel JPEG covers one inch.
+	b.add(stream("", "q 10 0 0 5 50 50 cm /Im1 Do Q q 144 0 0 144 100 100 cm /Fm1 Do Q"))
+	b.add(stream("/Type /XObject /Subtype /Image /Width 20 /Height 10 /BitsPerComponent 8 /ColorSpace /DeviceRGB", rgb))
+	b.add(stream("/Type /XObject /Subtype /Form /BBox [0 0 1 1] /Matrix [0.5 0 0 0.5 0 0] /Resources << /XObject << /Im2 7 0 R >> >>", "/Im2 Do"))
+	b.add(stream("/Type /XObject /Subtype /Image /Width 16 /Height 16 /BitsPerComponent 8 /ColorSpace /DeviceGray /Filter /DCTDecode", jpg.String()))
+	b.add(stream("/Type /XObject /Subtype /Image /Width 100 /Height 50 /ImageMask true /Filter /JBIG2Decode", "jbig2"))
+
+	return b.write(t), jpg.Bytes()
+}
+
+func TestAnalyzeFile_Images(t *testing.T) {
+	path, jpg := imagePDF(t)
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+
+	want := []Image{
+		{Page: 1, Name: "Im1", Width: 20, Height: 10, BitsPerComponent: 8, ColorSpace: "DeviceRGB", ByteSize: 600, DPIX: 144, DPIY: 144},
+		{Page: 1, Name: "Im2", Width: 16, Height: 16, BitsPerComponent: 8, ColorSpace: "DeviceGray", Filter: FilterDCT, ByteSize: int64(len(jpg)), DPIX: 16, DPIY: 16},
+		{Page: 1, Name: "Im3", Width: 100, Height: 50, BitsPerComponent: 1, Filter: FilterJBIG2, ByteSize: 5},
+	}
+	if fmt.Sprint(res.Images) != fmt.Sprint(want) {
+		t.Errorf("images:\n got %+v\nwant %+v", res.Images, want)
+	}
+	// 50 + 5184 square points of a 612x792 page.
+	if got := res.Pages[0].ImageCoverage; got != 0.011 {
+		t.Errorf("image coverage = %v, want 0.011", got)
+	}
+}
+
+func TestAnalyzeFile_ScannedPageCoverage(t *testing.T) {
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /XObject << /Scan 5 0 R >> >> /Contents 4 0 R >>")
+	// The scan overflows the page by a few points, as scanners do.
+	b.add(stream("", "q 620 0 0 800 -4 -4 cm /Scan Do Q"))
+	b.add(stream("/Type /XObject /Subtype /Image /Width 1 /Height 1 /BitsPerComponent 8 /ColorSpace /DeviceGray", "\x00"))
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+	if res.Content != "" || res.Pages[0].ImageCoverage != 1 {
+		t.Errorf("expected an image-only page, got content %q and coverage %v", res.Content, res.Pages[0].ImageCoverage)
+	}
+}
+
+func TestExtractImage(t *testing.T) {
+	path, jpg := imagePDF(t)
+	a := NewPDFAnalyzer()
+
+	data, err := a.ExtractImage(context.Background(), path, 1)
+	if err != nil {
+		t.Fatalf("ExtractImage(1): %v", err)
+	}
+	if data.ContentType != "image/png" {
+		t.Fatalf("content type = %q, want image/png", data.ContentType)
+	}
+	img, err := png.Decode(bytes.NewReader(data.Data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if r, g, _, _ := img.At(3, 4).RGBA(); img.Bounds().Dx() != 20 || img.Bounds().Dy() != 10 || r != 0xffff || g != 0 {
+		t.Errorf("unexpected image %v, pixel %v", img.Bounds(), img.At(3, 4))
+	}
+
+	data, err = a.ExtractImage(context.Background(), path, 2)
+	if err != nil {
+		t.Fatalf("ExtractImage(2): %v", err)
+	}
+	if data.ContentType != "image/jpeg" || !bytes.Equal(data.Data, jpg) {
+		t.Errorf("JPEG not returned as stored: %q, %d bytes", data.ContentType, len(data.Data))
+	}
+
+	data, err = a.ExtractImage(context.Background(), path, 3)
+	if err != nil || data.ContentType != "application/octet-stream" || string(data.Data) != "jbig2" {
+		t.Errorf("ExtractImage(3) = %q %q, %v", data.ContentType, data.Data, err)
+	}
+
+	for _, n := range []int{0, 4} {
+		if _, err := a.ExtractImage(context.Background(), path, n); !errors.Is(err, ErrImageNotFound) {
+			t.Errorf("ExtractImage(%d) error = %v, want ErrImageNotFound", n, err)
+		}
+	}
+}
+
+func TestExtractImage_LengthBeyondFile(t *testing.T) {
+	// A stream length past the end of the file fails the export
+	// instead of sizing the read buffer.
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, image.NewGray(image.Rect(0, 0, 8, 8)), nil); err != nil {
+		t.Fatal(err)
+	}
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /XObject << /Scan 5 0 R >> >> /Contents 4 0 R >>")
+	b.add(stream("", "q 612 0 0 792 0 0 cm /Scan Do Q"))
+	b.add(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width 8 /Height 8 /BitsPerComponent 8 /ColorSpace /DeviceGray /Filter /DCTDecode /Length %d >>\nstream\n%s\nendstream", int64(1)<<40, jpg.String()))
+
+	if _, err := NewPDFAnalyzer().ExtractImage(context.Background(), b.write(t), 1); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("error = %v, want ErrCorrupt", err)
+	}
+}
+
+// TestLibraryInternals fails when an update of the PDF library drops
+// the unexported fields that objectIDOf and rawStream read.
+func TestLibraryInternals(t *testing.T) {
+	path := scannedPDF(t, 1)
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	content, err := openReader(file, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page := content.Page(1).V
+	if id, ok := objectIDOf(page); !ok || id != (objectID{num: 3}) {
+		t.Errorf("objectIDOf(page) = %v, %v, want object 3", id, ok)
+	}
+	scan := page.Key("Resources").Key("XObject").Key("Scan")
+	raw, err := rawStream(file, scan)
+	if err != nil {
+		t.Fatalf("rawStream: %v", err)
+	}
+	if int64(len(raw)) != scan.Key("Length").Int64() || !bytes.HasPrefix(raw, []byte{0xff, 0xd8}) {
+		t.Errorf("rawStream returned %d bytes starting with % x, want the JPEG", len(raw), raw[:min(len(raw), 2)])
+	}
+}
@@ -0,0 +1,12 @@
+package pdfanalyzer
+
+import "github.com/jorgediasdsg/pdf-expert/internal/langid"
+
+// Language is the language of a text; see the langid package.
+type Language = langid.Result
+
+// detectLanguage identifies the language of extracted text. Texts too
+// short to tell, such as near-empty pages, have an empty code.
+func detectLanguage(text string) Language {
+	return langid.Detect(text)
+}
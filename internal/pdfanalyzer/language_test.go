@@ -0,0 +1,31 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalyzeFile_Language(t *testing.T) {
+	path := newTextPDF("", "",
+		textContent("The supplier shall deliver the goods within thirty days of the order."),
+		textContent("Los empleados no pueden trabajar mas de cuarenta horas por semana."),
+		textContent("Page 3"),
+	).write(t)
+
+	result, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("AnalyzeFile returned error: %v", err)
+	}
+
+	for i, want := range []string{"en", "es", ""} {
+		if got := result.Pages[i].Language; got.Code != want {
+			t.Errorf("page %d: language = %+v; want %q", i+1, got, want)
+		}
+	}
+	if result.Pages[2].Language.Confidence != 0 {
+		t.Errorf("an undetermined language must have no confidence: %+v", result.Pages[2].Language)
+	}
+	if result.Language.Code == "" || result.Language.Confidence <= 0 {
+		t.Errorf("document language = %+v; want a language", result.Language)
+	}
+}
@@ -0,0 +1,410 @@
+package pdfanalyzer
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Text extraction modes, set with WithLayout.
+const (
+	// LayoutRaw keeps the text in content stream order, as the PDF
+	// library extracts it. Multi-column pages come out interleaved
+	// when the producer wrote the columns line by line.
+	LayoutRaw = "raw"
+	// LayoutReading rebuilds lines from the glyph positions, detects
+	// columns and emits them one after the other, in reading order.
+	LayoutReading = "reading"
+	// LayoutPhysical rebuilds lines from the glyph positions and keeps
+	// their horizontal spacing, like pdftotext -layout.
+	LayoutPhysical = "physical"
+)
+
+// Layout heuristics, in multiples of the font size.
+const (
+	// rowTolerance is how far apart two baselines may be and still
+	// form one row; it also absorbs superscripts and subscripts.
+	rowTolerance = 0.4
+	// wordGap is the horizontal gap from which two glyphs belong to
+	// different words.
+	wordGap = 0.2
+	// minGutter is the width of the empty vertical strip that
+	// separates two columns.
+	minGutter = 1.0
+	// paragraphGap is the distance between baselines from which a
+	// blank line is emitted.
+	paragraphGap = 1.8
+	// minColumnRows is the number of rows a gutter must run through
+	// for the text on its sides to be read as columns.
+	minColumnRows = 2
+	// maxColumnGap is the distance between baselines that ends a run
+	// of columns, so footers and notes are not read as part of them.
+	maxColumnGap = 4.0
+	// maxLayoutDepth bounds the recursive column splitting.
+	maxLayoutDepth = 8
+	// maxColumnRows is the number of rows of a page above which the
+	// reading mode emits them top to bottom without looking for
+	// columns, whose search grows with the square of the rows.
+	maxColumnRows = 5000
+)
+
+// layoutWord is a run of glyphs without a word gap.
+type layoutWord struct {
+	x1, x2 float64
+	text   string
+}
+
+// layoutRow is the words sharing a baseline, ordered by x.
+type layoutRow struct {
+	y     float64
+	size  float64 // largest font size on the row
+	words []layoutWord
+}
+
+// layoutText rebuilds the text of a page from its glyphs in the given
+// mode. It returns "" for LayoutRaw or when there are no glyphs.
+func layoutText(glyphs []pdf.Text, mode string) string {
+	if mode != LayoutReading && mode != LayoutPhysical {
+		return ""
+	}
+	rows := layoutRows(glyphs)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var lines []string
+	switch {
+	case mode == LayoutReading && len(rows) > maxColumnRows:
+		lines = readingLines(rows, maxLayoutDepth)
+	case mode == LayoutReading:
+		lines = readingLines(rows, 0)
+	default:
+		lines = physicalLines(rows, glyphs)
+	}
+
+	// Collapse the blank lines produced at block boundaries.
+	var b strings.Builder
+	blank := true
+	for _, l := range lines {
+		if l == "" {
+			if !blank {
+				b.WriteByte('\n')
+			}
+			blank = true
+			continue
+		}
+		b.WriteString(l)
+		b.WriteByte('\n')
+		blank = false
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// layoutRows groups glyphs into rows, top to bottom, and each row into
+// words. The content stream order is ignored; explicit spaces only
+// break words.
+func layoutRows(glyphs []pdf.Text) []layoutRow {
+	sorted := make([]pdf.Text, 0, len(glyphs))
+	for _, g := range advanceGlyphs(glyphs) {
+		if g.S != "" {
+			sorted = append(sorted, g)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Y > sorted[j].Y })
+
+	var rows []layoutRow
+	var row []pdf.Text
+	flush := func() {
+		if len(row) > 0 {
+			if r, ok := buildRow(row); ok {
+				rows = append(rows, r)
+			}
+		}
+		row = row[:0]
+	}
+	for _, g := range sorted {
+		if len(row) > 0 && row[0].Y-g.Y > rowTolerance*math.Max(row[0].FontSize, 1) {
+			flush()
+		}
+		row = append(row, g)
+	}
+	flush()
+	return rows
+}
+
+// buildRow splits the glyphs of a row into words. Glyphs drawn twice
+// at the same place, as producers do to fake bold text, are kept once.
+func buildRow(glyphs []pdf.Text) (layoutRow, bool) {
+	sort.SliceStable(glyphs, func(i, j int) bool { return glyphs[i].X < glyphs[j].X })
+
+	r := layoutRow{y: glyphs[0].Y}
+	var word strings.Builder
+	var cur layoutWord
+	var prev pdf.Text
+	started := false
+	flush := func() {
+		if word.Len() > 0 {
+			cur.text = word.String()
+			r.words = append(r.words, cur)
+		}
+		word.Reset()
+	}
+
+	for _, g := range glyphs {
+		r.size = math.Max(r.size, g.FontSize)
+		if strings.TrimSpace(g.S) == "" {
+			flush()
+			continue
+		}
+		size := math.Max(g.FontSize, 1)
+		if started && g.S == prev.S && math.Abs(g.X-prev.X) < 0.1*size {
+			continue
+		}
+		if word.Len() > 0 && g.X-(prev.X+glyphWidth(prev)) > wordGap*size {
+			flush()
+		}
+		if word.Len() == 0 {
+			cur = layoutWord{x1: g.X}
+		}
+		word.WriteString(g.S)
+		cur.x2 = g.X + glyphWidth(g)
+		prev, started = g, true
+	}
+	flush()
+	return r, len(r.words) > 0
+}
+
+// advanceGlyphs returns glyphs with their positions along each string
+// restored. The PDF library only advances the position by the glyph
+// widths of the font, so the glyphs of fonts without widths, such as
+// the standard 14 fonts, share the position of their string; they
+// are spread by their estimated width.
+func advanceGlyphs(glyphs []pdf.Text) []pdf.Text {
+	out := make([]pdf.Text, len(glyphs))
+	copy(out, glyphs)
+	for i := 1; i < len(out); i++ {
+		prev, g := glyphs[i-1], glyphs[i]
+		if g.W == 0 && prev.W == 0 && g.X == prev.X && g.Y == prev.Y && g.Font == prev.Font {
+			out[i].X = out[i-1].X + glyphWidth(prev)
+		}
+	}
+	return out
+}
+
+// glyphWidth returns the advance of g, estimated from its font size
+// when the font has no widths.
+func glyphWidth(g pdf.Text) float64 {
+	if g.W > 0 {
+		return g.W
+	}
+	return 0.5 * g.FontSize * float64(utf8.RuneCountInString(g.S))
+}
+
+// readingLines emits rows in reading order. Runs of rows crossed by a
+// gutter are split into columns, read left to right, each of which may
+// hold further columns; other rows are emitted as they are.
+func readingLines(rows []layoutRow, depth int) []string {
+	var lines []string
+	emit := func(r layoutRow, prev *layoutRow) {
+		if prev != nil && prev.y-r.y > paragraphGap*math.Max(prev.size, 1) {
+			lines = append(lines, "")
+		}
+		texts := make([]string, len(r.words))
+		for i, w := range r.words {
+			texts[i] = w.text
+		}
+		lines = append(lines, strings.Join(texts, " "))
+	}
+
+	// The gutters are looked for between the edges of the text.
+	left, right := math.Inf(1), math.Inf(-1)
+	for _, r := range rows {
+		left = math.Min(left, r.words[0].x1)
+		right = math.Max(right, r.words[len(r.words)-1].x2)
+	}
+
+	var prev *layoutRow
+	for i := 0; i < len(rows); {
+		end, gutter := i+1, 0.0
+		if depth < maxLayoutDepth {
+			end, gutter = columnRun(rows, i, left, right)
+		}
+		if end-i < minColumnRows {
+			emit(rows[i], prev)
+			prev = &rows[i]
+			i++
+			continue
+		}
+
+		left, right := splitRows(rows[i:end], gutter)
+		lines = append(lines, "")
+		lines = append(lines, readingLines(left, depth+1)...)
+		lines = append(lines, "")
+		lines = append(lines, readingLines(right, depth+1)...)
+		lines = append(lines, "")
+		prev = nil
+		i = end
+	}
+	return lines
+}
+
+// columnRun finds the longest run of rows starting at start that
+// share a gutter: an empty vertical strip at least minGutter wide,
+// away from left and right, the edges of the text, with text on both
+// sides. It returns the end of the run, exclusive, and the middle of
+// the widest shared strip.
+func columnRun(rows []layoutRow, start int, left, right float64) (end int, gutter float64) {
+	interior := func(free [][2]float64, size float64) [][2]float64 {
+		var out [][2]float64
+		for _, iv := range free {
+			if iv[0] > left && iv[1] < right && iv[1]-iv[0] >= minGutter*math.Max(size, 1) {
+				out = append(out, iv)
+			}
+		}
+		return out
+	}
+
+	size := rows[start].size
+	free := interior(freeIntervals(rows[start], left, right), size)
+	end = start + 1
+	for ; end < len(rows) && len(free) > 0; end++ {
+		if rows[end-1].y-rows[end].y > maxColumnGap*math.Max(size, 1) {
+			break
+		}
+		next := interior(intersect(free, freeIntervals(rows[end], left, right)), math.Max(size, rows[end].size))
+		if len(next) == 0 {
+			break
+		}
+		free, size = next, math.Max(size, rows[end].size)
+	}
+	if end-start < minColumnRows {
+		return start + 1, 0
+	}
+
+	// A strip with text on one side only is a margin of the run.
+	best := 0.0
+	for _, iv := range free {
+		var before, after bool
+		for _, r := range rows[start:end] {
+			before = before || r.words[0].x2 <= iv[0]
+			after = after || r.words[len(r.words)-1].x1 >= iv[1]
+		}
+		if before && after && iv[1]-iv[0] > best {
+			best, gutter = iv[1]-iv[0], (iv[0]+iv[1])/2
+		}
+	}
+	if best == 0 {
+		return start + 1, 0
+	}
+	return end, gutter
+}
+
+// freeIntervals returns the parts of [left, right] a row leaves empty.
+func freeIntervals(r layoutRow, left, right float64) [][2]float64 {
+	var free [][2]float64
+	x := left
+	for _, w := range r.words {
+		if w.x1 > x {
+			free = append(free, [2]float64{x, w.x1})
+		}
+		x = math.Max(x, w.x2)
+	}
+	if right > x {
+		free = append(free, [2]float64{x, right})
+	}
+	return free
+}
+
+// intersect returns the intersection of two sorted interval lists.
+func intersect(a, b [][2]float64) [][2]float64 {
+	var out [][2]float64
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := math.Max(a[i][0], b[j][0]), math.Min(a[i][1], b[j][1])
+		if lo < hi {
+			out = append(out, [2]float64{lo, hi})
+		}
+		if a[i][1] < b[j][1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// splitRows divides rows at the vertical line x = gutter, dropping
+// the rows left empty on either side.
+func splitRows(rows []layoutRow, gutter float64) (left, right []layoutRow) {
+	for _, r := range rows {
+		l, rr := r, r
+		l.words, rr.words = nil, nil
+		for _, w := range r.words {
+			if w.x2 <= gutter {
+				l.words = append(l.words, w)
+			} else {
+				rr.words = append(rr.words, w)
+			}
+		}
+		if len(l.words) > 0 {
+			left = append(left, l)
+		}
+		if len(rr.words) > 0 {
+			right = append(right, rr)
+		}
+	}
+	return left, right
+}
+
+// physicalLines renders rows on a character grid: each word starts at
+// the column matching its x position, at least one space after the
+// previous word.
+func physicalLines(rows []layoutRow, glyphs []pdf.Text) []string {
+	left := math.Inf(1)
+	for _, r := range rows {
+		left = math.Min(left, r.words[0].x1)
+	}
+	cell := charWidth(glyphs)
+
+	var lines []string
+	for i, r := range rows {
+		if i > 0 && rows[i-1].y-r.y > paragraphGap*math.Max(rows[i-1].size, 1) {
+			lines = append(lines, "")
+		}
+		var b strings.Builder
+		n := 0 // runes written
+		for _, w := range r.words {
+			col := int(math.Round((w.x1 - left) / cell))
+			if n > 0 {
+				col = max(col, n+1)
+			}
+			for ; n < col; n++ {
+				b.WriteByte(' ')
+			}
+			b.WriteString(w.text)
+			n += utf8.RuneCountInString(w.text)
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// charWidth returns the mean advance of the visible glyphs, the width
+// of one column of the physical layout.
+func charWidth(glyphs []pdf.Text) float64 {
+	var total float64
+	var n int
+	for _, g := range glyphs {
+		if strings.TrimSpace(g.S) == "" {
+			continue
+		}
+		total += glyphWidth(g)
+		n += utf8.RuneCountInString(g.S)
+	}
+	if n == 0 || total <= 0 {
+		return 1
+	}
+	return total / float64(n)
+}
@@ -0,0 +1,94 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// twoColumnPDF returns a page with a centered title, two columns
+// written line by line across the gutter, as many producers do, and
+// a footer.
+func twoColumnPDF(t *testing.T) string {
+	t.Helper()
+
+	var content strings.Builder
+	content.WriteString("BT /F1 10 Tf 1 0 0 1 250 750 Tm (Two Column Study) Tj ET\n")
+	for i, pair := range [][2]string{
+		{"left column one", "right column one"},
+		{"left column two", "right column two"},
+		{"left column three", "right column three"},
+	} {
+		y := 700 - 14*i
+		fmt.Fprintf(&content, "BT /F1 10 Tf 1 0 0 1 72 %d Tm (%s) Tj ET\n", y, pair[0])
+		fmt.Fprintf(&content, "BT /F1 10 Tf 1 0 0 1 320 %d Tm (%s) Tj ET\n", y, pair[1])
+	}
+	content.WriteString("BT /F1 10 Tf 1 0 0 1 72 60 Tm (Footer of the page) Tj ET")
+
+	return newTextPDF("", "", content.String()).write(t)
+}
+
+func TestAnalyzeFile_Layout(t *testing.T) {
+	path := twoColumnPDF(t)
+	a := NewPDFAnalyzer()
+
+	res, err := a.AnalyzeFile(context.Background(), path, WithLayout(LayoutReading))
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+	want := "Two Column Study\n\n" +
+		"left column one\nleft column two\nleft column three\n\n" +
+		"right column one\nright column two\nright column three\n\n" +
+		"Footer of the page\n"
+	if res.Content != want {
+		t.Errorf("reading order:\n got %q\nwant %q", res.Content, want)
+	}
+	if res.WordCount != 25 || res.Pages[0].WordCount != 25 {
+		t.Errorf("word count = %d (page %d), want 25", res.WordCount, res.Pages[0].WordCount)
+	}
+
+	res, err = a.AnalyzeFile(context.Background(), path, WithLayout(LayoutPhysical))
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+	lines := strings.Split(res.Content, "\n")
+	if len(lines) < 5 || !strings.HasPrefix(lines[2], "left column one ") || !strings.HasSuffix(lines[2], "   right column one") {
+		t.Fatalf("physical layout lost the columns:\n%s", res.Content)
+	}
+	// Words keep their horizontal position: the right column is
+	// aligned on every line, and the title is indented.
+	col := strings.Index(lines[2], "right")
+	for _, l := range lines[3:5] {
+		if strings.Index(l, "right") != col {
+			t.Errorf("right column not aligned:\n%s", res.Content)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "          ") {
+		t.Errorf("title not indented: %q", lines[0])
+	}
+
+	// The raw mode keeps the content stream order.
+	res, err = a.AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+	if i, j := strings.Index(res.Content, "right column one"), strings.Index(res.Content, "left column two"); i < 0 || j < i {
+		t.Errorf("raw mode reordered the text: %q", res.Content)
+	}
+}
+
+func TestLayoutText_ManyRows(t *testing.T) {
+	// A page of single glyphs on 40,000 rows is emitted top to
+	// bottom without a column search.
+	var glyphs []pdf.Text
+	for i := range 40000 {
+		glyphs = append(glyphs, pdf.Text{Font: "F1", FontSize: 1, X: 10, Y: float64(40000 - i), W: 0.5, S: "a"})
+	}
+	text := layoutText(glyphs, LayoutReading)
+	if n := strings.Count(text, "a\n"); n != 40000 {
+		t.Errorf("got %d rows, want 40000", n)
+	}
+}
@@ -0,0 +1,71 @@
+package pdfanalyzer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ledongthuc/pdf"
+)
+
+var (
+	ErrTooManyPages   = errors.New("document has too many pages")
+	ErrStreamTooLarge = errors.New("content stream exceeds the decompressed size limit")
+)
+
+// checkPageCount enforces the page limit.
+func (a *PDFAnalyzer) checkPageCount(numPages int) error {
+	if a.maxPages > 0 && numPages > a.maxPages {
+		return fmt.Errorf("%w: %d pages, limit is %d", ErrTooManyPages, numPages, a.maxPages)
+	}
+	return nil
+}
+
+// checkStreamSizes decompresses the page content streams up to the
+// configured limit, so a small, highly compressed stream (a "zip
+// bomb") is rejected before the text extractor buffers it in memory.
+func (a *PDFAnalyzer) checkStreamSizes(p pdf.Page, number int) error {
+	if a.maxStreamBytes <= 0 {
+		return nil
+	}
+
+	contents := p.V.Key("Contents")
+	streams := []pdf.Value{contents}
+	if contents.Kind() == pdf.Array {
+		streams = streams[:0]
+		for i := 0; i < contents.Len(); i++ {
+			streams = append(streams, contents.Index(i))
+		}
+	}
+
+	for _, s := range streams {
+		if s.Kind() != pdf.Stream {
+			continue
+		}
+		n, err := decodedSize(s, a.maxStreamBytes+1)
+		if err != nil {
+			// Undecodable streams are reported by the text extraction.
+			continue
+		}
+		if n > a.maxStreamBytes {
+			return fmt.Errorf("%w: page %d, limit is %d bytes", ErrStreamTooLarge, number, a.maxStreamBytes)
+		}
+	}
+	return nil
+}
+
+// decodedSize returns the decoded length of stream s, reading at most
+// limit bytes. The PDF library panics on filters it does not support,
+// which is turned into an error here.
+func decodedSize(s pdf.Value, limit int64) (n int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("decode stream: %v", r)
+		}
+	}()
+
+	rd := s.Reader()
+	defer rd.Close()
+
+	return io.Copy(io.Discard, io.LimitReader(rd, limit))
+}
@@ -0,0 +1,42 @@
+package pdfanalyzer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeFile_MaxPages(t *testing.T) {
+	path := newTextPDF("", "", textContent("one"), textContent("two"), textContent("three")).write(t)
+
+	if _, err := NewPDFAnalyzer(WithMaxPages(2)).AnalyzeFile(context.Background(), path); !errors.Is(err, ErrTooManyPages) {
+		t.Fatalf("expected ErrTooManyPages, got %v", err)
+	}
+	if _, err := NewPDFAnalyzer(WithMaxPages(3)).AnalyzeFile(context.Background(), path); err != nil {
+		t.Fatalf("expected document at the limit to be accepted, got %v", err)
+	}
+}
+
+func TestAnalyzeFile_MaxStreamBytes(t *testing.T) {
+	// A content stream that compresses ~100x: a miniature zip bomb.
+	content := textContent("bomb") + "\n" + strings.Repeat("% padding comment\n", 5000)
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte(content))
+	zw.Close()
+
+	b := newTextPDF("", "", "")
+	b.set(5, stream("/Filter /FlateDecode", compressed.String()))
+	path := b.write(t)
+
+	if _, err := NewPDFAnalyzer(WithMaxStreamBytes(1024)).AnalyzeFile(context.Background(), path); !errors.Is(err, ErrStreamTooLarge) {
+		t.Fatalf("expected ErrStreamTooLarge, got %v", err)
+	}
+	if _, err := NewPDFAnalyzer(WithMaxStreamBytes(int64(len(content)))).AnalyzeFile(context.Background(), path); err != nil {
+		t.Fatalf("expected stream at the limit to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package pdfanalyzer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Metadata holds the document information dictionary, the PDF
+// version and the decoded XMP packet of a file.
+type Metadata struct {
+	Title            string
+	Author           string
+	Subject          string
+	Keywords         string
+	Creator          string
+	Producer         string
+	CreationDate     time.Time // zero when absent or unparseable
+	ModificationDate time.Time // zero when absent or unparseable
+	PDFVersion       string    // e.g. "1.7"
+	PageCount        int
+	XMP              map[string]string // qualified XMP property name → value
+}
+
+// maxXMPBytes bounds how much of the XMP stream is read. Real packets
+// are a few kilobytes; anything larger is padding or abuse.
+const maxXMPBytes = 4 << 20
+
+var headerVersion = regexp.MustCompile(`%PDF-(\d\.\d)`)
+
+// readMetadata collects the document-level metadata. header holds the
+// first bytes of the file, used to read the version comment.
+func readMetadata(r *pdf.Reader, header []byte) Metadata {
+	info := r.Trailer().Key("Info")
+	root := r.Trailer().Key("Root")
+
+	md := Metadata{
+		Title:            info.Key("Title").Text(),
+		Author:           info.Key("Author").Text(),
+		Subject:          info.Key("Subject").Text(),
+		Keywords:         info.Key("Keywords").Text(),
+		Creator:          info.Key("Creator").Text(),
+		Producer:         info.Key("Producer").Text(),
+		CreationDate:     parsePDFDate(info.Key("CreationDate").Text()),
+		ModificationDate: parsePDFDate(info.Key("ModDate").Text()),
+		PDFVersion:       pdfVersion(header, root.Key("Version").Name()),
+		PageCount:        r.NumPage(),
+	}
+
+	if stream := root.Key("Metadata"); stream.Kind() == pdf.Stream {
+		if xmp, err := parseXMP(io.LimitReader(stream.Reader(), maxXMPBytes)); err == nil {
+			md.XMP = xmp
+		}
+	}
+	md.fillFromXMP()
+
+	return md
+}
+
+// fillFromXMP completes fields missing from the information
+// dictionary with their XMP equivalents. Newer producers often
+// write only the XMP packet.
+func (md *Metadata) fillFromXMP() {
+	if len(md.XMP) == 0 {
+		return
+	}
+
+	fill := func(dst *string, key string) {
+		if *dst == "" {
+			*dst = md.XMP[key]
+		}
+	}
+	fill(&md.Title, "dc:title")
+	fill(&md.Author, "dc:creator")
+	fill(&md.Subject, "dc:description")
+	fill(&md.Keywords, "pdf:Keywords")
+	fill(&md.Creator, "xmp:CreatorTool")
+	fill(&md.Producer, "pdf:Producer")
+
+	if md.CreationDate.IsZero() {
+		md.CreationDate = parseXMPDate(md.XMP["xmp:CreateDate"])
+	}
+	if md.ModificationDate.IsZero() {
+		md.ModificationDate = parseXMPDate(md.XMP["xmp:ModifyDate"])
+	}
+}
+
+// pdfVersion returns the effective PDF version. The catalog's
+// /Version entry overrides the header when it is later
+// (PDF 32000-1:2008, §7.7.2).
+func pdfVersion(header []byte, catalogVersion string) string {
+	version := ""
+	if m := headerVersion.FindSubmatch(header); m != nil {
+		version = string(m[1])
+	}
+	if catalogVersion != "" && catalogVersion > version {
+		version = catalogVersion
+	}
+	return version
+}
+
+var pdfDatePattern = regexp.MustCompile(
+	`^D:(\d{4})(\d{2})?(\d{2})?(\d{2})?(\d{2})?(\d{2})?(?:([Zz+\-])(\d{2})?'?(\d{2})?'?)?`,
+)
+
+// parsePDFDate parses a PDF date string such as
+// "D:20230415103000+02'00'" (PDF 32000-1:2008, §7.9.4).
+// Missing components default to their lowest value and a
+// missing offset is treated as UTC. The zero time is
+// returned when s cannot be parsed.
+func parsePDFDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s != "" && !strings.HasPrefix(s, "D:") {
+		// Some producers omit the prefix.
+		s = "D:" + s
+	}
+
+	m := pdfDatePattern.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}
+	}
+
+	num := func(s string, fallback int) int {
+		if s == "" {
+			return fallback
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	year := num(m[1], 0)
+	month := num(m[2], 1)
+	day := num(m[3], 1)
+	hour := num(m[4], 0)
+	minute := num(m[5], 0)
+	second := num(m[6], 0)
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}
+	}
+
+	loc := time.UTC
+	if sign := m[7]; sign == "+" || sign == "-" {
+		offset := num(m[8], 0)*3600 + num(m[9], 0)*60
+		if sign == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, loc)
+}
+
+// parseXMPDate parses the ISO 8601 subset used by XMP dates.
+func parseXMPDate(s string) time.Time {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04",
+		"2006-01-02",
+		"2006-01",
+		"2006",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+const rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// parseXMP decodes an XMP packet into flat key/value pairs keyed by
+// the qualified property name (e.g. "dc:title"). Properties
+// expressed both as attributes of rdf:Description and as child
+// elements are supported. Values of rdf:Alt, rdf:Seq and rdf:Bag
+// containers are joined with "; ".
+func parseXMP(r io.Reader) (map[string]string, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+
+	prefixes := map[string]string{}
+	out := map[string]string{}
+
+	var (
+		property string // qualified name of the property being read
+		depth    int    // element depth relative to property
+		text     bytes.Buffer
+		items    []string
+	)
+
+	qualify := func(n xml.Name) string {
+		if p, ok := prefixes[n.Space]; ok {
+			return p + ":" + n.Local
+		}
+		return n.Local
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					prefixes[attr.Value] = attr.Name.Local
+				}
+			}
+
+			if property != "" {
+				depth++
+				if t.Name.Space == rdfNamespace && t.Name.Local == "li" {
+					text.Reset()
+				}
+				continue
+			}
+
+			if t.Name.Space == rdfNamespace && t.Name.Local == "Description" {
+				for _, attr := range t.Attr {
+					if attr.Name.Space == "xmlns" || attr.Name.Space == rdfNamespace || attr.Name.Space == "" {
+						continue
+					}
+					out[qualify(attr.Name)] = strings.TrimSpace(attr.Value)
+				}
+				continue
+			}
+
+			if t.Name.Space != rdfNamespace && t.Name.Space != "adobe:ns:meta/" {
+				property = qualify(t.Name)
+				depth = 0
+				text.Reset()
+				items = items[:0]
+			}
+
+		case xml.CharData:
+			if property != "" {
+				text.Write(t)
+			}
+
+		case xml.EndElement:
+			if property == "" {
+				continue
+			}
+			if depth > 0 {
+				if t.Name.Space == rdfNamespace && t.Name.Local == "li" {
+					if v := strings.TrimSpace(text.String()); v != "" {
+						items = append(items, v)
+					}
+					text.Reset()
+				}
+				depth--
+				continue
+			}
+
+			value := strings.TrimSpace(text.String())
+			if len(items) > 0 {
+				value = strings.Join(items, "; ")
+			}
+			if value != "" {
+				out[property] = value
+			}
+			property = ""
+		}
+	}
+
+	return out, nil
+}
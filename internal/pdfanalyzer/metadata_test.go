@@ -0,0 +1,141 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"full with offset", "D:20230415103000+02'00'", "2023-04-15T10:30:00+02:00"},
+		{"negative offset", "D:20230415103000-05'30'", "2023-04-15T10:30:00-05:30"},
+		{"utc", "D:20230415103000Z", "2023-04-15T10:30:00Z"},
+		{"utc with zero offset", "D:20230415103000Z00'00'", "2023-04-15T10:30:00Z"},
+		{"no offset", "D:20230415103000", "2023-04-15T10:30:00Z"},
+		{"year only", "D:2023", "2023-01-01T00:00:00Z"},
+		{"missing prefix", "20230415", "2023-04-15T00:00:00Z"},
+		{"offset without apostrophes", "D:20230415103000+0200", "2023-04-15T10:30:00+02:00"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parsePDFDate(tc.input)
+			if got.IsZero() {
+				t.Fatalf("parsePDFDate(%q) returned zero time", tc.input)
+			}
+			if s := got.Format(time.RFC3339); s != tc.expected {
+				t.Errorf("parsePDFDate(%q) = %s; want %s", tc.input, s, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParsePDFDate_Invalid(t *testing.T) {
+	for _, input := range []string{"", "yesterday", "D:20231345", "D:abcd"} {
+		if got := parsePDFDate(input); !got.IsZero() {
+			t.Errorf("parsePDFDate(%q) = %v; want zero time", input, got)
+		}
+	}
+}
+
+func TestPDFVersion(t *testing.T) {
+	if got := pdfVersion([]byte("%PDF-1.4\n%..."), ""); got != "1.4" {
+		t.Errorf("expected header version 1.4, got %q", got)
+	}
+	if got := pdfVersion([]byte("%PDF-1.4\n"), "1.7"); got != "1.7" {
+		t.Errorf("expected catalog version 1.7 to win, got %q", got)
+	}
+	if got := pdfVersion([]byte("%PDF-1.7\n"), "1.3"); got != "1.7" {
+		t.Errorf("expected older catalog version to be ignored, got %q", got)
+	}
+}
+
+const sampleXMP = `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:pdf="http://ns.adobe.com/pdf/1.3/"
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    pdf:Producer="Acme PDF Library"
+    xmp:CreateDate="2021-03-04T05:06:07+01:00">
+   <dc:title><rdf:Alt><rdf:li xml:lang="x-default">Service Contract</rdf:li></rdf:Alt></dc:title>
+   <dc:creator><rdf:Seq><rdf:li>Ana</rdf:li><rdf:li>Bruno</rdf:li></rdf:Seq></dc:creator>
+   <xmp:CreatorTool>Writer</xmp:CreatorTool>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+func TestParseXMP(t *testing.T) {
+	got, err := parseXMP(strings.NewReader(sampleXMP))
+	if err != nil {
+		t.Fatalf("parseXMP returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"pdf:Producer":    "Acme PDF Library",
+		"xmp:CreateDate":  "2021-03-04T05:06:07+01:00",
+		"dc:title":        "Service Contract",
+		"dc:creator":      "Ana; Bruno",
+		"xmp:CreatorTool": "Writer",
+	}
+	for key, want := range expected {
+		if got[key] != want {
+			t.Errorf("xmp[%q] = %q; want %q", key, got[key], want)
+		}
+	}
+}
+
+func TestAnalyzeFile_Metadata(t *testing.T) {
+	b := newTextPDF("/Version /1.7 /Metadata 6 0 R", "", textContent("Hello"))
+	b.add(stream("/Type /Metadata /Subtype /XML", sampleXMP))
+	b.add("<< /Author (Jane Doe) /Keywords (contract, legal) /CreationDate (D:20200102030405Z) >>")
+	b.trailer = "/Info 7 0 R"
+
+	result, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("AnalyzeFile returned error: %v", err)
+	}
+
+	md := result.Metadata
+	if md.Author != "Jane Doe" {
+		t.Errorf("expected author from info dictionary, got %q", md.Author)
+	}
+	if md.Title != "Service Contract" {
+		t.Errorf("expected title from XMP, got %q", md.Title)
+	}
+	if md.Keywords != "contract, legal" {
+		t.Errorf("expected keywords, got %q", md.Keywords)
+	}
+	if want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC); !md.CreationDate.Equal(want) {
+		t.Errorf("expected creation date %v, got %v", want, md.CreationDate)
+	}
+	if md.PDFVersion != "1.7" || md.PageCount != 1 {
+		t.Errorf("expected version 1.7 and 1 page, got %q and %d", md.PDFVersion, md.PageCount)
+	}
+	if md.XMP["pdf:Producer"] != "Acme PDF Library" {
+		t.Errorf("expected XMP producer, got %q", md.XMP["pdf:Producer"])
+	}
+}
+
+func TestAnalyzeFile_SimplePDFMetadata(t *testing.T) {
+	result, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), filepath.Join("testdata", "simple.pdf"))
+	if err != nil {
+		t.Fatalf("AnalyzeFile returned error: %v", err)
+	}
+
+	if result.Metadata.Title != "teste" {
+		t.Errorf("expected title 'teste', got %q", result.Metadata.Title)
+	}
+	if result.Metadata.PDFVersion != "1.4" {
+		t.Errorf("expected version 1.4, got %q", result.Metadata.PDFVersion)
+	}
+}
@@ -0,0 +1,101 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/tokenizer"
+)
+
+// ExportScans exports the scans of pages in one pass over the file,
+// for OCR. scans maps a page number to the 1-based number of its scan
+// in the inventory returned by AnalyzeFile. The images are returned by
+// page number, as ExtractImage exports them; a page whose scan cannot
+// be exported is left out. Errors are reported as in AnalyzeFile.
+func (a *PDFAnalyzer) ExportScans(ctx context.Context, filePath string, scans map[int]int, opts ...FileOption) (images map[int]ImageData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			images, err = nil, recoverError(r)
+		}
+	}()
+
+	var o fileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := openReader(file, o.password)
+	if err != nil {
+		return nil, err
+	}
+
+	numPages := content.NumPage()
+	if err := a.checkPageCount(numPages); err != nil {
+		return nil, err
+	}
+	last := 0
+	for page := range scans {
+		last = max(last, page)
+	}
+	encrypted := !content.Trailer().Key("Encrypt").IsNull()
+
+	images = make(map[int]ImageData, len(scans))
+	seen := 0
+	for i := 1; i <= min(numPages, last); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		placed, _ := pageImages(content.Page(i), i, maxImages-seen)
+		n, ok := scans[i]
+		first := seen
+		seen += len(placed)
+		if !ok || n <= first || n > seen {
+			continue
+		}
+
+		img, err := a.exportImage(file, placed[n-first-1], encrypted)
+		if err != nil {
+			continue
+		}
+		images[i] = img
+	}
+	return images, nil
+}
+
+// ContentStats is the text of a document, its page texts joined in
+// order, with its counts and language.
+type ContentStats struct {
+	Content        string
+	WordCount      int
+	TokenCount     int
+	SentenceCount  int
+	ParagraphCount int
+	CharCount      int // non-whitespace characters
+	Language       Language
+}
+
+// CountContent joins the page texts with a blank line and counts the
+// document as AnalyzeFile does. The blank line keeps a word or sentence
+// from running on from one page into the next. Callers that replace page texts, as OCR does, use
+// it to count the new pages, one at a time, and recount the document.
+func CountContent(pageTexts []string) ContentStats {
+	text := strings.Join(pageTexts, "\n\n")
+	language := detectLanguage(text)
+	stats := tokenizer.AnalyzeLanguage(text, language.Code)
+	return ContentStats{
+		Content:        text,
+		WordCount:      stats.Words,
+		TokenCount:     stats.Tokens,
+		SentenceCount:  stats.Sentences,
+		ParagraphCount: stats.Paragraphs,
+		CharCount:      countChars(text),
+		Language:       language,
+	}
+}
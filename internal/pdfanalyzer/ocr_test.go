@@ -0,0 +1,87 @@
+package pdfanalyzer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"testing"
+)
+
+// scannedPDF builds a document whose pages each draw one JPEG, page n
+// a square of 8n pixels.
+func scannedPDF(t *testing.T, pages int) string {
+	t.Helper()
+
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := ""
+	for i := range pages {
+		kids += fmt.Sprintf("%d 0 R ", 3+3*i)
+	}
+	b.add(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, pages))
+	for i := range pages {
+		size := 8 * (i + 1)
+		var jpg bytes.Buffer
+		if err := jpeg.Encode(&jpg, image.NewGray(image.Rect(0, 0, size, size)), nil); err != nil {
+			t.Fatal(err)
+		}
+		b.add(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /XObject << /Scan %d 0 R >> >> /Contents %d 0 R >>", 5+3*i, 4+3*i))
+		b.add(stream("", "q 612 0 0 792 0 0 cm /Scan Do Q"))
+		b.add(stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /BitsPerComponent 8 /ColorSpace /DeviceGray /Filter /DCTDecode", size, size), jpg.String()))
+	}
+	return b.write(t)
+}
+
+func TestExportScans(t *testing.T) {
+	path := scannedPDF(t, 4)
+
+	images, err := NewPDFAnalyzer().ExportScans(context.Background(), path, map[int]int{1: 1, 3: 3, 4: 4, 6: 6})
+	if err != nil {
+		t.Fatalf("ExportScans: %v", err)
+	}
+	var sizes []string
+	for _, page := range []int{1, 2, 3, 4, 6} {
+		img, ok := images[page]
+		if !ok {
+			continue
+		}
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(img.Data))
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		sizes = append(sizes, fmt.Sprintf("%d:%d", page, cfg.Width))
+	}
+	if fmt.Sprint(sizes) != "[1:8 3:24 4:32]" {
+		t.Errorf("exported scans = %v, want pages 1, 3 and 4", sizes)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewPDFAnalyzer().ExportScans(ctx, path, map[int]int{1: 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCountContent(t *testing.T) {
+	// The sentences are counted with the abbreviations of the language
+	// of the document.
+	stats := CountContent([]string{
+		"O Sr. Silva assinou o contrato de prestação de serviços.\n",
+		"A vigência é de um ano.\n",
+	})
+	if stats.Content != "O Sr. Silva assinou o contrato de prestação de serviços.\n\n\nA vigência é de um ano.\n" {
+		t.Errorf("content = %q", stats.Content)
+	}
+	if stats.Language.Code != "pt" || stats.WordCount != 16 || stats.SentenceCount != 2 || stats.CharCount != 65 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// A page ending mid-word does not run into the next one.
+	stats = CountContent([]string{"The contract was sig", "ned in May."})
+	if stats.Content != "The contract was sig\n\nned in May." || stats.WordCount != 7 || stats.ParagraphCount != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
@@ -0,0 +1,78 @@
+package pdfanalyzer
+
+// Option configures a PDFAnalyzer.
+type Option func(*PDFAnalyzer)
+
+// WithMaxPages rejects documents with more than n pages.
+// Zero means no limit.
+func WithMaxPages(n int) Option {
+	return func(a *PDFAnalyzer) {
+		a.maxPages = n
+	}
+}
+
+// WithMaxStreamBytes rejects documents where a page content stream
+// decompresses to more than n bytes. Zero means no limit.
+func WithMaxStreamBytes(n int64) Option {
+	return func(a *PDFAnalyzer) {
+		a.maxStreamBytes = n
+	}
+}
+
+// FileOption configures a single AnalyzeFile call.
+type FileOption func(*fileOptions)
+
+type fileOptions struct {
+	password string
+	layout   string
+	pages    []PageRange
+}
+
+// WithPassword opens encrypted documents with password, tried as the
+// user password and then as the owner password.
+func WithPassword(password string) FileOption {
+	return func(o *fileOptions) {
+		o.password = password
+	}
+}
+
+// WithLayout selects how the text of each page is extracted: LayoutRaw
+// (the default), LayoutReading or LayoutPhysical. Unknown modes are
+// treated as LayoutRaw.
+func WithLayout(mode string) FileOption {
+	return func(o *fileOptions) {
+		o.layout = mode
+	}
+}
+
+// PageRange is an inclusive range of 1-based page numbers. A Last of
+// zero runs to the end of the document.
+type PageRange struct {
+	First, Last int
+}
+
+// Contains reports whether page n is in r.
+func (r PageRange) Contains(n int) bool {
+	return n >= r.First && (r.Last == 0 || n <= r.Last)
+}
+
+// WithPages limits ExtractTables to the pages in any of ranges. Pages
+// beyond the document are ignored; no ranges means every page.
+func WithPages(ranges ...PageRange) FileOption {
+	return func(o *fileOptions) {
+		o.pages = ranges
+	}
+}
+
+// wantsPage reports whether page n was selected with WithPages.
+func (o fileOptions) wantsPage(n int) bool {
+	if len(o.pages) == 0 {
+		return true
+	}
+	for _, r := range o.pages {
+		if r.Contains(n) {
+			return true
+		}
+	}
+	return false
+}
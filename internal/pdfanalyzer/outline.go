@@ -0,0 +1,203 @@
+package pdfanalyzer
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Outline sources.
+const (
+	OutlineBookmarks = "bookmarks" // read from the /Outlines dictionary
+	OutlineHeadings  = "headings"  // derived from font sizes and weights
+)
+
+// Outline is the navigation tree of a document.
+type Outline struct {
+	Source string        // OutlineBookmarks, OutlineHeadings, or "" when empty
+	Items  []OutlineItem // top-level entries, in document order
+}
+
+// OutlineItem is one entry of the outline tree.
+type OutlineItem struct {
+	Title    string
+	Level    int // nesting depth, 1 for top-level entries
+	Page     int // 1-based destination page, 0 when unknown
+	Children []OutlineItem
+}
+
+// Bounds on the outline walk. They guard against cyclic /Next chains
+// and absurdly deep trees in broken or hostile files.
+const (
+	maxOutlineItems = 10000
+	maxOutlineDepth = 32
+)
+
+// objectID identifies an indirect object of the file.
+type objectID struct {
+	num uint32
+	gen uint16
+}
+
+// objectIDOf returns the object that v was read from. The PDF library
+// keeps it in an unexported field, so it is read through reflection.
+// ok is false when the field cannot be read.
+func objectIDOf(v pdf.Value) (id objectID, ok bool) {
+	ptr := reflect.ValueOf(v).FieldByName("ptr")
+	if !ptr.IsValid() || ptr.Kind() != reflect.Struct || ptr.NumField() != 2 {
+		return objectID{}, false
+	}
+	id = objectID{num: uint32(ptr.Field(0).Uint()), gen: uint16(ptr.Field(1).Uint())}
+	return id, id.num != 0
+}
+
+// hasBookmarks reports whether the document has a non-empty outline.
+func hasBookmarks(r *pdf.Reader) bool {
+	return r.Trailer().Key("Root").Key("Outlines").Key("First").Kind() == pdf.Dict
+}
+
+// readBookmarks reads the /Outlines tree, resolving destinations
+// with dests.
+func readBookmarks(r *pdf.Reader, dests destinations) Outline {
+	b := bookmarkReader{
+		dests: dests,
+		seen:  make(map[objectID]bool),
+	}
+
+	items := b.children(r.Trailer().Key("Root").Key("Outlines"), 1)
+	if len(items) == 0 {
+		return Outline{}
+	}
+	return Outline{Source: OutlineBookmarks, Items: items}
+}
+
+type bookmarkReader struct {
+	dests destinations
+	seen  map[objectID]bool
+	count int
+}
+
+func (b *bookmarkReader) children(parent pdf.Value, level int) []OutlineItem {
+	if level > maxOutlineDepth {
+		return nil
+	}
+
+	var items []OutlineItem
+	for node := parent.Key("First"); node.Kind() == pdf.Dict; node = node.Key("Next") {
+		if id, ok := objectIDOf(node); ok {
+			if b.seen[id] {
+				break
+			}
+			b.seen[id] = true
+		}
+		if b.count >= maxOutlineItems {
+			break
+		}
+		b.count++
+
+		items = append(items, OutlineItem{
+			Title:    cleanTitle(node.Key("Title").Text()),
+			Level:    level,
+			Page:     b.dests.page(destinationOf(node)),
+			Children: b.children(node, level+1),
+		})
+	}
+	return items
+}
+
+// destinationOf returns the /Dest of an outline item or link
+// annotation, or the destination of its GoTo action.
+func destinationOf(node pdf.Value) pdf.Value {
+	if dest := node.Key("Dest"); !dest.IsNull() {
+		return dest
+	}
+	if action := node.Key("A"); action.Key("S").Name() == "GoTo" {
+		return action.Key("D")
+	}
+	return pdf.Value{}
+}
+
+// destinations resolves destinations to page numbers (PDF
+// 32000-1:2008, §12.3.2).
+type destinations struct {
+	root  pdf.Value        // document catalog, for named destinations
+	pages map[objectID]int // page objects to page numbers
+}
+
+// page returns the page number dest points to, or 0.
+func (d destinations) page(dest pdf.Value) int {
+	// Named destinations may point to a dictionary holding the
+	// explicit destination; the bound stops reference loops.
+	for range 4 {
+		switch dest.Kind() {
+		case pdf.Array:
+			if id, ok := objectIDOf(dest.Index(0)); ok {
+				return d.pages[id]
+			}
+			return 0
+		case pdf.Dict:
+			dest = dest.Key("D")
+		case pdf.Name:
+			dest = d.named(dest.Name())
+		case pdf.String:
+			dest = d.named(dest.RawString())
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// named looks name up in the /Dests name tree and in the older /Dests
+// dictionary of the catalog.
+func (d destinations) named(name string) pdf.Value {
+	if v := lookupNameTree(d.root.Key("Names").Key("Dests"), name, 0); !v.IsNull() {
+		return v
+	}
+	return d.root.Key("Dests").Key(name)
+}
+
+// lookupNameTree finds key in a name tree (PDF 32000-1:2008, §7.9.6).
+func lookupNameTree(node pdf.Value, key string, depth int) pdf.Value {
+	if node.Kind() != pdf.Dict || depth > maxOutlineDepth {
+		return pdf.Value{}
+	}
+
+	names := node.Key("Names")
+	for i := 0; i+1 < names.Len(); i += 2 {
+		if names.Index(i).RawString() == key {
+			return names.Index(i + 1)
+		}
+	}
+
+	kids := node.Key("Kids")
+	for i := 0; i < kids.Len(); i++ {
+		kid := kids.Index(i)
+		if limits := kid.Key("Limits"); limits.Len() == 2 {
+			if key < limits.Index(0).RawString() || key > limits.Index(1).RawString() {
+				continue
+			}
+		}
+		if v := lookupNameTree(kid, key, depth+1); !v.IsNull() {
+			return v
+		}
+	}
+	return pdf.Value{}
+}
+
+// cleanTitle collapses whitespace and drops control characters, which
+// some producers leave in bookmark titles.
+func cleanTitle(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,126 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeFile_Bookmarks(t *testing.T) {
+	// Objects 1-7 are the catalog, page tree, font and two pages;
+	// the outline starts at 8.
+	b := newTextPDF(
+		"/Outlines 8 0 R /Names << /Dests << /Names [(scope) [4 0 R /XYZ 0 792 0]] >> >> /Dests << /appendix << /D [6 0 R /Fit] >> >>",
+		"",
+		textContent("Introduction text"), textContent("Appendix text"),
+	)
+	b.add("<< /Type /Outlines /First 9 0 R /Last 10 0 R /Count 3 >>")
+	b.add(fmt.Sprintf("<< /Title (Introduction) /Parent 8 0 R /Next 10 0 R /Dest [%d 0 R /Fit] /First 11 0 R /Last 11 0 R >>", pageObject(1)))
+	b.add("<< /Title (Appendix) /Parent 8 0 R /Prev 9 0 R /Dest /appendix >>")
+	b.add("<< /Title (  Scope\\r\\n and goals ) /Parent 9 0 R /A << /S /GoTo /D (scope) >> >>")
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Outline{Source: OutlineBookmarks, Items: []OutlineItem{
+		{Title: "Introduction", Level: 1, Page: 1, Children: []OutlineItem{
+			{Title: "Scope and goals", Level: 2, Page: 1},
+		}},
+		{Title: "Appendix", Level: 1, Page: 2},
+	}}
+	if got, want := formatOutline(res.Outline), formatOutline(want); got != want {
+		t.Errorf("outline:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAnalyzeFile_BookmarkCycle(t *testing.T) {
+	b := newTextPDF("/Outlines 6 0 R", "", textContent("text"))
+	b.add("<< /Type /Outlines /First 7 0 R >>")
+	b.add("<< /Title (Loop) /Parent 6 0 R /Next 7 0 R /First 7 0 R >>")
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Outline.Items) != 1 || len(res.Outline.Items[0].Children) != 0 {
+		t.Errorf("expected the cycle to be cut after one item, got %s", formatOutline(res.Outline))
+	}
+}
+
+func TestAnalyzeFile_HeadingOutline(t *testing.T) {
+	body := func(y int) string {
+		return fmt.Sprintf("BT /F1 12 Tf 72 %d Td (This is ordinary body text that fills the page.) Tj ET\n", y)
+	}
+
+	b := newTextPDF("", "",
+		"BT /F2 20 Tf 72 740 Td (1 Installation) Tj ET\n"+
+			body(710)+body(690)+
+			"BT /F2 12 Tf 72 660 Td (1.1 Requirements) Tj ET\n"+
+			body(640)+body(620)+
+			"BT /F1 12 Tf 72 600 Td (A sentence with a ) Tj /F2 12 Tf (bold) Tj /F1 12 Tf ( word stays body text.) Tj ET\n",
+		// A heading wrapped over two lines is one entry.
+		"BT /F2 20 Tf 72 740 Td (2 Configuration of the) Tj 0 -22 Td (service) Tj ET\n"+
+			body(690)+body(670)+body(650),
+	)
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), b.write(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Outline{Source: OutlineHeadings, Items: []OutlineItem{
+		{Title: "1 Installation", Level: 1, Page: 1, Children: []OutlineItem{
+			{Title: "1.1 Requirements", Level: 2, Page: 1},
+		}},
+		{Title: "2 Configuration of the service", Level: 1, Page: 2},
+	}}
+	if got, want := formatOutline(res.Outline), formatOutline(want); got != want {
+		t.Errorf("outline:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAnalyzeFile_NoOutline(t *testing.T) {
+	path := newTextPDF("", "", textContent("just", "plain", "body", "text")).write(t)
+
+	res, err := NewPDFAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Outline.Source != "" || len(res.Outline.Items) != 0 {
+		t.Errorf("expected no outline, got %s", formatOutline(res.Outline))
+	}
+}
+
+func TestNestOutline(t *testing.T) {
+	items := []OutlineItem{{Title: "a"}, {Title: "b"}, {Title: "c"}, {Title: "d"}, {Title: "e"}}
+	// A level-3 item directly under a level-1 item nests one level
+	// deeper, not two.
+	tree := nestOutline(items, []int{1, 3, 2, 1, 2})
+
+	got := formatOutline(Outline{Items: tree})
+	want := "a 1 p0\n  b 2 p0\n  c 2 p0\nd 1 p0\n  e 2 p0\n"
+	if got != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// formatOutline renders an outline one item per line, indented by
+// depth, for readable comparisons.
+func formatOutline(o Outline) string {
+	var sb strings.Builder
+	var walk func(items []OutlineItem, indent string)
+	walk = func(items []OutlineItem, indent string) {
+		for _, it := range items {
+			fmt.Fprintf(&sb, "%s%s %d p%d\n", indent, it.Title, it.Level, it.Page)
+			walk(it.Children, indent+"  ")
+		}
+	}
+	if o.Source != "" {
+		sb.WriteString(o.Source + ":\n")
+	}
+	walk(o.Items, "")
+	return sb.String()
+}
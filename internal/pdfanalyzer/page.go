@@ -0,0 +1,86 @@
+package pdfanalyzer
+
+import (
+	"math"
+	"unicode"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// analyzePage extracts the text of a single page and collects
+// its basic geometry. fonts is shared across pages so that
+// font encodings are parsed only once per document.
+func analyzePage(p pdf.Page, number int, fonts map[string]*pdf.Font) (PageAnalysis, error) {
+	for _, name := range p.Fonts() {
+		if _, ok := fonts[name]; !ok {
+			f := p.Font(name)
+			fonts[name] = &f
+		}
+	}
+
+	text, err := p.GetPlainText(fonts)
+	if err != nil {
+		return PageAnalysis{}, err
+	}
+
+	width, height := pageSize(p)
+
+	return PageAnalysis{
+		Number:    number,
+		Text:      text,
+		WordCount: countWords(text),
+		CharCount: countChars(text),
+		Width:     width,
+		Height:    height,
+		Rotation:  pageRotation(p),
+	}, nil
+}
+
+// inheritedKey looks up key on the page dictionary and, when it is
+// absent, on its ancestors in the page tree. MediaBox and Rotate
+// are inheritable attributes (PDF 32000-1:2008, §7.7.3.4).
+func inheritedKey(p pdf.Page, key string) pdf.Value {
+	node := p.V
+	// The depth limit guards against cyclic /Parent chains in broken files.
+	for depth := 0; depth < 64 && !node.IsNull(); depth++ {
+		if v := node.Key(key); !v.IsNull() {
+			return v
+		}
+		node = node.Key("Parent")
+	}
+	return pdf.Value{}
+}
+
+// pageSize returns the width and height of the page's MediaBox.
+func pageSize(p pdf.Page) (float64, float64) {
+	box := inheritedKey(p, "MediaBox")
+	if box.Len() != 4 {
+		return 0, 0
+	}
+
+	width := math.Abs(box.Index(2).Float64() - box.Index(0).Float64())
+	height := math.Abs(box.Index(3).Float64() - box.Index(1).Float64())
+	return width, height
+}
+
+// pageRotation returns the page's /Rotate value normalized to
+// one of 0, 90, 180 or 270.
+func pageRotation(p pdf.Page) int {
+	rotate := int(inheritedKey(p, "Rotate").Int64())
+	rotate %= 360
+	if rotate < 0 {
+		rotate += 360
+	}
+	return rotate - rotate%90
+}
+
+// countChars counts the non-whitespace characters in text.
+func countChars(text string) int {
+	count := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,248 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"math"
+	"os"
+
+	"github.com/ledongthuc/pdf"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/pdftables"
+)
+
+// Table is a table found on a page; see the pdftables package.
+type Table = pdftables.Table
+
+// Bounds on the table extraction: tables per document, and ruling
+// segments read per page.
+const (
+	maxTables  = 10000
+	maxRulings = 20000
+)
+
+// ExtractTables finds the tables of the document, page by page, from
+// their ruling lines and the alignment of their words. WithPages
+// limits the pages searched. Errors are reported as in AnalyzeFile.
+func (a *PDFAnalyzer) ExtractTables(ctx context.Context, filePath string, opts ...FileOption) (tables []Table, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			tables, err = nil, recoverError(r)
+		}
+	}()
+
+	var o fileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := openReader(file, o.password)
+	if err != nil {
+		return nil, err
+	}
+
+	numPages := content.NumPage()
+	if err := a.checkPageCount(numPages); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i <= numPages && len(tables) < maxTables; i++ {
+		if !o.wantsPage(i) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		p := content.Page(i)
+		if err := a.checkStreamSizes(p, i); err != nil {
+			return nil, err
+		}
+
+		found, err := pdftables.Detect(ctx, tableLines(pageGlyphs(p)), pageRulings(p))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range found {
+			if len(tables) == maxTables {
+				break
+			}
+			t.Page = i
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+// tableLines converts the rows rebuilt for the layout modes into the
+// lines of the table detector.
+func tableLines(glyphs []pdf.Text) []pdftables.Line {
+	rows := layoutRows(glyphs)
+	lines := make([]pdftables.Line, 0, len(rows))
+	for _, r := range rows {
+		l := pdftables.Line{Y: r.y, Size: r.size}
+		for _, w := range r.words {
+			l.Words = append(l.Words, pdftables.Word{X1: w.x1, X2: w.x2, Y: r.y, Size: r.size, Text: w.text})
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// pageRulings returns the straight segments a page strokes, and the
+// thin rectangles it fills, which producers also use to draw lines.
+// Reading the content is best effort; a failure only loses the
+// segments found so far.
+func pageRulings(p pdf.Page) []pdftables.Segment {
+	var w rulingWalker
+	func() {
+		defer func() { _ = recover() }()
+		w.walk(p.V.Key("Contents"), p.Resources(), identity, 0)
+	}()
+	return w.segments
+}
+
+// thinRect is the largest width of a filled rectangle read as a line.
+const thinRect = 2.0
+
+type rulingWalker struct {
+	segments []pdftables.Segment
+}
+
+// walk interprets the path operators of a content stream (PDF
+// 32000-1:2008, §8.5), following the current transformation matrix.
+// Curves only move the current point.
+func (w *rulingWalker) walk(content, resources pdf.Value, ctm matrix, depth int) {
+	var (
+		stack       []matrix
+		edges       []pdftables.Segment // lines of the current path
+		rects       []pdftables.Rect    // rectangles of the current path
+		cur, start  [2]float64
+		haveCurrent bool
+	)
+	point := func(x, y float64) [2]float64 {
+		return [2]float64{x*ctm[0] + y*ctm[2] + ctm[4], x*ctm[1] + y*ctm[3] + ctm[5]}
+	}
+	lineTo := func(to [2]float64) {
+		if haveCurrent {
+			edges = append(edges, pdftables.Segment{X1: cur[0], Y1: cur[1], X2: to[0], Y2: to[1]})
+		}
+		cur, haveCurrent = to, true
+	}
+	emit := func(segments ...pdftables.Segment) {
+		for _, s := range segments {
+			if len(w.segments) < maxRulings {
+				w.segments = append(w.segments, s)
+			}
+		}
+	}
+	// paint ends the current path. Filled rectangles only count when
+	// they are thin and not also stroked.
+	paint := func(close, stroke, fill bool) {
+		if close {
+			lineTo(start)
+		}
+		if stroke {
+			emit(edges...)
+		}
+		if fill && !stroke {
+			for _, r := range rects {
+				switch {
+				case r.Y2-r.Y1 <= thinRect:
+					y := (r.Y1 + r.Y2) / 2
+					emit(pdftables.Segment{X1: r.X1, Y1: y, X2: r.X2, Y2: y})
+				case r.X2-r.X1 <= thinRect:
+					x := (r.X1 + r.X2) / 2
+					emit(pdftables.Segment{X1: x, Y1: r.Y1, X2: x, Y2: r.Y2})
+				}
+			}
+		}
+		edges, rects, haveCurrent = edges[:0], rects[:0], false
+	}
+
+	pdf.Interpret(content, func(stk *pdf.Stack, op string) {
+		n := stk.Len()
+		args := make([]pdf.Value, n)
+		for i := n - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			if len(args) == 6 {
+				var m matrix
+				for i := range m {
+					m[i] = args[i].Float64()
+				}
+				ctm = m.mul(ctm)
+			}
+		case "m":
+			if len(args) == 2 {
+				cur = point(args[0].Float64(), args[1].Float64())
+				start, haveCurrent = cur, true
+			}
+		case "l":
+			if len(args) == 2 {
+				lineTo(point(args[0].Float64(), args[1].Float64()))
+			}
+		case "c", "v", "y":
+			if len(args) >= 4 {
+				cur = point(args[len(args)-2].Float64(), args[len(args)-1].Float64())
+			}
+		case "h":
+			lineTo(start)
+		case "re":
+			if len(args) == 4 {
+				x, y := args[0].Float64(), args[1].Float64()
+				wd, ht := args[2].Float64(), args[3].Float64()
+				corners := [4][2]float64{point(x, y), point(x+wd, y), point(x+wd, y+ht), point(x, y+ht)}
+				r := pdftables.Rect{X1: math.Inf(1), Y1: math.Inf(1), X2: math.Inf(-1), Y2: math.Inf(-1)}
+				for i, c := range corners {
+					next := corners[(i+1)%4]
+					edges = append(edges, pdftables.Segment{X1: c[0], Y1: c[1], X2: next[0], Y2: next[1]})
+					r.X1, r.X2 = math.Min(r.X1, c[0]), math.Max(r.X2, c[0])
+					r.Y1, r.Y2 = math.Min(r.Y1, c[1]), math.Max(r.Y2, c[1])
+				}
+				rects = append(rects, r)
+				cur, start, haveCurrent = corners[0], corners[0], true
+			}
+		case "S":
+			paint(false, true, false)
+		case "s":
+			paint(true, true, false)
+		case "f", "F", "f*":
+			paint(false, false, true)
+		case "B", "B*":
+			paint(false, true, true)
+		case "b", "b*":
+			paint(true, true, true)
+		case "n":
+			paint(false, false, false)
+		case "Do":
+			if len(args) != 1 || depth >= maxFormNested {
+				return
+			}
+			xobj := resources.Key("XObject").Key(args[0].Name())
+			if xobj.Key("Subtype").Name() != "Form" {
+				return
+			}
+			res := xobj.Key("Resources")
+			if res.IsNull() {
+				res = resources
+			}
+			w.walk(xobj, res, readMatrix(xobj.Key("Matrix")).mul(ctm), depth+1)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package pdfanalyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// tablePDF returns a document with a ruled 2x3 table on page 1, its
+// grid stroked through a scaled form XObject and its header separated
+// by a thin filled rectangle, and a table without rulings on page 2.
+func tablePDF(t *testing.T) string {
+	t.Helper()
+
+	var ruled strings.Builder
+	ruled.WriteString("q 2 0 0 2 0 0 cm /Grid Do Q\n")
+	ruled.WriteString("0 0 0 rg 100 669.5 300 1 re f\n")
+	for i, row := range [][3]string{{"Name", "Qty", "Price"}, {"Apples", "3", "1.20"}} {
+		y := 680 - 30*i
+		for j, cell := range row {
+			fmt.Fprintf(&ruled, "BT /F1 10 Tf 1 0 0 1 %d %d Tm (%s) Tj ET\n", 110+100*j, y, cell)
+		}
+	}
+
+	var aligned strings.Builder
+	for i, row := range [][3]string{{"Item", "Qty", "Price"}, {"Pears", "2", "0.80"}, {"Plums", "12", "2.10"}} {
+		y := 700 - 14*i
+		for j, cell := range row {
+			fmt.Fprintf(&aligned, "BT /F1 10 Tf 1 0 0 1 %d %d Tm (%s) Tj ET\n", 72+100*j, y, cell)
+		}
+	}
+
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R 5 0 R] /Count 2 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 7 0 R >> /XObject << /Grid 8 0 R >> >> /Contents 4 0 R >>")
+	b.add(stream("", ruled.String()))
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 7 0 R >> >> /Contents 6 0 R >>")
+	b.add(stream("", aligned.String()))
+	b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	// The grid is drawn at half scale: x 50 to 200, y 320 to 350.
+	b.add(stream("/Type /XObject /Subtype /Form /BBox [0 0 300 400]",
+		"0.5 w 50 320 150 30 re S 50 335 m 200 335 l S 100 320 m 100 350 l 150 320 m 150 350 l S"))
+	return b.write(t)
+}
+
+func TestExtractTables(t *testing.T) {
+	path := tablePDF(t)
+	a := NewPDFAnalyzer()
+
+	tables, err := a.ExtractTables(context.Background(), path)
+	if err != nil {
+		t.Fatalf("ExtractTables: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("got %d tables, want 2: %+v", len(tables), tables)
+	}
+
+	ruled := tables[0]
+	if ruled.Page != 1 || ruled.Method != "ruled" || ruled.Rows != 2 || ruled.Cols != 3 {
+		t.Errorf("unexpected ruled table %+v", ruled)
+	}
+	aligned := tables[1]
+	if aligned.Page != 2 || aligned.Method != "aligned" || aligned.Rows != 3 || aligned.Cols != 3 {
+		t.Errorf("unexpected aligned table %+v", aligned)
+	}
+	for _, tc := range []struct {
+		table Table
+		want  string
+	}{
+		{ruled, "Name|Qty|Price|Apples|3|1.20"},
+		{aligned, "Item|Qty|Price|Pears|2|0.80|Plums|12|2.10"},
+	} {
+		var texts []string
+		for _, c := range tc.table.Cells {
+			texts = append(texts, c.Text)
+		}
+		if got := strings.Join(texts, "|"); got != tc.want {
+			t.Errorf("page %d cells = %q, want %q", tc.table.Page, got, tc.want)
+		}
+	}
+
+	tables, err = a.ExtractTables(context.Background(), path, WithPages(PageRange{First: 2}, PageRange{First: 9, Last: 12}))
+	if err != nil {
+		t.Fatalf("ExtractTables(pages 2-): %v", err)
+	}
+	if len(tables) != 1 || tables[0].Page != 2 {
+		t.Errorf("page range not applied: %+v", tables)
+	}
+}
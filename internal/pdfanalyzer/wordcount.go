@@ -1,25 +1,9 @@
 package pdfanalyzer
 
-// countWords performs a naive word count using whitespace separation.
-// This is intentionally simple in Phase 2.1.
-func countWords(text string) int {
-	count := 0
-	inWord := false
-
-	for _, r := range text {
-		if r == ' ' || r == '\n' || r == '\t' {
-			if inWord {
-				count++
-				inWord = false
-			}
-		} else {
-			inWord = true
-		}
-	}
+import "github.com/jorgediasdsg/pdf-expert/internal/tokenizer"
 
-	if inWord {
-		count++
-	}
-
-	return count
+// countWords counts words using Unicode word boundaries (UAX #29),
+// so non-breaking spaces, dashes and CJK text are handled.
+func countWords(text string) int {
+	return tokenizer.CountWords(text)
 }
@@ -14,6 +14,27 @@ func TestCountWords(t *testing.T) {
 		{"empty", "", 0},
 		{"spaces only", "     ", 0},
 		{"unicode text", "olá mundo maravilhoso", 3},
+		{"non-breaking space", "hello\u00a0world", 2},
+		{"carriage returns", "hello\r\nworld\ragain", 3},
+		{"form feed", "page one\fpage two", 4},
+		{"em dash", "yes—no", 2},
+		{"punctuation is not a word", "wait , what ?!", 2},
+		{"apostrophes", "don't l'homme it’s", 3},
+		{"numbers", "costs 1.234,56 or 3.14", 4},
+		{"hyphenated line break", "infor-\nmation retrieval", 2},
+		{"german", "Größenordnung über Straße", 3},
+		{"french", "L'été à Paris, c'est génial.", 5},
+		{"spanish", "¿Dónde está la biblioteca?", 4},
+		{"russian", "Привет, как дела?", 3},
+		{"greek", "Καλημέρα κόσμε", 2},
+		{"hebrew", "שלום עולם", 2},
+		{"arabic", "مرحبا بالعالم", 2},
+		{"hindi", "नमस्ते दुनिया", 2},
+		{"chinese", "我爱北京", 4},
+		{"japanese", "東京でカメラを買った", 8},
+		{"mixed scripts", "PDF文件 analysis", 4},
+		{"combining marks", "cafe\u0301 nai\u0308ve", 2},
+		{"emoji", "ship it 🚀 now", 3},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,148 @@
+package pdftables
+
+import (
+	"math"
+	"sort"
+)
+
+// alignedTables finds tables without rulings: runs of consecutive lines
+// whose words leave the same wide vertical gaps, the gutters between
+// columns. Lines are split into cells at the middle of each gutter.
+func alignedTables(lines []Line) []Table {
+	sorted := make([]Line, len(lines))
+	copy(sorted, lines)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Y > sorted[j].Y })
+
+	var tables []Table
+	for i := 0; i < len(sorted); {
+		end, gutters := alignedRun(sorted, i)
+		if end-i < minAlignedRows {
+			i++
+			continue
+		}
+		tables = append(tables, alignedTable(sorted[i:end], gutters))
+		i = end
+	}
+	return tables
+}
+
+// alignedRun returns the end, exclusive, of the longest run of lines
+// starting at start that share at least minAlignedCols-1 gutters, and
+// the middle of each shared gutter.
+func alignedRun(lines []Line, start int) (end int, gutters []float64) {
+	size := lineSize(lines[start])
+	free := gaps(lines[start])
+	if len(wide(free, size)) < minAlignedCols-1 {
+		return start + 1, nil
+	}
+
+	end = start + 1
+	for ; end < len(lines); end++ {
+		next := lines[end]
+		if lines[end-1].Y-next.Y > maxRowGap*math.Max(size, lineSize(next)) {
+			break
+		}
+		nsize := math.Max(size, lineSize(next))
+		nfree := intersect(free, gaps(next))
+		if len(wide(nfree, nsize)) < minAlignedCols-1 || columnsUsed(next, wide(nfree, nsize)) < 2 {
+			break
+		}
+		free, size = nfree, nsize
+	}
+
+	for _, iv := range wide(free, size) {
+		gutters = append(gutters, (iv[0]+iv[1])/2)
+	}
+	return end, gutters
+}
+
+// alignedTable splits each line of a run into the columns bounded by
+// gutters.
+func alignedTable(lines []Line, gutters []float64) Table {
+	t := Table{
+		Method: MethodAligned,
+		Rect:   Rect{X1: math.Inf(1), X2: math.Inf(-1)},
+		Rows:   len(lines),
+		Cols:   len(gutters) + 1,
+	}
+	first, last := lines[0], lines[len(lines)-1]
+	t.Rect.Y2 = first.Y + lineSize(first)
+	t.Rect.Y1 = last.Y - lineSize(last)/3
+
+	for r, l := range lines {
+		texts := make([]cellText, t.Cols)
+		for _, w := range l.Words {
+			t.Rect.X1 = math.Min(t.Rect.X1, w.X1)
+			t.Rect.X2 = math.Max(t.Rect.X2, w.X2)
+			x, _ := w.center()
+			texts[sort.SearchFloat64s(gutters, x)].add(w)
+		}
+		for c := range texts {
+			t.Cells = append(t.Cells, Cell{Row: r, Col: c, RowSpan: 1, ColSpan: 1, Text: texts[c].b.String()})
+		}
+	}
+	return t
+}
+
+// gaps returns the parts of the x axis a line leaves empty, including
+// the unbounded ones before its first word and after its last.
+func gaps(l Line) [][2]float64 {
+	var free [][2]float64
+	x := math.Inf(-1)
+	for _, w := range l.Words {
+		if w.X1 > x {
+			free = append(free, [2]float64{x, w.X1})
+		}
+		x = math.Max(x, w.X2)
+	}
+	return append(free, [2]float64{x, math.Inf(1)})
+}
+
+// wide returns the bounded gaps at least minColumnGap font sizes wide.
+func wide(free [][2]float64, size float64) [][2]float64 {
+	var out [][2]float64
+	for _, iv := range free {
+		if !math.IsInf(iv[0], 0) && !math.IsInf(iv[1], 0) && iv[1]-iv[0] >= minColumnGap*math.Max(size, 1) {
+			out = append(out, iv)
+		}
+	}
+	return out
+}
+
+// columnsUsed counts the columns, delimited by gutters, that hold at
+// least one word of l.
+func columnsUsed(l Line, gutters [][2]float64) int {
+	used := make(map[int]bool)
+	for _, w := range l.Words {
+		x, _ := w.center()
+		used[sort.Search(len(gutters), func(i int) bool { return gutters[i][0] >= x })] = true
+	}
+	return len(used)
+}
+
+// intersect returns the intersection of two sorted interval lists.
+func intersect(a, b [][2]float64) [][2]float64 {
+	var out [][2]float64
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := math.Max(a[i][0], b[j][0]), math.Min(a[i][1], b[j][1])
+		if lo < hi {
+			out = append(out, [2]float64{lo, hi})
+		}
+		if a[i][1] < b[j][1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// lineSize returns the font size of l, falling back to its largest
+// word when the line has none.
+func lineSize(l Line) float64 {
+	size := l.Size
+	for _, w := range l.Words {
+		size = math.Max(size, w.Size)
+	}
+	return math.Max(size, 1)
+}
@@ -0,0 +1,352 @@
+package pdftables
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// hline and vline are normalized horizontal and vertical segments.
+type hline struct{ y, x1, x2 float64 }
+type vline struct{ x, y1, y2 float64 }
+
+// ruledTables builds a table from every group of connected horizontal
+// and vertical lines that forms at least two cells. Missing inner
+// lines merge cells into spans. It returns ctx.Err() once ctx is done.
+func ruledTables(ctx context.Context, lines []Line, rulings []Segment) ([]Table, error) {
+	hs, vs := splitSegments(rulings)
+	hs, vs = mergeH(hs), mergeV(vs)
+
+	// Group the lines that touch each other. The vertical lines are
+	// searched by x, so each horizontal line only visits those within
+	// its extent.
+	byX := make([]int, len(vs))
+	for j := range byX {
+		byX[j] = j
+	}
+	sort.Slice(byX, func(a, b int) bool { return vs[byX[a]].x < vs[byX[b]].x })
+	parent := make([]int, len(hs)+len(vs))
+	for i := range parent {
+		parent[i] = i
+	}
+	for i, h := range hs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		k := sort.Search(len(byX), func(k int) bool { return vs[byX[k]].x >= h.x1-snap })
+		for ; k < len(byX) && vs[byX[k]].x <= h.x2+snap; k++ {
+			if v := vs[byX[k]]; h.y >= v.y1-snap && h.y <= v.y2+snap {
+				union(parent, i, len(hs)+byX[k])
+			}
+		}
+	}
+	groups := make(map[int]*struct {
+		hs []hline
+		vs []vline
+	})
+	for i := range parent {
+		root := find(parent, i)
+		g := groups[root]
+		if g == nil {
+			g = &struct {
+				hs []hline
+				vs []vline
+			}{}
+			groups[root] = g
+		}
+		if i < len(hs) {
+			g.hs = append(g.hs, hs[i])
+		} else {
+			g.vs = append(g.vs, vs[i-len(hs)])
+		}
+	}
+
+	roots := make([]int, 0, len(groups))
+	for root := range groups {
+		roots = append(roots, root)
+	}
+	sort.Ints(roots)
+
+	var tables []Table
+	for _, root := range roots {
+		g := groups[root]
+		t, ok, err := ruledTable(ctx, g.hs, g.vs, lines)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+// splitSegments keeps the horizontal and vertical segments, turning
+// thin rectangles drawn as two parallel strokes into one line.
+func splitSegments(rulings []Segment) ([]hline, []vline) {
+	var hs []hline
+	var vs []vline
+	for _, s := range rulings {
+		dx, dy := math.Abs(s.X2-s.X1), math.Abs(s.Y2-s.Y1)
+		switch {
+		case dy <= snap/2 && dx >= minSegment:
+			hs = append(hs, hline{y: (s.Y1 + s.Y2) / 2, x1: math.Min(s.X1, s.X2), x2: math.Max(s.X1, s.X2)})
+		case dx <= snap/2 && dy >= minSegment:
+			vs = append(vs, vline{x: (s.X1 + s.X2) / 2, y1: math.Min(s.Y1, s.Y2), y2: math.Max(s.Y1, s.Y2)})
+		}
+	}
+	return hs, vs
+}
+
+// mergeH joins collinear horizontal lines that overlap or touch.
+func mergeH(hs []hline) []hline {
+	sort.Slice(hs, func(i, j int) bool {
+		if math.Abs(hs[i].y-hs[j].y) > snap {
+			return hs[i].y < hs[j].y
+		}
+		return hs[i].x1 < hs[j].x1
+	})
+	var out []hline
+	for _, h := range hs {
+		if n := len(out); n > 0 && math.Abs(out[n-1].y-h.y) <= snap && h.x1 <= out[n-1].x2+snap {
+			out[n-1].x2 = math.Max(out[n-1].x2, h.x2)
+			continue
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// mergeV joins collinear vertical lines that overlap or touch.
+func mergeV(vs []vline) []vline {
+	sort.Slice(vs, func(i, j int) bool {
+		if math.Abs(vs[i].x-vs[j].x) > snap {
+			return vs[i].x < vs[j].x
+		}
+		return vs[i].y1 < vs[j].y1
+	})
+	var out []vline
+	for _, v := range vs {
+		if n := len(out); n > 0 && math.Abs(out[n-1].x-v.x) <= snap && v.y1 <= out[n-1].y2+snap {
+			out[n-1].y2 = math.Max(out[n-1].y2, v.y2)
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// ruledTable lays a grid over a group of lines and fills it with the
+// words inside. A single framed box is not a table, and neither is a
+// grid of more than maxGridPositions: graph paper and hatching, whose
+// cells would only cost memory.
+func ruledTable(ctx context.Context, hs []hline, vs []vline, lines []Line) (Table, bool, error) {
+	if len(hs) < 2 || len(vs) < 2 {
+		return Table{}, false, nil
+	}
+
+	var xs, ys []float64
+	for _, v := range vs {
+		xs = append(xs, v.x)
+	}
+	for _, h := range hs {
+		ys = append(ys, h.y)
+	}
+	xs = uniqueSorted(xs)
+	ys = uniqueSorted(ys)
+
+	rows, cols := len(ys)-1, len(xs)-1
+	if rows < 1 || cols < 1 || rows*cols < 2 || rows*cols > maxGridPositions {
+		return Table{}, false, nil
+	}
+
+	// vEdge reports a line at xs[c] along row r; hEdge a line at
+	// ys[r] along column c. The lines are indexed by grid line, in
+	// order along it.
+	atX := make([][]vline, len(xs))
+	for _, v := range vs {
+		c := nearest(xs, v.x)
+		atX[c] = append(atX[c], v)
+	}
+	atY := make([][]hline, len(ys))
+	for _, h := range hs {
+		r := nearest(ys, h.y)
+		atY[r] = append(atY[r], h)
+	}
+	// Rows are read top to bottom.
+	for i, j := 0, len(ys)-1; i < j; i, j = i+1, j-1 {
+		ys[i], ys[j] = ys[j], ys[i]
+		atY[i], atY[j] = atY[j], atY[i]
+	}
+	for _, l := range atX {
+		sort.Slice(l, func(i, j int) bool { return l[i].y1 < l[j].y1 })
+	}
+	for _, l := range atY {
+		sort.Slice(l, func(i, j int) bool { return l[i].x1 < l[j].x1 })
+	}
+	vEdge := func(c, r int) bool {
+		mid := (ys[r] + ys[r+1]) / 2
+		l := atX[c]
+		// Merged lines do not overlap: going back from the last one
+		// starting before mid, they end lower and lower.
+		for k := sort.Search(len(l), func(k int) bool { return l[k].y1-snap > mid }) - 1; k >= 0 && l[k].y2+snap >= mid; k-- {
+			if math.Abs(l[k].x-xs[c]) <= snap {
+				return true
+			}
+		}
+		return false
+	}
+	hEdge := func(r, c int) bool {
+		mid := (xs[c] + xs[c+1]) / 2
+		l := atY[r]
+		for k := sort.Search(len(l), func(k int) bool { return l[k].x1-snap > mid }) - 1; k >= 0 && l[k].x2+snap >= mid; k-- {
+			if math.Abs(l[k].y-ys[r]) <= snap {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Positions without a line between them belong to one cell.
+	parent := make([]int, rows*cols)
+	for i := range parent {
+		parent[i] = i
+	}
+	for r := 0; r < rows; r++ {
+		if err := ctx.Err(); err != nil {
+			return Table{}, false, err
+		}
+		for c := 0; c < cols; c++ {
+			if c > 0 && !vEdge(c, r) {
+				union(parent, r*cols+c-1, r*cols+c)
+			}
+			if r > 0 && !hEdge(r, c) {
+				union(parent, (r-1)*cols+c, r*cols+c)
+			}
+		}
+	}
+	cells := spanCells(parent, rows, cols)
+	if len(cells) < 2 {
+		return Table{}, false, nil
+	}
+
+	t := Table{
+		Method: MethodRuled,
+		Rect:   Rect{X1: xs[0], Y1: ys[rows], X2: xs[cols], Y2: ys[0]},
+		Rows:   rows,
+		Cols:   cols,
+	}
+
+	// Fill the cells, line by line so multi-line cells keep their
+	// line breaks.
+	owner := make([]int, rows*cols) // position to index in cells
+	for i, cell := range cells {
+		for r := cell.Row; r < cell.Row+cell.RowSpan; r++ {
+			for c := cell.Col; c < cell.Col+cell.ColSpan; c++ {
+				owner[r*cols+c] = i
+			}
+		}
+	}
+	texts := make([]cellText, len(cells))
+	for _, l := range lines {
+		for _, w := range l.Words {
+			x, y := w.center()
+			if !t.Rect.contains(x, y) {
+				continue
+			}
+			r := sort.Search(rows, func(i int) bool { return ys[i+1] <= y })
+			c := sort.Search(cols, func(i int) bool { return xs[i+1] >= x })
+			if r < rows && c < cols {
+				texts[owner[r*cols+c]].add(w)
+			}
+		}
+	}
+	for i := range cells {
+		cells[i].Text = texts[i].b.String()
+	}
+	t.Cells = cells
+	return t, true, nil
+}
+
+// spanCells turns the groups of grid positions into cells. A group
+// that is not a rectangle, which only happens with broken rulings, is
+// split back into single positions.
+func spanCells(parent []int, rows, cols int) []Cell {
+	type bounds struct{ r1, c1, r2, c2, n int }
+	groups := make(map[int]*bounds)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			root := find(parent, r*cols+c)
+			b := groups[root]
+			if b == nil {
+				b = &bounds{r1: r, c1: c, r2: r, c2: c}
+				groups[root] = b
+			}
+			b.r1, b.c1 = min(b.r1, r), min(b.c1, c)
+			b.r2, b.c2 = max(b.r2, r), max(b.c2, c)
+			b.n++
+		}
+	}
+
+	var cells []Cell
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			b := groups[find(parent, r*cols+c)]
+			if (b.r2-b.r1+1)*(b.c2-b.c1+1) != b.n {
+				cells = append(cells, Cell{Row: r, Col: c, RowSpan: 1, ColSpan: 1})
+				continue
+			}
+			if b.r1 == r && b.c1 == c {
+				cells = append(cells, Cell{Row: r, Col: c, RowSpan: b.r2 - b.r1 + 1, ColSpan: b.c2 - b.c1 + 1})
+			}
+		}
+	}
+	return cells
+}
+
+// nearest returns the index of the value of sorted closest to v.
+func nearest(sorted []float64, v float64) int {
+	i := sort.SearchFloat64s(sorted, v)
+	if i == len(sorted) || i > 0 && v-sorted[i-1] < sorted[i]-v {
+		i--
+	}
+	return i
+}
+
+// uniqueSorted sorts values and merges those within snap of each
+// other into their mean.
+func uniqueSorted(values []float64) []float64 {
+	sort.Float64s(values)
+	var out []float64
+	var sum float64
+	var n int
+	for i, v := range values {
+		if i > 0 && v-values[i-1] > snap {
+			out = append(out, sum/float64(n))
+			sum, n = 0, 0
+		}
+		sum += v
+		n++
+	}
+	if n > 0 {
+		out = append(out, sum/float64(n))
+	}
+	return out
+}
+
+func find(parent []int, i int) int {
+	for parent[i] != i {
+		parent[i] = parent[parent[i]]
+		i = parent[i]
+	}
+	return i
+}
+
+func union(parent []int, a, b int) {
+	ra, rb := find(parent, a), find(parent, b)
+	if ra < rb {
+		parent[rb] = ra
+	} else if rb < ra {
+		parent[ra] = rb
+	}
+}
@@ -0,0 +1,158 @@
+// Package pdftables finds tables on a page from its ruling lines and
+// the alignment of its words. It works on geometry only: the caller
+// reads the words and lines from the PDF (see pdfanalyzer) and the
+// package returns cell grids.
+//
+// Coordinates are PDF user space, in points, with y growing upwards.
+package pdftables
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Detection methods, as reported in Table.Method.
+const (
+	MethodRuled   = "ruled"   // cells bounded by drawn lines
+	MethodAligned = "aligned" // columns found from the word positions
+)
+
+// Word is a run of glyphs on one baseline.
+type Word struct {
+	X1, X2 float64 // left and right edges
+	Y      float64 // baseline
+	Size   float64 // font size
+	Text   string
+}
+
+// Line is the words sharing a baseline, ordered by X1.
+type Line struct {
+	Y, Size float64
+	Words   []Word
+}
+
+// Segment is a stroke or a thin filled rectangle. Only horizontal and
+// vertical segments are used.
+type Segment struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// Rect is a rectangle, [X1, Y1] bottom-left and [X2, Y2] top-right.
+type Rect struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// Table is a grid of Rows × Cols positions covered by Cells. A cell
+// spanning several positions is listed once, at its top-left one.
+type Table struct {
+	Page   int // set by the caller
+	Method string
+	Rect   Rect
+	Rows   int
+	Cols   int
+	Cells  []Cell // in row-major order
+}
+
+// Cell is a table cell. Row and Col are 0-based; lines of text inside
+// the cell are separated by "\n".
+type Cell struct {
+	Row, Col         int
+	RowSpan, ColSpan int
+	Text             string
+}
+
+// Detection thresholds. Distances are in points, or in multiples of
+// the font size where noted.
+const (
+	// snap is the distance within which two lines are taken as the
+	// same grid line, and segments as touching.
+	snap = 2.0
+	// minSegment drops dots and dashes shorter than this.
+	minSegment = 3.0
+	// minColumnGap is the horizontal gap between two columns of an
+	// aligned table, in font sizes.
+	minColumnGap = 1.0
+	// maxRowGap is the largest distance between the baselines of
+	// consecutive rows of an aligned table, in font sizes.
+	maxRowGap = 2.5
+	// minAlignedRows and minAlignedCols reject runs of text that
+	// happen to line up, such as two-column prose.
+	minAlignedRows = 3
+	minAlignedCols = 3
+	// maxSegments bounds the lines considered per page.
+	maxSegments = 20000
+	// maxGridPositions bounds the rows × cols of a ruled table; larger
+	// grids are skipped.
+	maxGridPositions = 10000
+)
+
+// Detect returns the tables of a page, top to bottom. Ruled tables are
+// found first; the words they contain are not considered for aligned
+// tables. It returns ctx.Err() once ctx is done.
+func Detect(ctx context.Context, lines []Line, rulings []Segment) ([]Table, error) {
+	if len(rulings) > maxSegments {
+		rulings = rulings[:maxSegments]
+	}
+	tables, err := ruledTables(ctx, lines, rulings)
+	if err != nil {
+		return nil, err
+	}
+
+	var rest []Line
+	for _, l := range lines {
+		kept := l
+		kept.Words = nil
+		for _, w := range l.Words {
+			if !insideAny(tables, w) {
+				kept.Words = append(kept.Words, w)
+			}
+		}
+		if len(kept.Words) > 0 {
+			rest = append(rest, kept)
+		}
+	}
+	tables = append(tables, alignedTables(rest)...)
+
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].Rect.Y2 > tables[j].Rect.Y2 })
+	return tables, nil
+}
+
+// center returns the point used to place a word in a cell: the middle
+// of its width, a third of the font size above the baseline.
+func (w Word) center() (x, y float64) {
+	return (w.X1 + w.X2) / 2, w.Y + w.Size/3
+}
+
+func (r Rect) contains(x, y float64) bool {
+	return x >= r.X1 && x <= r.X2 && y >= r.Y1 && y <= r.Y2
+}
+
+func insideAny(tables []Table, w Word) bool {
+	x, y := w.center()
+	for _, t := range tables {
+		if t.Rect.contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// cellText accumulates the words of a cell, line by line.
+type cellText struct {
+	b     strings.Builder
+	lastY float64
+}
+
+func (c *cellText) add(w Word) {
+	switch {
+	case c.b.Len() == 0:
+	case math.Abs(c.lastY-w.Y) > snap:
+		c.b.WriteByte('\n')
+	default:
+		c.b.WriteByte(' ')
+	}
+	c.b.WriteString(w.Text)
+	c.lastY = w.Y
+}
@@ -0,0 +1,147 @@
+package pdftables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// line lays out words on a baseline from (x, text) pairs, with 6
+// points per character of a 12-point font.
+func line(y float64, words ...any) Line {
+	l := Line{Y: y, Size: 12}
+	for i := 0; i < len(words); i += 2 {
+		x, text := words[i].(float64), words[i+1].(string)
+		l.Words = append(l.Words, Word{X1: x, X2: x + 6*float64(len(text)), Y: y, Size: 12, Text: text})
+	}
+	return l
+}
+
+func hseg(y, x1, x2 float64) Segment { return Segment{X1: x1, Y1: y, X2: x2, Y2: y} }
+func vseg(x, y1, y2 float64) Segment { return Segment{X1: x, Y1: y1, X2: x, Y2: y2} }
+
+func detect(t *testing.T, lines []Line, rulings []Segment) []Table {
+	t.Helper()
+	tables, err := Detect(context.Background(), lines, rulings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tables
+}
+
+func cellTexts(t Table) string {
+	var s string
+	for _, c := range t.Cells {
+		s += fmt.Sprintf("[%d,%d %dx%d %q]", c.Row, c.Col, c.RowSpan, c.ColSpan, c.Text)
+	}
+	return s
+}
+
+func TestDetect_Ruled(t *testing.T) {
+	// A 3x3 grid from x 100 to 400 and y 700 down to 610, whose
+	// header spans the last two columns and whose first column spans
+	// the two body rows.
+	rulings := []Segment{
+		hseg(700, 100, 400), hseg(670, 100, 250), hseg(670, 250, 400),
+		hseg(640, 200, 400), hseg(610, 100, 400),
+		vseg(100, 610, 700), vseg(200, 610, 700), vseg(300, 610, 670), vseg(400, 610, 700),
+		// Tick marks too short to be rulings.
+		hseg(500, 10, 11),
+	}
+	lines := []Line{
+		line(750, 100.0, "Title"),
+		line(680, 110.0, "Name", 210.0, "Totals"),
+		line(650, 110.0, "Widgets", 210.0, "3", 310.0, "4"),
+		line(620, 210.0, "5", 310.0, "6"),
+		line(632, 110.0, "blue"),
+	}
+
+	tables := detect(t, lines, rulings)
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1: %+v", len(tables), tables)
+	}
+	tab := tables[0]
+	if tab.Method != MethodRuled || tab.Rows != 3 || tab.Cols != 3 || tab.Rect != (Rect{100, 610, 400, 700}) {
+		t.Errorf("unexpected table %+v", tab)
+	}
+	want := `[0,0 1x1 "Name"][0,1 1x2 "Totals"][1,0 2x1 "Widgets\nblue"][1,1 1x1 "3"][1,2 1x1 "4"][2,1 1x1 "5"][2,2 1x1 "6"]`
+	if got := cellTexts(tab); got != want {
+		t.Errorf("cells:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestDetect_FramedBoxIsNotATable(t *testing.T) {
+	rulings := []Segment{hseg(700, 100, 400), hseg(600, 100, 400), vseg(100, 600, 700), vseg(400, 600, 700)}
+	if tables := detect(t, []Line{line(650, 110.0, "Note")}, rulings); len(tables) != 0 {
+		t.Errorf("expected no tables, got %+v", tables)
+	}
+}
+
+func TestDetect_Aligned(t *testing.T) {
+	lines := []Line{
+		line(720, 100.0, "A paragraph of running text above the table."),
+		line(690, 100.0, "Item", 200.0, "Qty", 300.0, "Price"),
+		line(675, 100.0, "Apples", 200.0, "3", 300.0, "1.20"),
+		line(660, 100.0, "Pears", 300.0, "0.80"),
+		line(645, 100.0, "Plums", 200.0, "12", 300.0, "2.10"),
+		line(600, 100.0, "Text after a gap."),
+	}
+
+	tables := detect(t, lines, nil)
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1: %+v", len(tables), tables)
+	}
+	tab := tables[0]
+	if tab.Method != MethodAligned || tab.Rows != 4 || tab.Cols != 3 {
+		t.Errorf("unexpected table %+v", tab)
+	}
+	want := `[0,0 1x1 "Item"][0,1 1x1 "Qty"][0,2 1x1 "Price"]` +
+		`[1,0 1x1 "Apples"][1,1 1x1 "3"][1,2 1x1 "1.20"]` +
+		`[2,0 1x1 "Pears"][2,1 1x1 ""][2,2 1x1 "0.80"]` +
+		`[3,0 1x1 "Plums"][3,1 1x1 "12"][3,2 1x1 "2.10"]`
+	if got := cellTexts(tab); got != want {
+		t.Errorf("cells:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestDetect_TwoColumnProseIsNotATable(t *testing.T) {
+	var lines []Line
+	for i := range 6 {
+		lines = append(lines, line(700-14*float64(i), 100.0, "left column text", 320.0, "right column text"))
+	}
+	if tables := detect(t, lines, nil); len(tables) != 0 {
+		t.Errorf("expected no tables, got %+v", tables)
+	}
+}
+
+// grid draws a ruled grid of rows × cols cells of 10 points.
+func grid(rows, cols int) []Segment {
+	var rulings []Segment
+	for r := 0; r <= rows; r++ {
+		rulings = append(rulings, hseg(float64(10*r), 0, float64(10*cols)))
+	}
+	for c := 0; c <= cols; c++ {
+		rulings = append(rulings, vseg(float64(10*c), 0, float64(10*rows)))
+	}
+	return rulings
+}
+
+func TestDetect_LargeGrids(t *testing.T) {
+	// 99 × 99 positions fit the limit.
+	tables := detect(t, nil, grid(99, 99))
+	if len(tables) != 1 || len(tables[0].Cells) != 99*99 {
+		t.Fatalf("got %d tables, want one of 99x99 cells", len(tables))
+	}
+
+	// Graph paper is skipped without laying out its grid.
+	if tables := detect(t, nil, grid(5000, 5000)); len(tables) != 0 {
+		t.Errorf("expected no tables, got %d", len(tables))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Detect(ctx, nil, grid(99, 99)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package readability
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		word     string
+		language string
+		want     int
+	}{
+		{"table", English, 2},
+		{"make", English, 1},
+		{"jumped", English, 1},
+		{"wanted", English, 2},
+		{"played", English, 1},
+		{"boxes", English, 2},
+		{"makes", English, 1},
+		{"readability", English, 5},
+		{"rhythm", English, 1},
+		{"día", Spanish, 2},
+		{"ciudad", Spanish, 2},
+		{"poeta", Spanish, 3},
+		{"guitarra", Spanish, 3},
+		{"rey", Spanish, 1},
+		{"educación", Spanish, 4},
+		{"mãe", Portuguese, 1},
+		{"corações", Portuguese, 3},
+		{"saúde", Portuguese, 3},
+		{"quero", Portuguese, 2},
+		{"eletrônica", Portuguese, 5},
+	}
+	for _, tc := range tests {
+		if got := Syllables(tc.word, tc.language); got != tc.want {
+			t.Errorf("Syllables(%q, %q) = %d; want %d", tc.word, tc.language, got, tc.want)
+		}
+	}
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+func TestAnalyze_English(t *testing.T) {
+	m := Analyze("The cat sat on the mat. The dog ran.", English)
+
+	if m.Words != 9 || m.Sentences != 2 || m.Syllables != 9 || m.AverageSentenceLength != 4.5 || m.SyllablesPerWord != 1 {
+		t.Fatalf("unexpected counts: %+v", m)
+	}
+	want := map[string]float64{FleschReadingEase: 117.668, FleschKincaidGrade: -2.035, GunningFog: 1.8}
+	if len(m.Scores) != len(want) {
+		t.Errorf("scores = %v; want %v", m.Scores, want)
+	}
+	for name, v := range want {
+		if got := round(m.Scores[name]); got != v {
+			t.Errorf("%s = %g; want %g", name, got, v)
+		}
+	}
+	if m.ReadingTime != 2*time.Second || m.SpeakingTime != 4*time.Second {
+		t.Errorf("reading %v, speaking %v; want 2s and 4s", m.ReadingTime, m.SpeakingTime)
+	}
+
+	// Complex words raise the fog; names do not.
+	fog := Analyze("It has organizational responsibilities in Washington.", English)
+	if fog.ComplexWords != 2 {
+		t.Errorf("complex words = %d; want 2", fog.ComplexWords)
+	}
+}
+
+func TestAnalyze_Languages(t *testing.T) {
+	es := Analyze("El gato come pescado.", Spanish)
+	if got := round(es.Scores[FernandezHuerta]); got != 61.34 || len(es.Scores) != 1 {
+		t.Errorf("Spanish scores = %v; want fernandez_huerta 61.34", es.Scores)
+	}
+
+	pt := Analyze("O menino lê o livro. O Sr. Silva chegou.", Portuguese)
+	if pt.Sentences != 2 {
+		t.Errorf("Portuguese sentences = %d; want 2", pt.Sentences)
+	}
+	if _, ok := pt.Scores[FleschMartins]; !ok || len(pt.Scores) != 1 {
+		t.Errorf("Portuguese scores = %v; want flesch_martins", pt.Scores)
+	}
+
+	// Without a known language, only the counts and times are reported.
+	und := Analyze("Lorem ipsum dolor sit amet.", "")
+	if len(und.Scores) != 0 || und.Words != 5 || und.ReadingTime == 0 {
+		t.Errorf("unexpected metrics: %+v", und)
+	}
+	if empty := Analyze("1 2 3", English); empty.Words != 0 || empty.Sentences != 0 || len(empty.Scores) != 0 {
+		t.Errorf("a text without words must have zero metrics: %+v", empty)
+	}
+}
@@ -0,0 +1,129 @@
+package readability
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Syllables estimates the number of syllables of a word in language,
+// an ISO 639-1 code, from its vowel groups. Spanish and Portuguese
+// split groups of two strong vowels (hiatus) and keep diphthongs
+// together; English drops silent endings. Other languages are counted
+// as English. Words without vowels count one syllable.
+func Syllables(word, language string) int {
+	w := []rune(strings.ToLower(word))
+	var n int
+	switch language {
+	case Spanish, Portuguese:
+		n = romanceSyllables(w, language)
+	default:
+		n = englishSyllables(w)
+	}
+	return max(n, 1)
+}
+
+// englishSyllables counts the groups of vowels, y included after the
+// first letter, then drops a silent final "e" and the "-ed" and "-es"
+// endings that do not add a syllable: "make", "jumped", "makes", but
+// "table", "wanted", "boxes".
+func englishSyllables(w []rune) int {
+	isVowel := func(i int) bool {
+		switch w[i] {
+		case 'a', 'e', 'i', 'o', 'u':
+			return true
+		case 'y':
+			return i > 0
+		}
+		return false
+	}
+
+	n := 0
+	for i := range w {
+		if isVowel(i) && (i == 0 || !isVowel(i-1)) {
+			n++
+		}
+	}
+	if n < 2 {
+		return n
+	}
+
+	s := string(w)
+	switch {
+	case strings.HasSuffix(s, "le") && len(w) > 2 && !isVowel(len(w)-3):
+		// "ta-ble": the final e is silent, but l carries a syllable.
+	case strings.HasSuffix(s, "e") && !strings.HasSuffix(s, "ee"):
+		n--
+	case strings.HasSuffix(s, "ed") && len(w) > 2 && !strings.ContainsRune("tdei", w[len(w)-3]):
+		n--
+	case strings.HasSuffix(s, "es") && len(w) > 2 && !isVowel(len(w)-3) && !sibilantBefore(s[:len(s)-2]):
+		n--
+	}
+	return n
+}
+
+// sibilantBefore reports whether stem ends with a sound after which
+// "-es" is pronounced: "boxes", "wishes", "places".
+func sibilantBefore(stem string) bool {
+	for _, end := range []string{"s", "x", "z", "ch", "sh", "ce", "ge", "c", "g"} {
+		if strings.HasSuffix(stem, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// romanceSyllables counts one syllable per group of vowels, plus one
+// for each strong vowel that follows another strong vowel: "po-e-ta",
+// "dí-a", but "ciu-dad", "ai-re". The u of "que", "qui", "gue" and
+// "gui" is silent. In Spanish, y is a vowel at the end of a word
+// ("rey"); in Portuguese, the nasal diphthongs "ão", "ãe" and "õe" are
+// one syllable.
+func romanceSyllables(w []rune, language string) int {
+	isVowel := func(i int) bool {
+		r := w[i]
+		if r == 'y' {
+			return language == Spanish && i == len(w)-1 && i > 0
+		}
+		if r == 'u' && i > 0 && (w[i-1] == 'q' || w[i-1] == 'g') && i+1 < len(w) && (w[i+1] == 'e' || w[i+1] == 'i') {
+			return false // silent u
+		}
+		return isRomanceVowel(r)
+	}
+
+	n := 0
+	for i := range w {
+		if !isVowel(i) {
+			continue
+		}
+		if i == 0 || !isVowel(i-1) {
+			n++
+			continue
+		}
+		prev, cur := w[i-1], w[i]
+		if language == Portuguese && (prev == 'ã' || prev == 'õ') && (cur == 'o' || cur == 'e') {
+			continue // nasal diphthong
+		}
+		if isStrong(prev) && isStrong(cur) {
+			n++
+		}
+	}
+	return n
+}
+
+func isRomanceVowel(r rune) bool {
+	return isStrong(r) || strings.ContainsRune("iuü", r)
+}
+
+// isStrong reports whether r is a strong vowel: a, e, o, or a stressed
+// i or u, which breaks a diphthong ("pa-ís").
+func isStrong(r rune) bool {
+	return strings.ContainsRune("aeoáàâãéêóôõíú", r)
+}
+
+// isCapitalized reports whether word starts with an uppercase letter.
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
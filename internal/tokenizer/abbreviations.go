@@ -0,0 +1,21 @@
+package tokenizer
+
+import "strings"
+
+// abbreviations lists, per ISO 639-1 language code, the lowercased
+// abbreviations usually followed by a capitalised name or number, so a
+// period after them does not end a sentence: "Mr. Smith", "Sra. Lima",
+// "art. 5". Abbreviations followed by lowercase words need no entry.
+var abbreviations = map[string]map[string]bool{
+	"en": wordSet("mr mrs ms dr prof st jr sr inc ltd co corp no nos vol fig figs p pp ch sec art ed eds rev gen col capt lt sgt jan feb mar apr jun jul aug sep sept oct nov dec e.g i.e vs approx dept est"),
+	"pt": wordSet("sr sra srta dr dra prof profa eng av r pç ltda cia nº n art arts inc pág págs p pp cap fig vol ed v.g i.e obs tel ex exmo exma ilmo ilma jan fev mar abr mai jun jul ago set out nov dez"),
+	"es": wordSet("sr sra srta dr dra prof profa lic ing ud uds av avda c s.a cía nº núm n art arts pág págs p pp cap fig vol ed p.ej tel ene feb mar abr may jun jul ago sep sept oct nov dic"),
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,111 @@
+package tokenizer
+
+import "unicode"
+
+// wordBreak is the Word_Break property of a rune, as defined by
+// Unicode Standard Annex #29 ("Unicode Text Segmentation"), §4.1.
+// Only the values the segmentation rules distinguish are modelled.
+type wordBreak uint8
+
+const (
+	wbOther wordBreak = iota
+	wbCR
+	wbLF
+	wbNewline
+	wbExtend // Extend, Format and ZWJ: never start a segment (WB4)
+	wbRegionalIndicator
+	wbKatakana
+	wbHebrewLetter
+	wbALetter
+	wbSingleQuote
+	wbDoubleQuote
+	wbMidNumLet
+	wbMidLetter
+	wbMidNum
+	wbNumeric
+	wbExtendNumLet
+	wbWSegSpace
+	wbIdeographic // Han and Hiragana: not a Word_Break value, see isIdeographic
+)
+
+// wordBreakOf classifies r. The tables follow WordBreakProperty.txt
+// closely enough for word counting; scripts that need a dictionary
+// (Thai, Lao, Khmer, Myanmar) are treated as ALetter runs.
+func wordBreakOf(r rune) wordBreak {
+	switch r {
+	case '\r':
+		return wbCR
+	case '\n':
+		return wbLF
+	case '\v', '\f', 0x85, 0x2028, 0x2029:
+		return wbNewline
+	case '\'':
+		return wbSingleQuote
+	case '"':
+		return wbDoubleQuote
+	case '.', 0x2018, 0x2019, 0x2024, 0xFE52, 0xFF07, 0xFF0E:
+		return wbMidNumLet
+	case ':', 0xB7, 0x387, 0x55F, 0x5F4, 0x2027, 0xFE13, 0xFE55, 0xFF1A:
+		return wbMidLetter
+	case ',', ';', 0x37E, 0x589, 0x60C, 0x60D, 0x66C, 0x7F8, 0x2044, 0xFE10, 0xFE14, 0xFE50, 0xFE54, 0xFF0C, 0xFF1B:
+		return wbMidNum
+	case '_', 0x202F, 0x203F, 0x2040, 0x2054, 0xFE33, 0xFE34, 0xFE4D, 0xFE4E, 0xFE4F, 0xFF3F:
+		return wbExtendNumLet
+	case ' ', 0x1680, 0x2000, 0x2001, 0x2002, 0x2003, 0x2004, 0x2005, 0x2006, 0x2008, 0x2009, 0x200A, 0x205F, 0x3000:
+		return wbWSegSpace
+	case 0x200D, 0x00AD:
+		// ZWJ and soft hyphen (Format) glue to the preceding rune.
+		return wbExtend
+	case 0x30FC, 0x3031, 0x3032, 0x3033, 0x3034, 0x3035, 0x309B, 0x309C, 0x30A0, 0xFF70:
+		return wbKatakana
+	}
+
+	switch {
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return wbRegionalIndicator
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Cf):
+		return wbExtend
+	case unicode.Is(unicode.Katakana, r):
+		return wbKatakana
+	case unicode.Is(unicode.Hebrew, r) && unicode.IsLetter(r):
+		return wbHebrewLetter
+	case isIdeographic(r):
+		return wbIdeographic
+	case unicode.IsLetter(r):
+		return wbALetter
+	case unicode.Is(unicode.Nd, r):
+		return wbNumeric
+	case unicode.Is(unicode.Pc, r):
+		return wbExtendNumLet
+	}
+	return wbOther
+}
+
+// isIdeographic reports whether r belongs to a script written without
+// spaces where every character forms its own segment under UAX #29
+// (no rule joins them). Each such character is counted as a word,
+// the usual convention for CJK word counts.
+func isIdeographic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana) ||
+		(r >= 0x3005 && r <= 0x3007) // 々 〆 〇
+}
+
+// isAHLetter groups ALetter and Hebrew_Letter (UAX #29 macro AHLetter).
+func (wb wordBreak) isAHLetter() bool {
+	return wb == wbALetter || wb == wbHebrewLetter
+}
+
+// isMidNumLetQ groups MidNumLet and Single_Quote (macro MidNumLetQ).
+func (wb wordBreak) isMidNumLetQ() bool {
+	return wb == wbMidNumLet || wb == wbSingleQuote
+}
+
+// isWordLike reports whether a rune with this property makes the
+// segment containing it a word.
+func (wb wordBreak) isWordLike() bool {
+	switch wb {
+	case wbALetter, wbHebrewLetter, wbNumeric, wbKatakana, wbIdeographic:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package tokenizer
+
+import "unicode/utf8"
+
+// segment is a span of text between two UAX #29 word boundaries.
+type segment struct {
+	text  string
+	first wordBreak // Word_Break value of the first rune
+}
+
+// isSpace reports whether the segment is whitespace only.
+func (s segment) isSpace() bool {
+	switch s.first {
+	case wbWSegSpace, wbCR, wbLF, wbNewline:
+		return true
+	}
+	if s.first != wbOther {
+		return false
+	}
+	for _, r := range s.text {
+		if !isSpaceRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLineBreak reports whether the segment is a hard line break.
+func (s segment) isLineBreak() bool {
+	switch s.first {
+	case wbCR, wbLF, wbNewline:
+		return true
+	}
+	return false
+}
+
+// isSpaceRune covers the spaces that are not WSegSpace under UAX #29
+// (NBSP, figure space, tab) but still separate tokens for counting.
+func isSpaceRune(r rune) bool {
+	switch r {
+	case '\t', 0xA0, 0x2007, 0x180E, 0xFEFF:
+		return true
+	}
+	return false
+}
+
+// segments splits text at UAX #29 word boundaries (rules WB1–WB999).
+// WB3c (ZWJ × emoji) is not modelled; emoji sequences fall apart into
+// several symbol segments, which only affects the token count.
+func segments(text string) []segment {
+	if text == "" {
+		return nil
+	}
+
+	type unit struct {
+		pos int
+		wb  wordBreak
+		ri  int // regional indicators in the run ending here, for WB15 and WB16
+	}
+	units := make([]unit, 0, len(text))
+	ri := 0
+	for pos, r := range text {
+		wb := wordBreakOf(r)
+		switch wb {
+		case wbExtend: // WB4: the run goes on
+		case wbRegionalIndicator:
+			ri++
+		default:
+			ri = 0
+		}
+		units = append(units, unit{pos: pos, wb: wb, ri: ri})
+	}
+
+	// prev returns the index of the last non-Extend unit before i
+	// (WB4: ignore Extend/Format/ZWJ), or -1.
+	prev := func(i int) int {
+		for i--; i >= 0; i-- {
+			if units[i].wb != wbExtend {
+				return i
+			}
+		}
+		return -1
+	}
+	// next returns the index of the first non-Extend unit after i, or -1.
+	next := func(i int) int {
+		for i++; i < len(units); i++ {
+			if units[i].wb != wbExtend {
+				return i
+			}
+		}
+		return -1
+	}
+	at := func(i int) wordBreak {
+		if i < 0 {
+			return wbOther
+		}
+		return units[i].wb
+	}
+
+	isBreak := func(i int) bool {
+		before, cur := units[i-1].wb, units[i].wb
+
+		switch {
+		case before == wbCR && cur == wbLF: // WB3
+			return false
+		case before == wbCR || before == wbLF || before == wbNewline: // WB3a
+			return true
+		case cur == wbCR || cur == wbLF || cur == wbNewline: // WB3b
+			return true
+		case before == wbWSegSpace && cur == wbWSegSpace: // WB3d
+			return false
+		case cur == wbExtend: // WB4
+			return false
+		}
+
+		p := prev(i)
+		left := at(p)
+		left2 := at(prev(p))
+		right2 := at(next(i))
+
+		switch {
+		case left.isAHLetter() && cur.isAHLetter(): // WB5
+			return false
+		case left.isAHLetter() && (cur == wbMidLetter || cur.isMidNumLetQ()) && right2.isAHLetter(): // WB6
+			return false
+		case left2.isAHLetter() && (left == wbMidLetter || left.isMidNumLetQ()) && cur.isAHLetter(): // WB7
+			return false
+		case left == wbHebrewLetter && cur == wbSingleQuote: // WB7a
+			return false
+		case left == wbHebrewLetter && cur == wbDoubleQuote && right2 == wbHebrewLetter: // WB7b
+			return false
+		case left2 == wbHebrewLetter && left == wbDoubleQuote && cur == wbHebrewLetter: // WB7c
+			return false
+		case left == wbNumeric && cur == wbNumeric: // WB8
+			return false
+		case left.isAHLetter() && cur == wbNumeric: // WB9
+			return false
+		case left == wbNumeric && cur.isAHLetter(): // WB10
+			return false
+		case left2 == wbNumeric && (left == wbMidNum || left.isMidNumLetQ()) && cur == wbNumeric: // WB11
+			return false
+		case left == wbNumeric && (cur == wbMidNum || cur.isMidNumLetQ()) && right2 == wbNumeric: // WB12
+			return false
+		case left == wbKatakana && cur == wbKatakana: // WB13
+			return false
+		case (left.isAHLetter() || left == wbNumeric || left == wbKatakana || left == wbExtendNumLet) && cur == wbExtendNumLet: // WB13a
+			return false
+		case left == wbExtendNumLet && (cur.isAHLetter() || cur == wbNumeric || cur == wbKatakana): // WB13b
+			return false
+		case left == wbRegionalIndicator && cur == wbRegionalIndicator: // WB15, WB16
+			return units[p].ri%2 == 0
+		}
+		return true // WB999
+	}
+
+	var out []segment
+	start := 0
+	for i := 1; i < len(units); i++ {
+		if isBreak(i) {
+			out = append(out, segment{text: text[units[start].pos:units[i].pos], first: units[start].wb})
+			start = i
+		}
+	}
+	out = append(out, segment{text: text[units[start].pos:], first: units[start].wb})
+	return out
+}
+
+// isWord reports whether the segment contains a letter, digit or
+// ideograph. Lone connectors such as "_" are not words.
+func (s segment) isWord() bool {
+	if s.first.isWordLike() {
+		return true
+	}
+	if s.first != wbExtendNumLet {
+		return false
+	}
+	for _, r := range s.text {
+		if wordBreakOf(r).isWordLike() {
+			return true
+		}
+	}
+	return false
+}
+
+// firstRune returns the first rune of s.
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
@@ -0,0 +1,209 @@
+// Package tokenizer splits extracted text into words, sentences and
+// paragraphs following the Unicode default segmentation rules
+// (UAX #29), so counts agree across scripts and whitespace variants.
+package tokenizer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Kind classifies a token.
+type Kind uint8
+
+const (
+	// Word is a run of letters, digits or a single ideograph,
+	// including inner apostrophes and separators ("don't", "3.14").
+	Word Kind = iota + 1
+	// Punctuation is any other non-space segment: punctuation,
+	// symbols and emoji.
+	Punctuation
+)
+
+// Token is a non-whitespace segment of text.
+type Token struct {
+	Text string
+	Kind Kind
+}
+
+// Stats holds the counts produced by Analyze.
+type Stats struct {
+	Tokens     int // words and punctuation tokens
+	Words      int // Word tokens
+	Sentences  int // sentences containing at least one word
+	Paragraphs int // paragraphs containing at least one word
+}
+
+// hyphenBreak matches a word hyphenated across a line break, such as
+// "exam-\nple". Only a lowercase continuation is joined, so line-final
+// dashes before a capitalised word or a list item are preserved.
+var hyphenBreak = regexp.MustCompile(`(\p{L})[-\x{2010}\x{00AD}][ \t]*(?:\r\n|\r|\n)[ \t]*(\p{Ll})`)
+
+// JoinHyphenated removes hyphens that split a word across two lines.
+func JoinHyphenated(text string) string {
+	return hyphenBreak.ReplaceAllString(text, "${1}${2}")
+}
+
+// Tokenize returns the word and punctuation tokens of text, in order.
+// Hyphenated line breaks are joined first.
+func Tokenize(text string) []Token {
+	var tokens []Token
+	for _, s := range segments(JoinHyphenated(text)) {
+		switch {
+		case s.isWord():
+			tokens = append(tokens, Token{Text: s.text, Kind: Word})
+		case !s.isSpace():
+			tokens = append(tokens, Token{Text: s.text, Kind: Punctuation})
+		}
+	}
+	return tokens
+}
+
+// CountWords returns the number of Word tokens in text.
+func CountWords(text string) int {
+	n := 0
+	for _, s := range segments(JoinHyphenated(text)) {
+		if s.isWord() {
+			n++
+		}
+	}
+	return n
+}
+
+// Analyze counts tokens, words, sentences and paragraphs in text.
+//
+// Sentences end at a terminator (. ! ? and their script variants)
+// followed, after any closing quotes or brackets, by whitespace or the
+// end of the text; ideographic full stops need no trailing space.
+// A period followed by a lowercase word does not end a sentence, so
+// "e.g. this" and "approx. ten" stay together. Paragraphs are
+// separated by blank lines, form feeds or U+2029 PARAGRAPH SEPARATOR.
+func Analyze(text string) Stats {
+	return AnalyzeLanguage(text, "")
+}
+
+// AnalyzeLanguage is Analyze with the abbreviations of language, an
+// ISO 639-1 code: a period after them does not end a sentence even
+// before a capitalised word, as in "Dr. Silva". Languages without a
+// list, and the empty code, are analyzed as by Analyze.
+func AnalyzeLanguage(text, language string) Stats {
+	abbrev := abbreviations[language]
+	var st Stats
+	segs := segments(JoinHyphenated(text))
+
+	sentenceWords, paragraphWords := 0, 0
+	endSentence := func() {
+		if sentenceWords > 0 {
+			st.Sentences++
+		}
+		sentenceWords = 0
+	}
+	endParagraph := func() {
+		endSentence()
+		if paragraphWords > 0 {
+			st.Paragraphs++
+		}
+		paragraphWords = 0
+	}
+
+	lineBreaks := 0
+	for i := 0; i < len(segs); i++ {
+		s := segs[i]
+
+		if s.isSpace() {
+			if s.isLineBreak() {
+				lineBreaks++
+				if lineBreaks >= 2 || strings.ContainsAny(s.text, "\u2029\f") {
+					endParagraph()
+				}
+			}
+			continue
+		}
+		lineBreaks = 0
+		st.Tokens++
+
+		if s.isWord() {
+			st.Words++
+			sentenceWords++
+			paragraphWords++
+			continue
+		}
+
+		if !isTerminator(s.text) {
+			continue
+		}
+		ideographic := isIdeographicTerminator(s.text)
+		period := s.text == "."
+		abbreviated := period && i > 0 && segs[i-1].isWord() && abbrev[strings.ToLower(segs[i-1].text)]
+
+		// Absorb further terminators and closing punctuation: "?!", ".)".
+		j := i + 1
+		for ; j < len(segs) && !segs[j].isSpace() && !segs[j].isWord(); j++ {
+			t := segs[j].text
+			if !isTerminator(t) && !isCloser(t) {
+				break
+			}
+			st.Tokens++
+			period = false
+		}
+		i = j - 1
+
+		if ideographic {
+			endSentence()
+			continue
+		}
+		if j < len(segs) && !segs[j].isSpace() {
+			continue // "3.x", "a.b": no space, no break
+		}
+		if period && (abbreviated || startsLowercase(segs[j:])) {
+			continue
+		}
+		endSentence()
+	}
+	endParagraph()
+
+	return st
+}
+
+// isTerminator reports whether s is sentence-final punctuation.
+func isTerminator(s string) bool {
+	switch s {
+	case ".", "!", "?", "‼", "⁇", "⁈", "⁉", "…",
+		"؟", "۔", "।", "॥", "。", "！", "？", "．", "｡":
+		return true
+	}
+	return false
+}
+
+// isIdeographicTerminator reports whether s is a full stop used in
+// scripts written without spaces.
+func isIdeographicTerminator(s string) bool {
+	switch s {
+	case "。", "！", "？", "．", "｡":
+		return true
+	}
+	return false
+}
+
+// isCloser reports whether s closes a quotation or bracket.
+func isCloser(s string) bool {
+	r := firstRune(s)
+	return r == '"' || r == '\'' || unicode.In(r, unicode.Pe, unicode.Pf)
+}
+
+// startsLowercase reports whether the first word after the leading
+// spaces in segs begins with a lowercase letter, without crossing a
+// line break.
+func startsLowercase(segs []segment) bool {
+	for _, s := range segs {
+		if s.isLineBreak() {
+			return false
+		}
+		if s.isSpace() {
+			continue
+		}
+		return unicode.IsLower(firstRune(s.text))
+	}
+	return false
+}
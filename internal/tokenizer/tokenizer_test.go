@@ -0,0 +1,112 @@
+package tokenizer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"apostrophe", "don't stop", []string{"don't", "stop"}},
+		{"decimal and thousands", "R$ 1.234,56 total", []string{"R", "$", "1.234,56", "total"}},
+		{"trailing period", "end.", []string{"end", "."}},
+		{"em dash", "before—after", []string{"before", "—", "after"}},
+		{"hyphen compound", "well-known", []string{"well", "-", "known"}},
+		{"cjk", "日本語です", []string{"日", "本", "語", "で", "す"}},
+		{"katakana run", "コンピュータ", []string{"コンピュータ"}},
+		{"combining mark", "café au lait", []string{"café", "au", "lait"}},
+		{"underscore", "snake_case _", []string{"snake_case", "_"}},
+		{"hyphenated line break", "exam-\nple", []string{"example"}},
+		{"capitalised after hyphen", "A-\nB", []string{"A", "-", "B"}},
+		{"flags", "🇧🇷🇵🇹🇪", []string{"🇧🇷", "🇵🇹", "🇪"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range Tokenize(tc.input) {
+				got = append(got, tok.Text)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Tokenize(%q) = %q; want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenize_LongFlagRun(t *testing.T) {
+	// Regional indicators pair up in one pass, not by counting the
+	// run back from each of them.
+	text := strings.Repeat("🇧🇷", 20000)
+	toks := Tokenize(text)
+	if len(toks) != 20000 || toks[0].Text != "🇧🇷" {
+		t.Errorf("got %d tokens, want 20000 flags", len(toks))
+	}
+}
+
+func TestAnalyzeLanguage(t *testing.T) {
+	tests := []struct {
+		input     string
+		language  string
+		sentences int
+	}{
+		{"Mr. Smith signed. The end.", "", 3},
+		{"Mr. Smith signed. The end.", "en", 2},
+		{"O Sr. Silva e a Dra. Lima assinaram. Fim.", "pt", 2},
+		{"Ver art. 5 da lei. Fim.", "pt", 2},
+		{"La Sra. García firmó. ¿Y el Dr. Pérez? No.", "es", 3},
+		{"The Sr. Lima case.", "xx", 2},
+	}
+	for _, tc := range tests {
+		if got := AnalyzeLanguage(tc.input, tc.language).Sentences; got != tc.sentences {
+			t.Errorf("AnalyzeLanguage(%q, %q) = %d sentences; want %d", tc.input, tc.language, got, tc.sentences)
+		}
+	}
+}
+
+func TestTokenizeKinds(t *testing.T) {
+	toks := Tokenize("Hi, 42!")
+	want := []Kind{Word, Punctuation, Word, Punctuation}
+	if len(toks) != len(want) {
+		t.Fatalf("got %d tokens; want %d", len(toks), len(want))
+	}
+	for i, k := range want {
+		if toks[i].Kind != k {
+			t.Errorf("token %d (%q) kind = %d; want %d", i, toks[i].Text, toks[i].Kind, k)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Stats
+	}{
+		{"empty", "", Stats{}},
+		{"no terminator", "just some words", Stats{Tokens: 3, Words: 3, Sentences: 1, Paragraphs: 1}},
+		{"two sentences", "It works. Really!", Stats{Tokens: 5, Words: 3, Sentences: 2, Paragraphs: 1}},
+		{"decimal is not a break", "Pi is 3.14 today.", Stats{Tokens: 5, Words: 4, Sentences: 1, Paragraphs: 1}},
+		{"abbreviation before lowercase", "Use e.g. this one.", Stats{Tokens: 6, Words: 4, Sentences: 1, Paragraphs: 1}},
+		{"closing quote", `He said "stop." Then left.`, Stats{Tokens: 9, Words: 5, Sentences: 2, Paragraphs: 1}},
+		{"interrobang run", "Why?! Because.", Stats{Tokens: 5, Words: 2, Sentences: 2, Paragraphs: 1}},
+		{"ideographic stops", "今日は晴れ。明日は雨。", Stats{Tokens: 11, Words: 9, Sentences: 2, Paragraphs: 1}},
+		{"paragraphs", "First one.\n\nSecond one.\r\n \r\nThird.", Stats{Tokens: 8, Words: 5, Sentences: 3, Paragraphs: 3}},
+		{"single newline keeps paragraph", "line one\nline two", Stats{Tokens: 4, Words: 4, Sentences: 1, Paragraphs: 1}},
+		{"paragraph separator", "One\u2029Two", Stats{Tokens: 2, Words: 2, Sentences: 2, Paragraphs: 2}},
+		{"punctuation only", "...", Stats{Tokens: 3}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Analyze(tc.input); got != tc.want {
+				t.Errorf("Analyze(%q) = %+v; want %+v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// File name pattern of staged uploads. Client-provided names are
+// never used to build paths: they may contain separators or "..",
+// and two clients can send the same name at the same time.
+const (
+	filePrefix = "upload-"
+	fileSuffix = ".pdf"
+)
+
+// pdfMagic is the signature every PDF file starts with.
+var pdfMagic = []byte("%PDF-")
+
+// Stager writes uploads to unique, owner-only files inside a
+// single directory. Only PDF files are accepted, up to maxBytes.
+type Stager struct {
+	dir      string
+	maxBytes int64
+}
+
+// NewStager returns a stager writing into dir. The directory is
+// created on first use. A maxBytes of zero disables the size limit.
+func NewStager(dir string, maxBytes int64) *Stager {
+	return &Stager{dir: dir, maxBytes: maxBytes}
+}
+
+// StagedFile is an upload stored on disk. Remove must be called
+// once the file is no longer needed; it is safe to call more than
+// once, so it can be both deferred and called explicitly.
+type StagedFile struct {
+	Path string
+	Size int64
+
+	once sync.Once
+	err  error
+}
+
+// Remove deletes the staged file.
+func (f *StagedFile) Remove() error {
+	f.once.Do(func() {
+		if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+			f.err = err
+		}
+	})
+	return f.err
+}
+
+// Stage copies src into a new staged file. It fails with
+// dto.ErrUnsupportedMediaType when src does not start with the PDF
+// signature and with dto.ErrFileTooLarge when it exceeds the size
+// limit; nothing is left on disk in either case.
+func (s *Stager) Stage(src io.Reader) (*StagedFile, error) {
+	magic := make([]byte, len(pdfMagic))
+	if _, err := io.ReadFull(src, magic); err != nil || !bytes.Equal(magic, pdfMagic) {
+		return nil, dto.ErrUnsupportedMediaType
+	}
+	src = io.MultiReader(bytes.NewReader(magic), src)
+	if s.maxBytes > 0 {
+		// One extra byte tells "exactly at the limit" from "over it".
+		src = io.LimitReader(src, s.maxBytes+1)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create upload directory: %w", err)
+	}
+
+	// CreateTemp picks a random, unused name and opens the file
+	// with O_EXCL and mode 0600.
+	dst, err := os.CreateTemp(s.dir, filePrefix+"*"+fileSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("create staged file: %w", err)
+	}
+
+	staged := &StagedFile{Path: dst.Name()}
+
+	n, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = staged.Remove()
+		return nil, fmt.Errorf("write staged file: %w", err)
+	}
+	if s.maxBytes > 0 && n > s.maxBytes {
+		_ = staged.Remove()
+		return nil, dto.ErrFileTooLarge
+	}
+
+	staged.Size = n
+	return staged, nil
+}
+
+// StageMultipart stages a file received in a multipart form.
+func (s *Stager) StageMultipart(fh *multipart.FileHeader) (*StagedFile, error) {
+	if s.maxBytes > 0 && fh.Size > s.maxBytes {
+		return nil, dto.ErrFileTooLarge
+	}
+
+	src, err := fh.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open upload: %w", err)
+	}
+	defer src.Close()
+
+	return s.Stage(src)
+}
+
+// Sweep removes staged files last modified more than olderThan ago
+// and returns how many were removed. It is meant to run at startup,
+// to clean up files left behind by a crash. Files that do not match
+// the staging pattern are left untouched.
+func (s *Stager) Sweep(olderThan time.Duration) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	removed := 0
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, name)); err == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
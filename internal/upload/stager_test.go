@@ -0,0 +1,187 @@
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// fileHeader builds a multipart file header as a client would send it.
+func fileHeader(t *testing.T, filename, content string) *multipart.FileHeader {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("parse multipart form: %v", err)
+	}
+	return req.MultipartForm.File["file"][0]
+}
+
+func TestStageMultipart_IgnoresClientPath(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+	stager := NewStager(dir, 0)
+
+	for _, name := range []string{"../../escape.pdf", "/etc/passwd", `..\..\win.pdf`, "a/../../b.pdf"} {
+		staged, err := stager.StageMultipart(fileHeader(t, name, "%PDF-1.4"))
+		if err != nil {
+			t.Fatalf("stage %q: %v", name, err)
+		}
+
+		if filepath.Dir(staged.Path) != dir {
+			t.Errorf("staged %q outside upload dir: %s", name, staged.Path)
+		}
+		if strings.Contains(filepath.Base(staged.Path), "escape") {
+			t.Errorf("client file name leaked into path: %s", staged.Path)
+		}
+		_ = staged.Remove()
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "escape.pdf")); !os.IsNotExist(err) {
+		t.Errorf("expected no file outside the upload dir, got %v", err)
+	}
+}
+
+func TestStageMultipart_ConcurrentSameName(t *testing.T) {
+	stager := NewStager(t.TempDir(), 0)
+
+	first, err := stager.StageMultipart(fileHeader(t, "report.pdf", "%PDF-first"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := stager.StageMultipart(fileHeader(t, "report.pdf", "%PDF-second"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first.Path == second.Path {
+		t.Fatalf("expected distinct paths, both got %s", first.Path)
+	}
+
+	for staged, want := range map[*StagedFile]string{first: "%PDF-first", second: "%PDF-second"} {
+		got, _ := os.ReadFile(staged.Path)
+		if string(got) != want {
+			t.Errorf("expected %q in %s, got %q", want, staged.Path, got)
+		}
+		if staged.Size != int64(len(want)) {
+			t.Errorf("expected size %d, got %d", len(want), staged.Size)
+		}
+	}
+}
+
+func TestStage_PermissionsAndRemove(t *testing.T) {
+	stager := NewStager(t.TempDir(), 0)
+
+	staged, err := stager.Stage(strings.NewReader("%PDF-1.4"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(staged.Path)
+	if err != nil {
+		t.Fatalf("stat staged file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected mode 0600, got %o", perm)
+	}
+
+	if err := staged.Remove(); err != nil {
+		t.Fatalf("unexpected remove error: %v", err)
+	}
+	if err := staged.Remove(); err != nil {
+		t.Errorf("expected second Remove to be a no-op, got %v", err)
+	}
+	if _, err := os.Stat(staged.Path); !os.IsNotExist(err) {
+		t.Errorf("expected staged file to be gone")
+	}
+}
+
+func TestSweep(t *testing.T) {
+	dir := t.TempDir()
+	stager := NewStager(dir, 0)
+
+	old, _ := stager.Stage(strings.NewReader("%PDF-old"))
+	recent, _ := stager.Stage(strings.NewReader("%PDF-recent"))
+	unrelated := filepath.Join(dir, "keep.txt")
+	_ = os.WriteFile(unrelated, []byte("x"), 0o600)
+
+	past := time.Now().Add(-2 * time.Hour)
+	_ = os.Chtimes(old.Path, past, past)
+	_ = os.Chtimes(unrelated, past, past)
+
+	removed, err := stager.Sweep(time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("expected 1 file removed, got %d", removed)
+	}
+
+	if _, err := os.Stat(old.Path); !os.IsNotExist(err) {
+		t.Errorf("expected old upload to be swept")
+	}
+	for _, path := range []string{recent.Path, unrelated} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be kept, got %v", path, err)
+		}
+	}
+}
+
+func TestSweep_MissingDirectory(t *testing.T) {
+	removed, err := NewStager(filepath.Join(t.TempDir(), "missing"), 0).Sweep(0)
+	if err != nil || removed != 0 {
+		t.Errorf("expected no-op on missing dir, got %d, %v", removed, err)
+	}
+}
+
+func TestStage_RejectsNonPDF(t *testing.T) {
+	dir := t.TempDir()
+	stager := NewStager(dir, 0)
+
+	for _, content := range []string{"", "%PD", "dummy pdf content", "PK\x03\x04zip"} {
+		if _, err := stager.Stage(strings.NewReader(content)); !errors.Is(err, dto.ErrUnsupportedMediaType) {
+			t.Errorf("Stage(%q): expected ErrUnsupportedMediaType, got %v", content, err)
+		}
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing on disk, found %d files", len(entries))
+	}
+}
+
+func TestStage_SizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	stager := NewStager(dir, 10)
+
+	staged, err := stager.Stage(strings.NewReader("%PDF-12345"))
+	if err != nil {
+		t.Fatalf("expected file at the limit to be accepted, got %v", err)
+	}
+	_ = staged.Remove()
+
+	if _, err := stager.Stage(strings.NewReader("%PDF-123456")); !errors.Is(err, dto.ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge, got %v", err)
+	}
+	if _, err := stager.StageMultipart(fileHeader(t, "big.pdf", "%PDF-123456789")); !errors.Is(err, dto.ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge from multipart, got %v", err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing on disk, found %d files", len(entries))
+	}
+}
@@ -0,0 +1,142 @@
+package upload
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// zipMagic is the signature of a ZIP local file header.
+var zipMagic = []byte("PK\x03\x04")
+
+// maxCompressionRatio is the largest uncompressed/compressed size
+// ratio accepted for an entry. PDFs are already compressed and stay
+// far below it; a higher ratio is the mark of a decompression bomb.
+const maxCompressionRatio = 100
+
+// ArchiveLimits bounds what StageZip extracts. Zero disables a limit.
+type ArchiveLimits struct {
+	MaxEntries    int   // file entries; more fail the whole archive
+	MaxTotalBytes int64 // decompressed bytes across all entries
+}
+
+// ArchiveEntry is one file of a ZIP archive: either staged on disk
+// or rejected with Err. Name is the path inside the archive and is
+// never used to build a path on disk.
+type ArchiveEntry struct {
+	Name string
+	File *StagedFile
+	Err  error
+}
+
+// IsZip reports whether r starts with a ZIP local file header.
+func IsZip(r io.ReaderAt) bool {
+	head := make([]byte, len(zipMagic))
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return false
+	}
+	return bytes.Equal(head, zipMagic)
+}
+
+// StageZip stages every file of the ZIP archive read from r.
+//
+// Each entry goes through Stage, so the PDF signature and the
+// per-file size limit apply. Entries with absolute or ".." paths
+// (zip-slip) are rejected with dto.ErrUnsafeArchivePath, and entries
+// whose declared compression ratio exceeds maxCompressionRatio with
+// dto.ErrArchiveTooLarge. Declared sizes can be trusted because
+// archive/zip fails reads past them.
+//
+// The whole archive fails with dto.ErrInvalidArchive when it cannot
+// be read, dto.ErrBatchTooLarge when it holds more than MaxEntries
+// files and dto.ErrArchiveTooLarge when the entries together declare
+// more than MaxTotalBytes; these checks run before anything is
+// extracted. The caller must remove the staged files.
+func (s *Stager) StageZip(r io.ReaderAt, size int64, limits ArchiveLimits) ([]ArchiveEntry, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidArchive, err)
+	}
+
+	var files []*zip.File
+	var total uint64
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || isArchiveMetadata(f.Name) {
+			continue
+		}
+		// Compare before adding: declared sizes can be crafted to
+		// overflow the sum.
+		if limits.MaxTotalBytes > 0 && f.UncompressedSize64 > uint64(limits.MaxTotalBytes)-total {
+			return nil, dto.ErrArchiveTooLarge
+		}
+		files = append(files, f)
+		total += f.UncompressedSize64
+	}
+	if limits.MaxEntries > 0 && len(files) > limits.MaxEntries {
+		return nil, dto.ErrBatchTooLarge
+	}
+
+	entries := make([]ArchiveEntry, 0, len(files))
+	for _, f := range files {
+		file, err := s.stageEntry(f)
+		entries = append(entries, ArchiveEntry{Name: f.Name, File: file, Err: err})
+	}
+	return entries, nil
+}
+
+// stageEntry stages a single archive file.
+func (s *Stager) stageEntry(f *zip.File) (*StagedFile, error) {
+	if !isSafeArchivePath(f.Name) {
+		return nil, dto.ErrUnsafeArchivePath
+	}
+
+	declared := int64(f.UncompressedSize64)
+	if s.maxBytes > 0 && declared > s.maxBytes {
+		return nil, dto.ErrFileTooLarge
+	}
+	if f.CompressedSize64 > 0 && f.UncompressedSize64/f.CompressedSize64 > maxCompressionRatio {
+		return nil, fmt.Errorf("%w: compression ratio above %d", dto.ErrArchiveTooLarge, maxCompressionRatio)
+	}
+
+	if f.Flags&0x1 != 0 {
+		return nil, fmt.Errorf("%w: encrypted entry", dto.ErrInvalidArchive)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidArchive, err)
+	}
+	defer rc.Close()
+
+	return s.Stage(rc)
+}
+
+// isSafeArchivePath rejects names that would escape an extraction
+// directory: absolute paths, drive letters and ".." elements, with
+// either separator.
+func isSafeArchivePath(name string) bool {
+	if name == "" || strings.ContainsRune(name, 0) {
+		return false
+	}
+	name = strings.ReplaceAll(name, `\`, "/")
+	if path.IsAbs(name) || (len(name) >= 2 && name[1] == ':') {
+		return false
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// isArchiveMetadata reports files added by archivers rather than by
+// the user, such as the macOS resource forks under __MACOSX/.
+func isArchiveMetadata(name string) bool {
+	return strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), "._")
+}
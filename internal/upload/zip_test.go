@@ -0,0 +1,130 @@
+package upload
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jorgediasdsg/pdf-expert/internal/app/dto"
+)
+
+// zipFile is one entry of a test archive.
+type zipFile struct {
+	name    string
+	content string
+}
+
+func buildZip(t *testing.T, files ...zipFile) *bytes.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for _, f := range files {
+		fw, err := w.Create(f.name)
+		if err != nil {
+			t.Fatalf("create %q: %v", f.name, err)
+		}
+		fw.Write([]byte(f.content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestStageZip_Entries(t *testing.T) {
+	stager := NewStager(t.TempDir(), 1<<20)
+	r := buildZip(t,
+		zipFile{"docs/a.pdf", "%PDF-1.4 a"},
+		zipFile{"docs/", ""},
+		zipFile{"../../evil.pdf", "%PDF-1.4 evil"},
+		zipFile{`..\win.pdf`, "%PDF-1.4 win"},
+		zipFile{"/abs.pdf", "%PDF-1.4 abs"},
+		zipFile{"notes.txt", "not a pdf"},
+		zipFile{"__MACOSX/docs/._a.pdf", "resource fork"},
+		zipFile{"bomb.pdf", "%PDF-1.4 " + strings.Repeat("0", 512<<10)},
+	)
+
+	if !IsZip(r) {
+		t.Fatal("expected archive to be detected as ZIP")
+	}
+
+	entries, err := stager.StageZip(r, r.Size(), ArchiveLimits{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		for _, e := range entries {
+			if e.File != nil {
+				_ = e.File.Remove()
+			}
+		}
+	}()
+
+	want := []struct {
+		name string
+		err  error
+	}{
+		{"docs/a.pdf", nil},
+		{"../../evil.pdf", dto.ErrUnsafeArchivePath},
+		{`..\win.pdf`, dto.ErrUnsafeArchivePath},
+		{"/abs.pdf", dto.ErrUnsafeArchivePath},
+		{"notes.txt", dto.ErrUnsupportedMediaType},
+		{"bomb.pdf", dto.ErrArchiveTooLarge},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Name != w.name {
+			t.Errorf("entry %d: expected %q, got %q", i, w.name, e.Name)
+		}
+		if !errors.Is(e.Err, w.err) {
+			t.Errorf("%s: expected %v, got %v", e.Name, w.err, e.Err)
+		}
+		if (e.File != nil) != (w.err == nil) {
+			t.Errorf("%s: staged file = %v with error %v", e.Name, e.File, e.Err)
+		}
+	}
+
+	data, err := os.ReadFile(entries[0].File.Path)
+	if err != nil || string(data) != "%PDF-1.4 a" {
+		t.Errorf("unexpected staged content %q (%v)", data, err)
+	}
+}
+
+func TestStageZip_ArchiveLimits(t *testing.T) {
+	stager := NewStager(t.TempDir(), 0)
+	r := buildZip(t,
+		zipFile{"a.pdf", "%PDF-1.4 " + strings.Repeat("a", 100)},
+		zipFile{"b.pdf", "%PDF-1.4 " + strings.Repeat("b", 100)},
+	)
+
+	if _, err := stager.StageZip(r, r.Size(), ArchiveLimits{MaxEntries: 1}); !errors.Is(err, dto.ErrBatchTooLarge) {
+		t.Errorf("expected ErrBatchTooLarge, got %v", err)
+	}
+	if _, err := stager.StageZip(r, r.Size(), ArchiveLimits{MaxTotalBytes: 150}); !errors.Is(err, dto.ErrArchiveTooLarge) {
+		t.Errorf("expected ErrArchiveTooLarge, got %v", err)
+	}
+
+	// Whole-archive limits are checked before anything is extracted.
+	if left, _ := os.ReadDir(stager.dir); len(left) != 0 {
+		t.Errorf("expected no staged files, found %d", len(left))
+	}
+}
+
+func TestStageZip_InvalidArchive(t *testing.T) {
+	stager := NewStager(t.TempDir(), 0)
+	r := bytes.NewReader([]byte("PK\x03\x04 truncated"))
+
+	if _, err := stager.StageZip(r, r.Size(), ArchiveLimits{}); !errors.Is(err, dto.ErrInvalidArchive) {
+		t.Errorf("expected ErrInvalidArchive, got %v", err)
+	}
+	if IsZip(bytes.NewReader([]byte("%PDF-1.4"))) {
+		t.Error("PDF detected as ZIP")
+	}
+}